@@ -0,0 +1,638 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BackupFormatVersion is the version of the layout of the directories written by Backup.
+const BackupFormatVersion = 1
+
+// BackupManifestFile is the name of the manifest file of a backup directory.
+const BackupManifestFile = "manifest.json"
+
+// DefaultAttachmentTimeout is the timeout of the transfers of the files attached to contract terms documents by
+// Backup and Restore, when neither an HTTP client nor a *DpxV1 client is specified.
+const DefaultAttachmentTimeout = 60 * time.Second
+
+// BackupManifest : The manifest of a backup directory, which describes every file of the backup.
+type BackupManifest struct {
+	// The version of the layout of the backup directory (see BackupFormatVersion).
+	FormatVersion int `json:"format_version"`
+
+	// The time of the first backup.
+	CreatedAt time.Time `json:"created_at"`
+
+	// The time of the last backup that changed the directory.
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// The files of the backup, keyed by their slash-separated path relative to the backup directory.
+	Entries map[string]*BackupEntry `json:"entries"`
+}
+
+// BackupEntry : A file of a backup directory.
+type BackupEntry struct {
+	// The kind of the file.
+	Kind string `json:"kind"`
+
+	// The ID of the data product.
+	DataProductID string `json:"data_product_id"`
+
+	// The ID of the draft or release (for all kinds except data_product).
+	VersionID string `json:"version_id,omitempty"`
+
+	// The state of the draft or release.
+	State string `json:"state,omitempty"`
+
+	// The ID of the contract terms (for contract_terms_document and attachment).
+	ContractTermsID string `json:"contract_terms_id,omitempty"`
+
+	// The ID of the contract terms document (for contract_terms_document and attachment).
+	DocumentID string `json:"document_id,omitempty"`
+
+	// The ID of the attachment of the contract terms document, if any.
+	AttachmentID string `json:"attachment_id,omitempty"`
+
+	// The SHA-256 hash of the content of the file, in hexadecimal.
+	SHA256 string `json:"sha256"`
+
+	// The published_at of a release. A release that was backed up with a published_at is not retrieved again
+	// unless its listed state changes (see BackupOptions.Full).
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+
+	// The latest updated_at of the parts of a draft or release.
+	PartsUpdatedAt *time.Time `json:"parts_updated_at,omitempty"`
+
+	// The time at which the file was last written.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Constants associated with the BackupEntry.Kind property.
+const (
+	BackupEntry_Kind_Attachment            = "attachment"
+	BackupEntry_Kind_ContractTermsDocument = "contract_terms_document"
+	BackupEntry_Kind_DataProduct           = "data_product"
+	BackupEntry_Kind_Draft                 = "draft"
+	BackupEntry_Kind_Release               = "release"
+)
+
+// BackupOptions : Options for Backup.
+type BackupOptions struct {
+	// The IDs of the data products to back up. Defaults to every data product (as listed by ListDataProducts).
+	DataProductIDs []string
+
+	// If true, the files attached to contract terms documents are not downloaded.
+	SkipAttachments bool
+
+	// Retrieve every release, rather than only the releases that are new or whose listed state changed since
+	// the previous backup. A full backup is needed to pick up the tags, domain and use cases patched with
+	// UpdateDataProductRelease, since the listed releases carry no modification timestamp.
+	Full bool
+
+	// The client used to download the files attached to contract terms documents from their (signed) URLs.
+	// Defaults to the HTTP client of the service if "client" is a *DpxV1, or else to a client whose timeout is
+	// DefaultAttachmentTimeout.
+	HTTPClient *http.Client
+}
+
+// BackupReport : The changes made to a backup directory by Backup.
+type BackupReport struct {
+	// The paths of the files that were written because they are new or changed.
+	Written []string
+
+	// The number of files whose content did not change.
+	Unchanged int
+
+	// The paths of the files that were removed because the corresponding resource no longer exists.
+	Removed []string
+}
+
+// LoadBackupManifest reads the manifest of the backup directory "dir". It returns an empty manifest if the
+// directory does not contain a backup.
+func LoadBackupManifest(dir string) (*BackupManifest, error) {
+	manifest := &BackupManifest{
+		FormatVersion: BackupFormatVersion,
+		Entries:       make(map[string]*BackupEntry),
+	}
+	data, err := os.ReadFile(filepath.Join(dir, BackupManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading backup manifest: %w", err)
+	}
+	err = json.Unmarshal(data, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling backup manifest: %w", err)
+	}
+	if manifest.FormatVersion > BackupFormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %d", manifest.FormatVersion)
+	}
+	if manifest.Entries == nil {
+		manifest.Entries = make(map[string]*BackupEntry)
+	}
+	return manifest, nil
+}
+
+// Backup writes a snapshot of the catalog to the directory "dir": every data product, draft and release
+// (available or retired), the metadata of their contract terms documents and the files attached to them.
+// Each resource is written as canonical JSON (indented, with sorted keys), and BackupManifestFile
+// describes every file with its SHA-256 hash. The layout of the directory is:
+//
+//	manifest.json
+//	data_products/<data product ID>/data_product.json
+//	data_products/<data product ID>/drafts/<draft ID>.json
+//	data_products/<data product ID>/releases/<release ID>.json
+//	data_products/<data product ID>/<drafts|releases>/<version ID>/documents/<contract terms ID>/<document ID>.json
+//	data_products/<data product ID>/<drafts|releases>/<version ID>/documents/<contract terms ID>/<document ID>.content
+//
+// Backup is incremental: only new or changed files are written (as determined by their content hashes),
+// so the directory can be committed to git or synced to object storage to keep a history of the catalog.
+// Every draft is retrieved. A release is published once, so a release that was already backed up with a
+// published_at is only retrieved again if its listed state changed (e.g. because it was retired), or if
+// options.Full is set to pick up the patches made with UpdateDataProductRelease. The documents of a version
+// whose content and parts (updated_at) are unchanged are not retrieved again, nor are the attachments that were
+// already downloaded. Files of resources that no longer exist are removed. Volatile fields (the signed
+// upload_url and, for attachments, url of documents, including those embedded in the drafts and releases)
+// are omitted so that they do not produce changes.
+func Backup(ctx context.Context, client DpxV1API, dir string, options *BackupOptions) (*BackupReport, error) {
+	if options == nil {
+		options = &BackupOptions{}
+	}
+	manifest, err := LoadBackupManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	b := &backup{
+		ctx:      ctx,
+		client:   client,
+		dir:      dir,
+		options:  options,
+		manifest: manifest,
+		seen:     make(map[string]bool),
+		report:   &BackupReport{},
+		now:      time.Now().UTC(),
+	}
+	err = b.run()
+	if saveErr := b.saveManifest(); err == nil {
+		err = saveErr
+	}
+	return b.report, err
+}
+
+// backup holds the state of a call to Backup.
+type backup struct {
+	ctx             context.Context
+	client          DpxV1API
+	dir             string
+	options         *BackupOptions
+	manifest        *BackupManifest
+	manifestChanged bool
+	seen            map[string]bool
+	report          *BackupReport
+	now             time.Time
+}
+
+func (b *backup) run() error {
+	dataProductIDs, err := listDataProductIDs(b.ctx, b.client, b.options.DataProductIDs)
+	if err != nil {
+		return err
+	}
+	for _, dataProductID := range dataProductIDs {
+		err = b.backupDataProduct(dataProductID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Remove the files of the resources that no longer exist.
+	var removed []string
+	for relPath, entry := range b.manifest.Entries {
+		if !b.seen[relPath] && (len(b.options.DataProductIDs) == 0 || slices.Contains(b.options.DataProductIDs, entry.DataProductID)) {
+			removed = append(removed, relPath)
+		}
+	}
+	sort.Strings(removed)
+	for _, relPath := range removed {
+		err = os.Remove(filepath.Join(b.dir, filepath.FromSlash(relPath)))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error removing %s: %w", relPath, err)
+		}
+		delete(b.manifest.Entries, relPath)
+		b.manifestChanged = true
+		b.report.Removed = append(b.report.Removed, relPath)
+	}
+	return nil
+}
+
+func (b *backup) backupDataProduct(dataProductID string) error {
+	dataProduct, _, err := b.client.GetDataProductWithContext(b.ctx, b.client.NewGetDataProductOptions(dataProductID))
+	if err != nil {
+		return err
+	}
+	dataProductDir := backupDataProductDir(dataProductID)
+	_, err = b.writeJSON(path.Join(dataProductDir, "data_product.json"), dataProduct, &BackupEntry{
+		Kind:          BackupEntry_Kind_DataProduct,
+		DataProductID: dataProductID,
+	})
+	if err != nil {
+		return err
+	}
+
+	draftsPager, err := b.client.NewDataProductDraftsPager(b.client.NewListDataProductDraftsOptions(dataProductID))
+	if err != nil {
+		return err
+	}
+	drafts, err := draftsPager.GetAllWithContext(b.ctx)
+	if err != nil {
+		return err
+	}
+	for i := range drafts {
+		err = b.backupVersion(dataProductID, &drafts[i], true)
+		if err != nil {
+			return err
+		}
+	}
+
+	releasesOptions := b.client.NewListDataProductReleasesOptions(dataProductID)
+	releasesOptions.SetState([]string{ListDataProductReleasesOptions_State_Available, ListDataProductReleasesOptions_State_Retired})
+	releasesPager, err := b.client.NewDataProductReleasesPager(releasesOptions)
+	if err != nil {
+		return err
+	}
+	releases, err := releasesPager.GetAllWithContext(b.ctx)
+	if err != nil {
+		return err
+	}
+	for i := range releases {
+		err = b.backupVersion(dataProductID, &releases[i], false)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *backup) backupVersion(dataProductID string, summary *DataProductVersionSummary, isDraft bool) error {
+	versionID := stringValue(summary.ID)
+	versionDir := backupVersionDir(dataProductID, versionID, isDraft)
+	versionPath := versionDir + ".json"
+	previous := b.manifest.Entries[versionPath]
+	if !isDraft && !b.options.Full && previous != nil && previous.PublishedAt != nil && previous.State == stringValue(summary.State) {
+		if _, err := os.Stat(filepath.Join(b.dir, filepath.FromSlash(versionPath))); err == nil {
+			b.markSeen(versionPath)
+			b.report.Unchanged++
+			return nil
+		}
+	}
+
+	var version *DataProductVersion
+	var err error
+	kind := BackupEntry_Kind_Release
+	if isDraft {
+		kind = BackupEntry_Kind_Draft
+		version, _, err = b.client.GetDataProductDraftWithContext(b.ctx, b.client.NewGetDataProductDraftOptions(dataProductID, versionID))
+	} else {
+		version, _, err = b.client.GetDataProductReleaseWithContext(b.ctx, b.client.NewGetDataProductReleaseOptions(dataProductID, versionID))
+	}
+	if err != nil {
+		return err
+	}
+	entry := &BackupEntry{
+		Kind:           kind,
+		DataProductID:  dataProductID,
+		VersionID:      versionID,
+		State:          stringValue(version.State),
+		PartsUpdatedAt: latestPartUpdate(version),
+	}
+	if version.PublishedAt != nil {
+		publishedAt := time.Time(*version.PublishedAt).UTC()
+		entry.PublishedAt = &publishedAt
+	}
+	changed, err := b.writeJSON(versionPath, backupVersionModel(version), entry)
+	if err != nil {
+		return err
+	}
+	if !changed && previous != nil && timesEqual(previous.PartsUpdatedAt, entry.PartsUpdatedAt) {
+		// The documents are described by the version itself, so they didn't change either.
+		b.markSeen(versionPath)
+		return nil
+	}
+
+	for _, contractTerms := range version.ContractTerms {
+		for _, document := range contractTerms.Documents {
+			err = b.backupDocument(dataProductID, versionID, isDraft, stringValue(contractTerms.ID), stringValue(document.ID))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *backup) backupDocument(dataProductID string, versionID string, isDraft bool, contractTermsID string, documentID string) error {
+	var document *ContractTermsDocument
+	var err error
+	if isDraft {
+		document, _, err = b.client.GetDraftContractTermsDocumentWithContext(b.ctx,
+			b.client.NewGetDraftContractTermsDocumentOptions(dataProductID, versionID, contractTermsID, documentID))
+	} else {
+		document, _, err = b.client.GetReleaseContractTermsDocumentWithContext(b.ctx,
+			b.client.NewGetReleaseContractTermsDocumentOptions(dataProductID, versionID, contractTermsID, documentID))
+	}
+	if err != nil {
+		return err
+	}
+
+	entry := &BackupEntry{
+		Kind:            BackupEntry_Kind_ContractTermsDocument,
+		DataProductID:   dataProductID,
+		VersionID:       versionID,
+		ContractTermsID: contractTermsID,
+		DocumentID:      documentID,
+	}
+	if document.Attachment != nil {
+		entry.AttachmentID = stringValue(document.Attachment.ID)
+	}
+	documentPath := backupDocumentPath(dataProductID, versionID, isDraft, contractTermsID, documentID)
+	_, err = b.writeJSON(documentPath+".json", backupDocumentModel(document), entry)
+	if err != nil {
+		return err
+	}
+	if document.Attachment == nil || document.URL == nil {
+		return nil
+	}
+
+	contentPath := documentPath + ".content"
+	if b.options.SkipAttachments {
+		// Keep any previously downloaded attachment.
+		b.seen[contentPath] = true
+		return nil
+	}
+	previous := b.manifest.Entries[contentPath]
+	if previous != nil && entry.AttachmentID != "" && previous.AttachmentID == entry.AttachmentID {
+		b.markSeen(contentPath)
+		b.report.Unchanged++
+		return nil
+	}
+	content, err := b.download(*document.URL)
+	if err != nil {
+		return fmt.Errorf("error downloading the attachment of document %s: %w", documentID, err)
+	}
+	contentEntry := *entry
+	contentEntry.Kind = BackupEntry_Kind_Attachment
+	_, err = b.write(contentPath, content, &contentEntry)
+	return err
+}
+
+// download returns the content at "url".
+func (b *backup) download(url string) ([]byte, error) {
+	httpClient := attachmentHTTPClient(b.client, b.options.HTTPClient)
+	request, err := http.NewRequestWithContext(b.ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %s", response.Status)
+	}
+	return io.ReadAll(response.Body)
+}
+
+// writeJSON writes "model" as canonical JSON to the file at "relPath" (see write).
+func (b *backup) writeJSON(relPath string, model interface{}, entry *BackupEntry) (bool, error) {
+	data, err := canonicalJSON(model)
+	if err != nil {
+		return false, err
+	}
+	return b.write(relPath, data, entry)
+}
+
+// write writes "data" to the file at "relPath" unless it already has that content, and records "entry" for
+// it in the manifest. It returns true if the file was written.
+func (b *backup) write(relPath string, data []byte, entry *BackupEntry) (bool, error) {
+	b.seen[relPath] = true
+	sum := sha256.Sum256(data)
+	entry.SHA256 = hex.EncodeToString(sum[:])
+	filePath := filepath.Join(b.dir, filepath.FromSlash(relPath))
+
+	previous := b.manifest.Entries[relPath]
+	if previous != nil && previous.SHA256 == entry.SHA256 {
+		if _, err := os.Stat(filePath); err == nil {
+			entry.UpdatedAt = previous.UpdatedAt
+			if !sameBackupEntry(previous, entry) {
+				b.manifest.Entries[relPath] = entry
+				b.manifestChanged = true
+			}
+			b.report.Unchanged++
+			return false, nil
+		}
+	}
+
+	err := os.MkdirAll(filepath.Dir(filePath), 0o755)
+	if err == nil {
+		err = writeFileAtomic(filePath, data)
+	}
+	if err != nil {
+		return false, fmt.Errorf("error writing %s: %w", relPath, err)
+	}
+	entry.UpdatedAt = b.now
+	b.manifest.Entries[relPath] = entry
+	b.manifestChanged = true
+	b.report.Written = append(b.report.Written, relPath)
+	return true, nil
+}
+
+// markSeen records that the file at "relPath" and the files in the directory of the same name (without the
+// extension) are still current.
+func (b *backup) markSeen(relPath string) {
+	b.seen[relPath] = true
+	prefix := strings.TrimSuffix(relPath, path.Ext(relPath)) + "/"
+	for entryPath := range b.manifest.Entries {
+		if strings.HasPrefix(entryPath, prefix) {
+			b.seen[entryPath] = true
+		}
+	}
+}
+
+func (b *backup) saveManifest() error {
+	if !b.manifestChanged {
+		return nil
+	}
+	if b.manifest.CreatedAt.IsZero() {
+		b.manifest.CreatedAt = b.now
+	}
+	b.manifest.UpdatedAt = b.now
+	b.manifest.FormatVersion = BackupFormatVersion
+	data, err := canonicalJSON(b.manifest)
+	if err == nil {
+		err = os.MkdirAll(b.dir, 0o755)
+	}
+	if err == nil {
+		err = writeFileAtomic(filepath.Join(b.dir, BackupManifestFile), data)
+	}
+	if err != nil {
+		return fmt.Errorf("error writing backup manifest: %w", err)
+	}
+	return nil
+}
+
+// backupDataProductDir returns the path of the directory of a data product in a backup directory.
+func backupDataProductDir(dataProductID string) string {
+	return path.Join("data_products", backupPathSegment(dataProductID))
+}
+
+// backupVersionDir returns the path of a draft or release in a backup directory, without the extension.
+func backupVersionDir(dataProductID string, versionID string, isDraft bool) string {
+	folder := "releases"
+	if isDraft {
+		folder = "drafts"
+	}
+	return path.Join(backupDataProductDir(dataProductID), folder, backupPathSegment(versionID))
+}
+
+// backupDocumentPath returns the path of a contract terms document in a backup directory, without the extension.
+func backupDocumentPath(dataProductID string, versionID string, isDraft bool, contractTermsID string, documentID string) string {
+	return path.Join(backupVersionDir(dataProductID, versionID, isDraft), "documents",
+		backupPathSegment(contractTermsID), backupPathSegment(documentID))
+}
+
+// backupPathSegment returns "id" escaped for use as a file name.
+func backupPathSegment(id string) string {
+	segment := url.PathEscape(id)
+	if strings.Trim(segment, ".") == "" {
+		segment = "_" + strings.ReplaceAll(segment, ".", "%2E")
+	}
+	return segment
+}
+
+// backupDocumentModel returns "document" without its volatile fields.
+func backupDocumentModel(document *ContractTermsDocument) *ContractTermsDocument {
+	documentCopy := *document
+	documentCopy.UploadURL = nil
+	if documentCopy.Attachment != nil {
+		documentCopy.URL = nil
+	}
+	return &documentCopy
+}
+
+// backupVersionModel returns "version" with the volatile fields of its contract terms documents omitted.
+func backupVersionModel(version *DataProductVersion) *DataProductVersion {
+	versionCopy := *version
+	if version.ContractTerms != nil {
+		versionCopy.ContractTerms = make([]DataProductContractTerms, len(version.ContractTerms))
+		for i, contractTerms := range version.ContractTerms {
+			if contractTerms.Documents != nil {
+				documents := make([]ContractTermsDocument, len(contractTerms.Documents))
+				for j := range contractTerms.Documents {
+					documents[j] = *backupDocumentModel(&contractTerms.Documents[j])
+				}
+				contractTerms.Documents = documents
+			}
+			versionCopy.ContractTerms[i] = contractTerms
+		}
+	}
+	return &versionCopy
+}
+
+// canonicalJSON returns "model" as indented JSON with sorted keys, terminated by a newline.
+func canonicalJSON(model interface{}) ([]byte, error) {
+	data, err := json.Marshal(model)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling %T: %w", model, err)
+	}
+	// Round-trip through a generic value so that the keys of objects are sorted.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	err = decoder.Decode(&value)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling %T: %w", model, err)
+	}
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(value)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling %T: %w", model, err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// attachmentHTTPClient returns the client that transfers the files attached to contract terms documents:
+// "httpClient" if set, or else the HTTP client of the service, or else a client with DefaultAttachmentTimeout.
+func attachmentHTTPClient(client DpxV1API, httpClient *http.Client) *http.Client {
+	if httpClient != nil {
+		return httpClient
+	}
+	if dpx, ok := client.(*DpxV1); ok && dpx.Service != nil {
+		if serviceClient := dpx.Service.GetHTTPClient(); serviceClient != nil {
+			return serviceClient
+		}
+	}
+	return &http.Client{Timeout: DefaultAttachmentTimeout}
+}
+
+// latestPartUpdate returns the latest updated_at of the parts of "version", or nil if none is set.
+func latestPartUpdate(version *DataProductVersion) (latest *time.Time) {
+	for _, part := range version.PartsOut {
+		if part.UpdatedAt != nil {
+			updatedAt := time.Time(*part.UpdatedAt).UTC()
+			if latest == nil || updatedAt.After(*latest) {
+				latest = &updatedAt
+			}
+		}
+	}
+	return
+}
+
+// timesEqual returns true if "a" and "b" are both nil or are the same instant.
+func timesEqual(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// sameBackupEntry returns true if two entries have the same JSON representation.
+func sameBackupEntry(a *BackupEntry, b *BackupEntry) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(dataA, dataB)
+}
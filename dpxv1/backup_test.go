@@ -0,0 +1,224 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testRelease returns a published release with parts and contract terms documents, one of which has an attachment.
+func testRelease(dataProductID string, releaseID string) *dpxv1.DataProductVersion {
+	release := testVersion(dataProductID, releaseID, dpxv1.DataProductVersion_State_Available, "Sales")
+	publishedAt, _ := core.ParseDateTime("2024-02-10T00:00:00.000Z")
+	release.PublishedAt = &publishedAt
+	updatedAt, _ := core.ParseDateTime("2024-02-09T00:00:00.000Z")
+	release.PartsOut = []dpxv1.DataProductPart{{
+		Asset:     &dpxv1.AssetPartReference{ID: core.StringPtr("part-1"), Container: release.Asset.Container},
+		UpdatedAt: &updatedAt,
+	}}
+	release.ContractTerms = []dpxv1.DataProductContractTerms{{
+		ID: core.StringPtr("terms-1"),
+		Documents: []dpxv1.ContractTermsDocument{
+			{
+				ID:         core.StringPtr("document-1"),
+				Name:       core.StringPtr("SLA"),
+				Type:       core.StringPtr(dpxv1.ContractTermsDocument_Type_Sla),
+				Attachment: &dpxv1.ContractTermsDocumentAttachment{ID: core.StringPtr("attachment-1")},
+			},
+			{
+				ID:   core.StringPtr("document-2"),
+				Name: core.StringPtr("Terms"),
+				Type: core.StringPtr(dpxv1.ContractTermsDocument_Type_TermsAndConditions),
+				URL:  core.StringPtr("https://example.com/terms"),
+			},
+		},
+	}}
+	return release
+}
+
+var _ = Describe(`Backup`, func() {
+	const releaseDir = "data_products/product-1/releases/release-1"
+	var server *catalogServer
+	var dpxService *dpxv1.DpxV1
+	var dir string
+
+	countRequests := func(prefix string) (count int) {
+		for _, request := range server.requestLog() {
+			if strings.HasPrefix(request, prefix) {
+				count++
+			}
+		}
+		return
+	}
+	readFile := func(relPath string) string {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(relPath)))
+		Expect(err).To(BeNil())
+		return string(data)
+	}
+
+	BeforeEach(func() {
+		server = newCatalogServer()
+		dpxService = server.newService()
+		server.put(testRelease("product-1", "release-1"))
+		server.put(testVersion("product-1", "draft-1", dpxv1.DataProductVersion_State_Draft, "Sales"))
+		server.putFile("attachment-1", []byte("%PDF sla"))
+
+		var err error
+		dir, err = os.MkdirTemp("", "dpx-backup")
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	It(`Writes every resource as canonical JSON with a manifest`, func() {
+		report, err := dpxv1.Backup(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		Expect(report.Written).To(ConsistOf(
+			"data_products/product-1/data_product.json",
+			"data_products/product-1/drafts/draft-1.json",
+			releaseDir+".json",
+			releaseDir+"/documents/terms-1/document-1.json",
+			releaseDir+"/documents/terms-1/document-1.content",
+			releaseDir+"/documents/terms-1/document-2.json",
+		))
+
+		Expect(readFile(releaseDir + ".json")).To(HavePrefix("{\n  \"asset\": {\n"))
+		Expect(readFile(releaseDir + "/documents/terms-1/document-1.content")).To(Equal("%PDF sla"))
+		document := readFile(releaseDir + "/documents/terms-1/document-1.json")
+		Expect(document).ToNot(ContainSubstring("url"))
+		Expect(document).To(ContainSubstring(`"attachment-1"`))
+		Expect(readFile(releaseDir + "/documents/terms-1/document-2.json")).To(ContainSubstring(`"url": "https://example.com/terms"`))
+
+		manifest, err := dpxv1.LoadBackupManifest(dir)
+		Expect(err).To(BeNil())
+		Expect(manifest.FormatVersion).To(Equal(dpxv1.BackupFormatVersion))
+		Expect(manifest.Entries).To(HaveLen(6))
+		entry := manifest.Entries[releaseDir+".json"]
+		Expect(entry.Kind).To(Equal(dpxv1.BackupEntry_Kind_Release))
+		Expect(entry.State).To(Equal(dpxv1.DataProductVersion_State_Available))
+		Expect(entry.PublishedAt.Format("2006-01-02")).To(Equal("2024-02-10"))
+		Expect(entry.PartsUpdatedAt.Format("2006-01-02")).To(Equal("2024-02-09"))
+		Expect(entry.SHA256).To(HaveLen(64))
+		attachment := manifest.Entries[releaseDir+"/documents/terms-1/document-1.content"]
+		Expect(attachment.Kind).To(Equal(dpxv1.BackupEntry_Kind_Attachment))
+		Expect(attachment.ContractTermsID).To(Equal("terms-1"))
+		Expect(attachment.AttachmentID).To(Equal("attachment-1"))
+	})
+	It(`Writes only what changed on later runs`, func() {
+		_, err := dpxv1.Backup(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		manifestBefore := readFile(dpxv1.BackupManifestFile)
+		releaseGets := countRequests("GET /data_product_exchange/v1/data_products/product-1/releases/")
+
+		report, err := dpxv1.Backup(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		Expect(report.Written).To(BeEmpty())
+		Expect(report.Removed).To(BeEmpty())
+		Expect(report.Unchanged).To(Equal(3))
+		Expect(countRequests("GET /data_product_exchange/v1/data_products/product-1/releases/")).To(Equal(releaseGets))
+		Expect(readFile(dpxv1.BackupManifestFile)).To(Equal(manifestBefore))
+
+		retired := testRelease("product-1", "release-1")
+		retired.State = core.StringPtr(dpxv1.DataProductVersion_State_Retired)
+		server.put(retired)
+		server.remove("product-1", "draft-1")
+		server.put(testVersion("product-1", "draft-2", dpxv1.DataProductVersion_State_Draft, "Returns"))
+		report, err = dpxv1.Backup(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		Expect(report.Written).To(ConsistOf(releaseDir+".json", "data_products/product-1/drafts/draft-2.json"))
+		Expect(report.Removed).To(Equal([]string{"data_products/product-1/drafts/draft-1.json"}))
+		Expect(countRequests("GET /files/")).To(Equal(1))
+		Expect(readFile(releaseDir + ".json")).To(ContainSubstring(`"state": "retired"`))
+		_, err = os.Stat(filepath.Join(dir, "data_products", "product-1", "drafts", "draft-1.json"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+
+		manifest, err := dpxv1.LoadBackupManifest(dir)
+		Expect(err).To(BeNil())
+		Expect(manifest.Entries).To(HaveLen(6))
+		Expect(manifest.Entries[releaseDir+".json"].State).To(Equal(dpxv1.DataProductVersion_State_Retired))
+	})
+	It(`Omits the signed URLs of the documents embedded in the versions`, func() {
+		draft := testRelease("product-1", "draft-1")
+		draft.State = core.StringPtr(dpxv1.DataProductVersion_State_Draft)
+		draft.PublishedAt = nil
+		server.put(draft)
+		_, err := dpxv1.Backup(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		draftBefore := readFile("data_products/product-1/drafts/draft-1.json")
+		releaseBefore := readFile(releaseDir + ".json")
+		Expect(draftBefore).ToNot(ContainSubstring("upload"))
+		Expect(draftBefore).ToNot(ContainSubstring("signature"))
+		Expect(draftBefore).To(ContainSubstring(`"url": "https://example.com/terms"`))
+
+		report, err := dpxv1.Backup(context.Background(), dpxService, dir, &dpxv1.BackupOptions{Full: true})
+		Expect(err).To(BeNil())
+		Expect(report.Written).To(BeEmpty())
+		Expect(readFile("data_products/product-1/drafts/draft-1.json")).To(Equal(draftBefore))
+		Expect(readFile(releaseDir + ".json")).To(Equal(releaseBefore))
+	})
+	It(`Writes the releases that were patched since the previous run`, func() {
+		_, err := dpxv1.Backup(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+
+		patched := testRelease("product-1", "release-1")
+		patched.Tags = []string{"finance"}
+		patched.Domain = &dpxv1.Domain{ID: core.StringPtr("domain-1"), Name: core.StringPtr("Sales")}
+		server.put(patched)
+		report, err := dpxv1.Backup(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		Expect(report.Written).To(BeEmpty())
+
+		report, err = dpxv1.Backup(context.Background(), dpxService, dir, &dpxv1.BackupOptions{Full: true})
+		Expect(err).To(BeNil())
+		Expect(report.Written).To(Equal([]string{releaseDir + ".json"}))
+		Expect(readFile(releaseDir + ".json")).To(ContainSubstring(`"finance"`))
+		Expect(readFile(releaseDir + ".json")).To(ContainSubstring(`"domain-1"`))
+	})
+	It(`Downloads the attachments with the HTTP client of the service`, func() {
+		transport := &pathRecordingTransport{}
+		dpxService.Service.GetHTTPClient().Transport = transport
+		_, err := dpxv1.Backup(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		Expect(transport.paths()).To(ContainElement(HavePrefix("/files/attachment-1")))
+	})
+	It(`Skips attachments and backs up only the specified data products`, func() {
+		server.put(testVersion("product-2", "draft-1", dpxv1.DataProductVersion_State_Draft, "Other"))
+		report, err := dpxv1.Backup(context.Background(), dpxService, dir, &dpxv1.BackupOptions{
+			DataProductIDs:  []string{"product-1"},
+			SkipAttachments: true,
+		})
+		Expect(err).To(BeNil())
+		Expect(report.Written).To(HaveLen(5))
+		Expect(report.Written).ToNot(ContainElement(ContainSubstring("product-2")))
+		Expect(countRequests("GET /files/")).To(Equal(0))
+	})
+	It(`Returns the error of a failed request`, func() {
+		_, err := dpxv1.Backup(context.Background(), dpxService, dir, &dpxv1.BackupOptions{DataProductIDs: []string{"missing"}})
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("Resource not found"))
+	})
+})
@@ -0,0 +1,276 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// DefaultBulkConcurrency is the default maximum number of items of a bulk operation that are processed concurrently.
+const DefaultBulkConcurrency = 4
+
+// BulkOptions : Options for the bulk operations (BulkCreateDataProducts, BulkUpdateDrafts and BulkPublishDrafts).
+type BulkOptions struct {
+	// The maximum number of items that are processed concurrently. Defaults to DefaultBulkConcurrency.
+	Concurrency int
+
+	// An optional function that is called (from the worker goroutines) as each item completes,
+	// e.g. to report progress.
+	OnResult func(result BulkItemResult)
+}
+
+// BulkItemError : The error of an item of a bulk operation that failed.
+type BulkItemError struct {
+	// The index of the item in the bulk operation's input.
+	Index int
+
+	// The operation that failed (e.g. "PublishDataProductDraft").
+	Operation string
+
+	// The HTTP status code of the response, or 0 if no response was received.
+	StatusCode int
+
+	// The code of the first error reported by the service (see ErrorModelResource), if any.
+	Code string
+
+	// The ID that identifies the failed request in the service's logs, if any.
+	Trace string
+
+	// The error returned by the operation.
+	Err error
+}
+
+// Error implements the error interface.
+func (bulkErr *BulkItemError) Error() string {
+	return fmt.Sprintf("item %d: %s failed: %s", bulkErr.Index, bulkErr.Operation, bulkErr.Err.Error())
+}
+
+// Unwrap returns the error returned by the operation.
+func (bulkErr *BulkItemError) Unwrap() error {
+	return bulkErr.Err
+}
+
+// BulkItemResult : The result of one item of a bulk operation.
+type BulkItemResult struct {
+	// The index of the item in the bulk operation's input.
+	Index int
+
+	// The ID of the data product that was created or whose draft was updated or published.
+	DataProductID string
+
+	// The ID of the draft that was created, updated or published.
+	DraftID string
+
+	// The ID of the release that was published.
+	ReleaseID string
+
+	// The data product returned by CreateDataProduct, or nil.
+	DataProduct *DataProduct
+
+	// The data product version returned by UpdateDataProductDraft or PublishDataProductDraft, or nil.
+	Version *DataProductVersion
+
+	// The error of a failed item (a *BulkItemError), or nil if the item succeeded.
+	Err error
+}
+
+// BulkReport : The report of a bulk operation, with one result per input item.
+type BulkReport struct {
+	// The operation performed for each item (e.g. "CreateDataProduct").
+	Operation string
+
+	// The result of each item, in the order of the input items.
+	Results []BulkItemResult
+}
+
+// Succeeded returns the results of the items that succeeded.
+func (report *BulkReport) Succeeded() (results []BulkItemResult) {
+	for _, result := range report.Results {
+		if result.Err == nil {
+			results = append(results, result)
+		}
+	}
+	return
+}
+
+// Failed returns the results of the items that failed.
+func (report *BulkReport) Failed() (results []BulkItemResult) {
+	for _, result := range report.Results {
+		if result.Err != nil {
+			results = append(results, result)
+		}
+	}
+	return
+}
+
+// Err returns an error that joins the errors of the items that failed, or nil if every item succeeded.
+func (report *BulkReport) Err() error {
+	var errs []error
+	for _, result := range report.Results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// errBulkNoResult is recorded for an item whose operation succeeded without returning a result.
+var errBulkNoResult = errors.New("the operation returned no result")
+
+// BulkCreateDataProducts creates a data product for each of the specified options, processing up to
+// options.Concurrency items at a time. Every item is attempted (unless "ctx" is cancelled) and its outcome is
+// recorded in the returned report, including the IDs of the new data product and of its first draft.
+// Client-side rate limits (see DpxV1.SetRateLimiter) are applied to every request.
+func BulkCreateDataProducts(ctx context.Context, client DpxV1API, items []*CreateDataProductOptions, options *BulkOptions) *BulkReport {
+	return runBulk(ctx, "CreateDataProduct", items, options, func(ctx context.Context, item *CreateDataProductOptions, result *BulkItemResult) (*core.DetailedResponse, error) {
+		dataProduct, response, err := client.CreateDataProductWithContext(ctx, item)
+		if err != nil {
+			return response, err
+		}
+		if dataProduct == nil {
+			return response, errBulkNoResult
+		}
+		result.DataProduct = dataProduct
+		result.DataProductID = stringValue(dataProduct.ID)
+		if len(dataProduct.Drafts) > 0 {
+			result.DraftID = stringValue(dataProduct.Drafts[0].ID)
+		}
+		return response, nil
+	})
+}
+
+// BulkUpdateDrafts updates a data product draft for each of the specified options, processing up to
+// options.Concurrency items at a time. Every item is attempted (unless "ctx" is cancelled) and its outcome is
+// recorded in the returned report. Client-side rate limits (see DpxV1.SetRateLimiter) are applied to every request.
+func BulkUpdateDrafts(ctx context.Context, client DpxV1API, items []*UpdateDataProductDraftOptions, options *BulkOptions) *BulkReport {
+	return runBulk(ctx, "UpdateDataProductDraft", items, options, func(ctx context.Context, item *UpdateDataProductDraftOptions, result *BulkItemResult) (*core.DetailedResponse, error) {
+		if item != nil {
+			result.DataProductID = stringValue(item.DataProductID)
+			result.DraftID = stringValue(item.DraftID)
+		}
+		version, response, err := client.UpdateDataProductDraftWithContext(ctx, item)
+		if err != nil {
+			return response, err
+		}
+		if version == nil {
+			return response, errBulkNoResult
+		}
+		result.Version = version
+		return response, nil
+	})
+}
+
+// BulkPublishDrafts publishes a data product draft for each of the specified options, processing up to
+// options.Concurrency items at a time. Every item is attempted (unless "ctx" is cancelled) and its outcome is
+// recorded in the returned report, including the ID of the new release.
+// Client-side rate limits (see DpxV1.SetRateLimiter) are applied to every request.
+func BulkPublishDrafts(ctx context.Context, client DpxV1API, items []*PublishDataProductDraftOptions, options *BulkOptions) *BulkReport {
+	return runBulk(ctx, "PublishDataProductDraft", items, options, func(ctx context.Context, item *PublishDataProductDraftOptions, result *BulkItemResult) (*core.DetailedResponse, error) {
+		if item != nil {
+			result.DataProductID = stringValue(item.DataProductID)
+			result.DraftID = stringValue(item.DraftID)
+		}
+		version, response, err := client.PublishDataProductDraftWithContext(ctx, item)
+		if err != nil {
+			return response, err
+		}
+		if version == nil {
+			return response, errBulkNoResult
+		}
+		result.Version = version
+		result.ReleaseID = stringValue(version.ID)
+		return response, nil
+	})
+}
+
+// runBulk processes "items" with a pool of workers, calling "process" for each item, and returns the report.
+func runBulk[T any](ctx context.Context, operationID string, items []T, options *BulkOptions,
+	process func(ctx context.Context, item T, result *BulkItemResult) (*core.DetailedResponse, error)) *BulkReport {
+	if options == nil {
+		options = &BulkOptions{}
+	}
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+	if concurrency > len(items) {
+		concurrency = len(items)
+	}
+
+	report := &BulkReport{
+		Operation: operationID,
+		Results:   make([]BulkItemResult, len(items)),
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				result := &report.Results[index]
+				result.Index = index
+
+				var response *core.DetailedResponse
+				err := ctx.Err()
+				if err == nil {
+					response, err = process(ctx, items[index], result)
+				}
+				if err != nil {
+					result.Err = newBulkItemError(index, operationID, response, err)
+				}
+				if options.OnResult != nil {
+					options.OnResult(*result)
+				}
+			}
+		}()
+	}
+	for index := range items {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+	return report
+}
+
+// newBulkItemError returns a *BulkItemError for an item whose operation failed with "err".
+func newBulkItemError(index int, operationID string, response *core.DetailedResponse, err error) *BulkItemError {
+	bulkErr := &BulkItemError{
+		Index:     index,
+		Operation: operationID,
+		Err:       err,
+	}
+	if response != nil {
+		bulkErr.StatusCode = response.StatusCode
+		bulkErr.Code = getResponseErrorCode(response)
+		bulkErr.Trace = getResponseTrace(response)
+	}
+	return bulkErr
+}
+
+// stringValue returns the value of "s", or "" if it is nil.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
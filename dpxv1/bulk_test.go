@@ -0,0 +1,182 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1/dpxv1mock"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Bulk operations`, func() {
+	var mock *dpxv1mock.MockDpxV1
+
+	BeforeEach(func() {
+		mock = dpxv1mock.NewMockDpxV1()
+	})
+
+	publishOptions := func(count int) (items []*dpxv1.PublishDataProductDraftOptions) {
+		for i := 0; i < count; i++ {
+			items = append(items, mock.NewPublishDataProductDraftOptions("product-1", fmt.Sprintf("draft-%d", i)))
+		}
+		return
+	}
+
+	It(`Publishes drafts with bounded concurrency and reports every item`, func() {
+		var inFlight, maxInFlight int32
+		mock.PublishDataProductDraftFunc = func(ctx context.Context, options *dpxv1.PublishDataProductDraftOptions) (*dpxv1.DataProductVersion, *core.DetailedResponse, error) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				observed := atomic.LoadInt32(&maxInFlight)
+				if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			if *options.DraftID == "draft-3" {
+				response := &core.DetailedResponse{
+					StatusCode: 409,
+					Result: map[string]interface{}{
+						"errors": []interface{}{map[string]interface{}{"code": "already_exists", "message": "Conflict"}},
+						"trace":  "trace-3",
+					},
+				}
+				return nil, response, errors.New("Conflict")
+			}
+			return &dpxv1.DataProductVersion{ID: core.StringPtr("release-for-" + *options.DraftID)}, &core.DetailedResponse{StatusCode: 200}, nil
+		}
+
+		var completed int32
+		report := dpxv1.BulkPublishDrafts(context.Background(), mock, publishOptions(10), &dpxv1.BulkOptions{
+			Concurrency: 3,
+			OnResult: func(result dpxv1.BulkItemResult) {
+				atomic.AddInt32(&completed, 1)
+			},
+		})
+		Expect(report.Operation).To(Equal("PublishDataProductDraft"))
+		Expect(report.Results).To(HaveLen(10))
+		Expect(atomic.LoadInt32(&maxInFlight)).To(BeNumerically("<=", 3))
+		Expect(atomic.LoadInt32(&completed)).To(Equal(int32(10)))
+		Expect(report.Succeeded()).To(HaveLen(9))
+		Expect(report.Results[0].ReleaseID).To(Equal("release-for-draft-0"))
+		Expect(report.Results[0].DraftID).To(Equal("draft-0"))
+
+		failed := report.Failed()
+		Expect(failed).To(HaveLen(1))
+		Expect(failed[0].Index).To(Equal(3))
+		var bulkErr *dpxv1.BulkItemError
+		Expect(errors.As(report.Err(), &bulkErr)).To(BeTrue())
+		Expect(bulkErr.Operation).To(Equal("PublishDataProductDraft"))
+		Expect(bulkErr.StatusCode).To(Equal(409))
+		Expect(bulkErr.Code).To(Equal("already_exists"))
+		Expect(bulkErr.Trace).To(Equal("trace-3"))
+	})
+	It(`Creates data products and reports their IDs`, func() {
+		mock.CreateDataProductFunc = func(ctx context.Context, options *dpxv1.CreateDataProductOptions) (*dpxv1.DataProduct, *core.DetailedResponse, error) {
+			return &dpxv1.DataProduct{
+				ID:     core.StringPtr("product-" + *options.Drafts[0].Name),
+				Drafts: []dpxv1.DataProductVersionSummary{{ID: core.StringPtr("draft-" + *options.Drafts[0].Name)}},
+			}, &core.DetailedResponse{StatusCode: 201}, nil
+		}
+		items := []*dpxv1.CreateDataProductOptions{
+			mock.NewCreateDataProductOptions([]dpxv1.DataProductVersionPrototype{{Name: core.StringPtr("a")}}),
+			mock.NewCreateDataProductOptions([]dpxv1.DataProductVersionPrototype{{Name: core.StringPtr("b")}}),
+		}
+		report := dpxv1.BulkCreateDataProducts(context.Background(), mock, items, nil)
+		Expect(report.Err()).To(BeNil())
+		Expect(report.Results[1].DataProductID).To(Equal("product-b"))
+		Expect(report.Results[1].DraftID).To(Equal("draft-b"))
+	})
+	It(`Records an error for the items that return no result`, func() {
+		mock.CreateDataProductFunc = func(ctx context.Context, options *dpxv1.CreateDataProductOptions) (*dpxv1.DataProduct, *core.DetailedResponse, error) {
+			return nil, &core.DetailedResponse{StatusCode: 201}, nil
+		}
+		mock.PublishDataProductDraftFunc = func(ctx context.Context, options *dpxv1.PublishDataProductDraftOptions) (*dpxv1.DataProductVersion, *core.DetailedResponse, error) {
+			return nil, &core.DetailedResponse{StatusCode: 200}, nil
+		}
+
+		report := dpxv1.BulkCreateDataProducts(context.Background(), mock, []*dpxv1.CreateDataProductOptions{
+			mock.NewCreateDataProductOptions([]dpxv1.DataProductVersionPrototype{{Name: core.StringPtr("a")}}),
+		}, nil)
+		Expect(report.Failed()).To(HaveLen(1))
+		Expect(report.Results[0].Err).To(MatchError(ContainSubstring("the operation returned no result")))
+
+		report = dpxv1.BulkPublishDrafts(context.Background(), mock, publishOptions(2), nil)
+		Expect(report.Failed()).To(HaveLen(2))
+		Expect(report.Results[1].DraftID).To(Equal("draft-1"))
+		Expect(report.Results[1].Err).To(MatchError(ContainSubstring("the operation returned no result")))
+
+		mock.UpdateDataProductDraftFunc = func(ctx context.Context, options *dpxv1.UpdateDataProductDraftOptions) (*dpxv1.DataProductVersion, *core.DetailedResponse, error) {
+			return nil, &core.DetailedResponse{StatusCode: 200}, nil
+		}
+		report = dpxv1.BulkUpdateDrafts(context.Background(), mock, []*dpxv1.UpdateDataProductDraftOptions{
+			mock.NewUpdateDataProductDraftOptions("product-1", "draft-0", []dpxv1.JSONPatchOperation{}),
+		}, nil)
+		Expect(report.Failed()).To(HaveLen(1))
+		Expect(report.Results[0].Version).To(BeNil())
+		Expect(report.Results[0].Err).To(MatchError(ContainSubstring("the operation returned no result")))
+	})
+	It(`Does not start items after the context is cancelled`, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		mock.UpdateDataProductDraftFunc = func(ctx context.Context, options *dpxv1.UpdateDataProductDraftOptions) (*dpxv1.DataProductVersion, *core.DetailedResponse, error) {
+			cancel()
+			return &dpxv1.DataProductVersion{}, &core.DetailedResponse{StatusCode: 200}, nil
+		}
+		items := []*dpxv1.UpdateDataProductDraftOptions{
+			mock.NewUpdateDataProductDraftOptions("product-1", "draft-1", nil),
+			mock.NewUpdateDataProductDraftOptions("product-1", "draft-2", nil),
+		}
+		report := dpxv1.BulkUpdateDrafts(ctx, mock, items, &dpxv1.BulkOptions{Concurrency: 1})
+		Expect(report.Results[0].Err).To(BeNil())
+		Expect(errors.Is(report.Results[1].Err, context.Canceled)).To(BeTrue())
+		Expect(report.Results[1].DraftID).To(BeEmpty())
+		Expect(mock.CallsTo("UpdateDataProductDraft")).To(HaveLen(1))
+	})
+	It(`Applies the client's rate limiter to every request`, func() {
+		var requestCount int32
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&requestCount, 1)
+			res.Header().Set("Content-type", "application/json")
+			res.WriteHeader(200)
+			fmt.Fprint(res, `{"version": "1.0.0", "state": "available", "id": "release-1", "name": "My Data Product"}`)
+		}))
+		defer testServer.Close()
+		dpxService, err := dpxv1.NewDpxV1(&dpxv1.DpxV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		dpxService.SetRateLimiter(dpxv1.NewRateLimiter(100, 1))
+
+		start := time.Now()
+		report := dpxv1.BulkPublishDrafts(context.Background(), dpxService, publishOptions(5), &dpxv1.BulkOptions{Concurrency: 5})
+		Expect(report.Err()).To(BeNil())
+		Expect(time.Since(start)).To(BeNumerically(">=", 35*time.Millisecond))
+		Expect(atomic.LoadInt32(&requestCount)).To(Equal(int32(5)))
+	})
+})
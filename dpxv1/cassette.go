@@ -0,0 +1,372 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+// RecorderMode : The mode of a Recorder.
+type RecorderMode string
+
+// Constants associated with the RecorderMode.
+const (
+	// Requests are sent to the service and the interactions are recorded.
+	RecorderModeRecord RecorderMode = "record"
+
+	// Requests are not sent; responses are replayed from the cassette.
+	RecorderModeReplay RecorderMode = "replay"
+)
+
+// ErrInteractionNotFound is returned (wrapped) by a replaying Recorder if the cassette
+// does not contain an interaction that matches a request.
+var ErrInteractionNotFound = errors.New("no matching interaction found in cassette")
+
+// Cassette : The interactions recorded by a Recorder.
+type Cassette struct {
+	// The recorded interactions, in the order in which they occurred.
+	Interactions []*CassetteInteraction `json:"interactions"`
+}
+
+// CassetteInteraction : A recorded request and its response.
+type CassetteInteraction struct {
+	// The operation on whose behalf the request was sent (e.g. "GetDataProduct").
+	Operation string `json:"operation,omitempty"`
+
+	// The path parameters of the operation.
+	PathParams map[string]string `json:"path_params,omitempty"`
+
+	// The request, with secrets redacted.
+	Request *CassetteRequest `json:"request"`
+
+	// The response, with secrets redacted.
+	Response *CassetteResponse `json:"response"`
+}
+
+// CassetteRequest : A recorded request.
+type CassetteRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// CassetteResponse : A recorded response.
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// RecorderOptions : Options for a Recorder.
+type RecorderOptions struct {
+	// The mode of the recorder. Defaults to RecorderModeReplay if the cassette file exists,
+	// and RecorderModeRecord otherwise.
+	Mode RecorderMode
+
+	// An optional function that scrubs additional data from each interaction before it is recorded.
+	// Credentials, tokens and pre-signed URLs are always redacted.
+	Scrub func(interaction *CassetteInteraction)
+}
+
+// Recorder records the HTTP traffic of DpxV1 operations to a cassette file and replays it, so that
+// tests can run deterministically without access to the service (see DpxV1.SetRecorder).
+//
+// Requests are matched to recorded interactions by operation and path parameters rather than by URL,
+// so a cassette can be replayed against any service URL. Interactions with the same operation and
+// path parameters are replayed in the order in which they were recorded; once they are exhausted,
+// the last one is replayed again.
+type Recorder struct {
+	path  string
+	mode  RecorderMode
+	scrub func(interaction *CassetteInteraction)
+
+	mu       sync.Mutex
+	cassette *Cassette
+	replayed map[string]int
+}
+
+// NewRecorder returns a new Recorder for the cassette file at "path".
+// Specify nil options to use the defaults.
+func NewRecorder(path string, options *RecorderOptions) (recorder *Recorder, err error) {
+	if options == nil {
+		options = &RecorderOptions{}
+	}
+	recorder = &Recorder{
+		path:     path,
+		mode:     options.Mode,
+		scrub:    options.Scrub,
+		cassette: &Cassette{},
+		replayed: make(map[string]int),
+	}
+	if recorder.mode == "" {
+		recorder.mode = RecorderModeRecord
+		if _, statErr := os.Stat(path); statErr == nil {
+			recorder.mode = RecorderModeReplay
+		}
+	}
+
+	switch recorder.mode {
+	case RecorderModeRecord:
+	case RecorderModeReplay:
+		var data []byte
+		data, err = os.ReadFile(path)
+		if err != nil {
+			err = fmt.Errorf("error reading cassette: %w", err)
+			return nil, err
+		}
+		err = json.Unmarshal(data, recorder.cassette)
+		if err != nil {
+			err = fmt.Errorf("error unmarshalling cassette %s: %w", path, err)
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown recorder mode: %s", recorder.mode)
+	}
+	return
+}
+
+// SetRecorder records or replays the HTTP traffic of this service instance with "recorder".
+// The recorder wraps the transport of the client that sends individual requests, so it should be set after
+// any other changes to the client (e.g. DisableSSLVerification). If retries are enabled (before or after
+// SetRecorder), the recorder is installed inside the retrying client, so each attempt is recorded separately.
+func (dpx *DpxV1) SetRecorder(recorder *Recorder) {
+	client := &http.Client{}
+	if current := dpx.Service.GetHTTPClient(); current != nil {
+		*client = *current
+	}
+	client.Transport = recorder.Transport(client.Transport)
+	if transport, ok := dpx.Service.Client.Transport.(*retryablehttp.RoundTripper); ok && transport.Client != nil {
+		transport.Client.HTTPClient = client
+		return
+	}
+	dpx.Service.SetHTTPClient(client)
+}
+
+// Mode returns the mode of the recorder.
+func (recorder *Recorder) Mode() RecorderMode {
+	return recorder.mode
+}
+
+// Interactions returns the interactions that have been recorded or loaded for replay.
+func (recorder *Recorder) Interactions() []*CassetteInteraction {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return append([]*CassetteInteraction(nil), recorder.cassette.Interactions...)
+}
+
+// Save writes the recorded interactions to the cassette file. It has no effect when replaying.
+func (recorder *Recorder) Save() error {
+	if recorder.mode != RecorderModeRecord {
+		return nil
+	}
+	recorder.mu.Lock()
+	data, err := json.MarshalIndent(recorder.cassette, "", "  ")
+	recorder.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("error marshalling cassette: %w", err)
+	}
+	err = os.WriteFile(recorder.path, append(data, '\n'), 0600)
+	if err != nil {
+		return fmt.Errorf("error writing cassette: %w", err)
+	}
+	return nil
+}
+
+// Transport returns an http.RoundTripper that records the requests sent through "next"
+// (or http.DefaultTransport if nil), or replays them without calling "next".
+func (recorder *Recorder) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &recorderTransport{recorder: recorder, next: next}
+}
+
+// recorderTransport is the http.RoundTripper returned by Recorder.Transport().
+type recorderTransport struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (transport *recorderTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if transport.recorder.mode == RecorderModeReplay {
+		return transport.recorder.replay(request)
+	}
+	return transport.recorder.record(request, transport.next)
+}
+
+// record sends "request" through "next" and records the interaction.
+func (recorder *Recorder) record(request *http.Request, next http.RoundTripper) (*http.Response, error) {
+	var requestBody []byte
+	if request.Body != nil && request.Body != http.NoBody {
+		var err error
+		requestBody, err = io.ReadAll(request.Body)
+		_ = request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		request.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	response, err := next.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	interaction := &CassetteInteraction{
+		Request: &CassetteRequest{
+			Method:  request.Method,
+			URL:     redactURL(request.URL.String()),
+			Headers: redactHeaders(request.Header),
+			Body:    scrubBody(requestBody),
+		},
+		Response: &CassetteResponse{
+			StatusCode: response.StatusCode,
+			Headers:    redactHeaders(response.Header),
+			Body:       scrubBody(responseBody),
+		},
+	}
+	if operation := getOperation(request.Context()); operation != nil {
+		interaction.Operation = operation.ID
+		if operation.PathParams != nil {
+			interaction.PathParams = make(map[string]string, len(operation.PathParams))
+			for name, value := range operation.PathParams {
+				interaction.PathParams[name] = value
+			}
+		}
+	}
+	if recorder.scrub != nil {
+		recorder.scrub(interaction)
+	}
+
+	recorder.mu.Lock()
+	recorder.cassette.Interactions = append(recorder.cassette.Interactions, interaction)
+	recorder.mu.Unlock()
+	return response, nil
+}
+
+// replay returns the recorded response to "request".
+func (recorder *Recorder) replay(request *http.Request) (*http.Response, error) {
+	if request.Body != nil {
+		_, _ = io.Copy(io.Discard, request.Body)
+		_ = request.Body.Close()
+	}
+
+	var key string
+	if operation := getOperation(request.Context()); operation != nil {
+		key = interactionKey(operation.ID, operation.PathParams, "", "")
+	} else {
+		key = interactionKey("", nil, request.Method, request.URL.Path)
+	}
+
+	recorder.mu.Lock()
+	var matches []*CassetteInteraction
+	for _, interaction := range recorder.cassette.Interactions {
+		if interaction.key() == key {
+			matches = append(matches, interaction)
+		}
+	}
+	if len(matches) == 0 {
+		recorder.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrInteractionNotFound, key)
+	}
+	index := recorder.replayed[key]
+	if index >= len(matches) {
+		index = len(matches) - 1
+	}
+	recorder.replayed[key] = index + 1
+	recorded := matches[index].Response
+	recorder.mu.Unlock()
+
+	header := recorded.Headers.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set("Content-Length", strconv.Itoa(len(recorded.Body)))
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       request,
+	}, nil
+}
+
+// key returns the key by which requests are matched to the interaction.
+func (interaction *CassetteInteraction) key() string {
+	if interaction.Operation != "" {
+		return interactionKey(interaction.Operation, interaction.PathParams, "", "")
+	}
+	method, path := "", ""
+	if interaction.Request != nil {
+		method = interaction.Request.Method
+		if parsedURL, err := url.Parse(interaction.Request.URL); err == nil {
+			path = parsedURL.Path
+		}
+	}
+	return interactionKey("", nil, method, path)
+}
+
+// interactionKey returns the key of an operation and its path parameters, or of a request that was
+// not sent on behalf of an operation (identified by its method and path).
+func interactionKey(operationID string, pathParams map[string]string, method string, path string) string {
+	if operationID == "" {
+		return method + " " + path
+	}
+	names := make([]string, 0, len(pathParams))
+	for name := range pathParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	params := make([]string, len(names))
+	for i, name := range names {
+		params[i] = url.QueryEscape(name) + "=" + url.QueryEscape(pathParams[name])
+	}
+	return operationID + "(" + strings.Join(params, ",") + ")"
+}
+
+// scrubBody returns "body" with its secrets redacted if it is a JSON document.
+func scrubBody(body []byte) string {
+	if json.Valid(body) {
+		return string(redactJSON(body))
+	}
+	return string(body)
+}
@@ -0,0 +1,174 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Recorder`, func() {
+	const presignedURL = `https://cos.example.com/bucket/terms.pdf?X-Amz-Signature=deadbeef`
+	var testServer *httptest.Server
+	var requestCount int32
+	var cassetteFile string
+
+	BeforeEach(func() {
+		atomic.StoreInt32(&requestCount, 0)
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			count := atomic.AddInt32(&requestCount, 1)
+			res.Header().Set("Content-type", "application/json")
+			switch req.Method {
+			case http.MethodPost:
+				res.WriteHeader(201)
+				fmt.Fprintf(res, `{"url": "https://example.com/terms", "type": "sdp", "name": "Terms", "id": "doc-1", "upload_url": %q}`, presignedURL)
+			default:
+				res.WriteHeader(200)
+				fmt.Fprintf(res, `{"version": "1.0.%d", "state": "draft", "id": "%s", "name": "My Data Product"}`, count, filepath.Base(req.URL.Path))
+			}
+		}))
+		dir, err := os.MkdirTemp("", "dpx-cassette")
+		Expect(err).To(BeNil())
+		cassetteFile = filepath.Join(dir, "cassette.json")
+	})
+	AfterEach(func() {
+		testServer.Close()
+		os.RemoveAll(filepath.Dir(cassetteFile))
+	})
+
+	newService := func(url string, recorder *dpxv1.Recorder) *dpxv1.DpxV1 {
+		dpxService, err := dpxv1.NewDpxV1(&dpxv1.DpxV1Options{
+			URL:           url,
+			Authenticator: &core.BearerTokenAuthenticator{BearerToken: "my-bearer-token"},
+		})
+		Expect(err).To(BeNil())
+		dpxService.SetRecorder(recorder)
+		return dpxService
+	}
+
+	It(`Records scrubbed interactions and replays them by operation and path params`, func() {
+		recorder, err := dpxv1.NewRecorder(cassetteFile, nil)
+		Expect(err).To(BeNil())
+		Expect(recorder.Mode()).To(Equal(dpxv1.RecorderModeRecord))
+
+		dpxService := newService(testServer.URL, recorder)
+		draft1, _, err := dpxService.GetDataProductDraft(dpxService.NewGetDataProductDraftOptions("product-1", "draft-1"))
+		Expect(err).To(BeNil())
+		draft2, _, err := dpxService.GetDataProductDraft(dpxService.NewGetDataProductDraftOptions("product-1", "draft-2"))
+		Expect(err).To(BeNil())
+		options := dpxService.NewCreateDraftContractTermsDocumentOptions("product-1", "draft-1", "terms-1", "sdp", "Terms", "doc-1", "https://example.com/terms")
+		options.SetUploadURL(presignedURL)
+		_, _, err = dpxService.CreateDraftContractTermsDocument(options)
+		Expect(err).To(BeNil())
+
+		Expect(recorder.Interactions()).To(HaveLen(3))
+		Expect(recorder.Save()).To(BeNil())
+
+		cassette, err := os.ReadFile(cassetteFile)
+		Expect(err).To(BeNil())
+		Expect(string(cassette)).To(ContainSubstring(`"operation": "GetDataProductDraft"`))
+		Expect(string(cassette)).ToNot(ContainSubstring("my-bearer-token"))
+		Expect(string(cassette)).ToNot(ContainSubstring("deadbeef"))
+
+		// Replay against a different URL, in a different order, without reaching the server.
+		recorder, err = dpxv1.NewRecorder(cassetteFile, nil)
+		Expect(err).To(BeNil())
+		Expect(recorder.Mode()).To(Equal(dpxv1.RecorderModeReplay))
+		dpxService = newService("https://dpx.replay.test/", recorder)
+		atomic.StoreInt32(&requestCount, 0)
+
+		replayed2, response, err := dpxService.GetDataProductDraft(dpxService.NewGetDataProductDraftOptions("product-1", "draft-2"))
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(200))
+		Expect(replayed2).To(Equal(draft2))
+		replayed1, _, err := dpxService.GetDataProductDraft(dpxService.NewGetDataProductDraftOptions("product-1", "draft-1"))
+		Expect(err).To(BeNil())
+		Expect(replayed1).To(Equal(draft1))
+
+		document, response, err := dpxService.CreateDraftContractTermsDocument(options)
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(201))
+		Expect(*document.ID).To(Equal("doc-1"))
+		Expect(*document.UploadURL).To(ContainSubstring("X-Amz-Signature=" + "%5Bredacted%5D"))
+		Expect(atomic.LoadInt32(&requestCount)).To(BeZero())
+
+		_, _, err = dpxService.GetDataProductDraft(dpxService.NewGetDataProductDraftOptions("product-1", "draft-3"))
+		Expect(errors.Is(err, dpxv1.ErrInteractionNotFound)).To(BeTrue())
+	})
+	It(`Records each attempt when retries are enabled`, func() {
+		var attempts int32
+		flakyServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Set("Content-type", "application/json")
+			if atomic.AddInt32(&attempts, 1)%2 == 1 {
+				res.WriteHeader(503)
+				fmt.Fprint(res, `{"errors": [{"code": "service_unavailable", "message": "Unavailable"}]}`)
+				return
+			}
+			fmt.Fprint(res, `{"id": "draft-1", "state": "draft"}`)
+		}))
+		defer flakyServer.Close()
+
+		for _, retriesFirst := range []bool{true, false} {
+			recorder, err := dpxv1.NewRecorder(cassetteFile, &dpxv1.RecorderOptions{Mode: dpxv1.RecorderModeRecord})
+			Expect(err).To(BeNil())
+			dpxService, err := dpxv1.NewDpxV1(&dpxv1.DpxV1Options{URL: flakyServer.URL, Authenticator: &core.NoAuthAuthenticator{}})
+			Expect(err).To(BeNil())
+			if retriesFirst {
+				dpxService.EnableRetries(1, time.Millisecond)
+				dpxService.SetRecorder(recorder)
+			} else {
+				dpxService.SetRecorder(recorder)
+				dpxService.EnableRetries(1, time.Millisecond)
+			}
+			_, _, err = dpxService.GetDataProductDraft(dpxService.NewGetDataProductDraftOptions("product-1", "draft-1"))
+			Expect(err).To(BeNil())
+			interactions := recorder.Interactions()
+			Expect(interactions).To(HaveLen(2))
+			Expect(interactions[0].Response.StatusCode).To(Equal(503))
+			Expect(interactions[1].Response.StatusCode).To(Equal(200))
+		}
+	})
+	It(`Applies the scrub function and rejects unknown modes`, func() {
+		recorder, err := dpxv1.NewRecorder(cassetteFile, &dpxv1.RecorderOptions{
+			Mode: dpxv1.RecorderModeRecord,
+			Scrub: func(interaction *dpxv1.CassetteInteraction) {
+				interaction.PathParams["data_product_id"] = "scrubbed"
+			},
+		})
+		Expect(err).To(BeNil())
+		dpxService := newService(testServer.URL, recorder)
+		_, _, err = dpxService.GetDataProductDraft(dpxService.NewGetDataProductDraftOptions("product-1", "draft-1"))
+		Expect(err).To(BeNil())
+		Expect(recorder.Interactions()[0].PathParams).To(HaveKeyWithValue("data_product_id", "scrubbed"))
+
+		_, err = dpxv1.NewRecorder(cassetteFile, &dpxv1.RecorderOptions{Mode: "rewind"})
+		Expect(err).ToNot(BeNil())
+		_, err = dpxv1.NewRecorder(cassetteFile, &dpxv1.RecorderOptions{Mode: dpxv1.RecorderModeReplay})
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,378 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/gomega"
+)
+
+// catalogServer is a fake Data Product Exchange service that serves an in-memory catalog of
+// data product versions (drafts and releases) for the tests of the catalog helpers.
+type catalogServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	versions map[string]map[string]*dpxv1.DataProductVersion // by data product ID, then version ID
+	files    map[string][]byte                               // the attachments of documents, by attachment ID
+	requests []string
+}
+
+func newCatalogServer() *catalogServer {
+	server := &catalogServer{
+		versions: make(map[string]map[string]*dpxv1.DataProductVersion),
+		files:    make(map[string][]byte),
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	return server
+}
+
+// newService returns a DpxV1 instance that sends its requests to the server.
+func (server *catalogServer) newService() *dpxv1.DpxV1 {
+	dpxService, err := dpxv1.NewDpxV1(&dpxv1.DpxV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	Expect(err).To(BeNil())
+	return dpxService
+}
+
+// put adds or replaces a version in the catalog.
+func (server *catalogServer) put(version *dpxv1.DataProductVersion) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	dataProductID := *version.DataProduct.ID
+	if server.versions[dataProductID] == nil {
+		server.versions[dataProductID] = make(map[string]*dpxv1.DataProductVersion)
+	}
+	server.versions[dataProductID][*version.ID] = version
+}
+
+// remove deletes a version from the catalog.
+func (server *catalogServer) remove(dataProductID string, versionID string) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	delete(server.versions[dataProductID], versionID)
+}
+
+// removeDataProduct removes a data product and its versions from the catalog.
+func (server *catalogServer) removeDataProduct(dataProductID string) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	delete(server.versions, dataProductID)
+}
+
+// get returns a version of the catalog, or nil.
+func (server *catalogServer) get(dataProductID string, versionID string) *dpxv1.DataProductVersion {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return server.versions[dataProductID][versionID]
+}
+
+// putFile adds or replaces the content of an attachment.
+func (server *catalogServer) putFile(attachmentID string, content []byte) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.files[attachmentID] = content
+}
+
+// requestLog returns the method and URI of each request received by the server.
+func (server *catalogServer) requestLog() []string {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return append([]string(nil), server.requests...)
+}
+
+func (server *catalogServer) serveHTTP(res http.ResponseWriter, req *http.Request) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.requests = append(server.requests, req.Method+" "+req.URL.RequestURI())
+
+	path := strings.Split(strings.TrimPrefix(req.URL.Path, "/data_product_exchange/v1/data_products"), "/")
+	switch {
+	case strings.HasPrefix(req.URL.Path, "/files/") && req.Method == http.MethodGet:
+		content, ok := server.files[strings.TrimPrefix(req.URL.Path, "/files/")]
+		if !ok {
+			res.WriteHeader(404)
+			return
+		}
+		_, _ = res.Write(content)
+	case strings.HasPrefix(req.URL.Path, "/upload/") && req.Method == http.MethodPut:
+		content, err := io.ReadAll(req.Body)
+		Expect(err).To(BeNil())
+		server.files[strings.TrimPrefix(req.URL.Path, "/upload/")] = content
+		res.WriteHeader(200)
+	case len(path) == 2 && req.Method == http.MethodGet:
+		if server.versions[path[1]] == nil {
+			server.writeNotFound(res)
+			return
+		}
+		server.writeJSON(res, 200, &dpxv1.DataProduct{
+			ID:        core.StringPtr(path[1]),
+			Container: &dpxv1.ContainerReference{ID: core.StringPtr("catalog-1"), Type: core.StringPtr("catalog")},
+		})
+	case len(path) == 1 && req.Method == http.MethodGet:
+		var dataProducts []dpxv1.DataProductSummary
+		for _, dataProductID := range server.sortedDataProductIDs() {
+			dataProducts = append(dataProducts, dpxv1.DataProductSummary{ID: core.StringPtr(dataProductID)})
+		}
+		server.writeJSON(res, 200, &dpxv1.DataProductSummaryCollection{DataProducts: dataProducts})
+	case len(path) == 3 && req.Method == http.MethodGet && (path[2] == "releases" || path[2] == "drafts"):
+		if server.versions[path[1]] == nil {
+			server.writeNotFound(res)
+			return
+		}
+		states := map[string]bool{}
+		for _, state := range strings.Split(req.URL.Query().Get("state"), ",") {
+			states[state] = state != ""
+		}
+		var summaries []dpxv1.DataProductVersionSummary
+		for _, version := range server.sortedVersions(path[1]) {
+			isDraft := *version.State == dpxv1.DataProductVersion_State_Draft
+			if isDraft != (path[2] == "drafts") || (len(req.URL.Query().Get("state")) > 0 && !states[*version.State]) {
+				continue
+			}
+			if query := req.URL.Query(); (query.Has("version") && query.Get("version") != *version.Version) ||
+				(query.Has("asset.container.id") && query.Get("asset.container.id") != *version.Asset.Container.ID) {
+				continue
+			}
+			summaries = append(summaries, dpxv1.DataProductVersionSummary{
+				Version:     version.Version,
+				State:       version.State,
+				DataProduct: version.DataProduct,
+				Name:        version.Name,
+				Description: version.Description,
+				ID:          version.ID,
+				Asset:       version.Asset,
+			})
+		}
+		if path[2] == "drafts" {
+			server.writeJSON(res, 200, &dpxv1.DataProductDraftCollection{Drafts: summaries})
+		} else {
+			server.writeJSON(res, 200, &dpxv1.DataProductReleaseCollection{Releases: summaries})
+		}
+	case len(path) == 4 && req.Method == http.MethodGet:
+		version := server.versions[path[1]][path[3]]
+		if version == nil {
+			server.writeNotFound(res)
+			return
+		}
+		versionCopy := *version
+		versionCopy.ContractTerms = make([]dpxv1.DataProductContractTerms, len(version.ContractTerms))
+		for i, contractTerms := range version.ContractTerms {
+			documents := make([]dpxv1.ContractTermsDocument, len(contractTerms.Documents))
+			for j := range contractTerms.Documents {
+				documents[j] = server.signDocument(&contractTerms.Documents[j])
+			}
+			contractTerms.Documents = documents
+			versionCopy.ContractTerms[i] = contractTerms
+		}
+		server.writeJSON(res, 200, &versionCopy)
+	case len(path) == 3 && req.Method == http.MethodPost && path[2] == "drafts":
+		draft := &dpxv1.DataProductVersion{}
+		Expect(json.NewDecoder(req.Body).Decode(draft)).To(Succeed())
+		draft.ID = core.StringPtr(fmt.Sprintf("draft-%d", len(server.requests)))
+		draft.State = core.StringPtr(dpxv1.DataProductVersion_State_Draft)
+		draft.DataProduct = &dpxv1.DataProductIdentity{ID: core.StringPtr(path[1])}
+		for i := range draft.ContractTerms {
+			draft.ContractTerms[i].ID = core.StringPtr(fmt.Sprintf("terms-%d-%d", len(server.requests), i))
+		}
+		if server.versions[path[1]] == nil {
+			server.versions[path[1]] = make(map[string]*dpxv1.DataProductVersion)
+		}
+		server.versions[path[1]][*draft.ID] = draft
+		server.writeJSON(res, 201, draft)
+	case len(path) == 4 && req.Method == http.MethodPatch:
+		version := server.versions[path[1]][path[3]]
+		if version == nil {
+			server.writeNotFound(res)
+			return
+		}
+		var patch []dpxv1.JSONPatchOperation
+		Expect(json.NewDecoder(req.Body).Decode(&patch)).To(Succeed())
+		patched := applyPatch(version, patch)
+		server.versions[path[1]][path[3]] = patched
+		server.writeJSON(res, 200, patched)
+	case len(path) == 7 && req.Method == http.MethodPost && path[4] == "contract_terms" && path[6] == "documents":
+		version := server.versions[path[1]][path[3]]
+		var contractTerms *dpxv1.DataProductContractTerms
+		for i := range version.ContractTerms {
+			if *version.ContractTerms[i].ID == path[5] {
+				contractTerms = &version.ContractTerms[i]
+			}
+		}
+		if contractTerms == nil {
+			server.writeNotFound(res)
+			return
+		}
+		document := dpxv1.ContractTermsDocument{}
+		Expect(json.NewDecoder(req.Body).Decode(&document)).To(Succeed())
+		documentCopy := document
+		if document.URL == nil {
+			attachmentID := fmt.Sprintf("attachment-%d", len(server.requests))
+			document.Attachment = &dpxv1.ContractTermsDocumentAttachment{ID: core.StringPtr(attachmentID)}
+			documentCopy = document
+			documentCopy.UploadURL = core.StringPtr(server.URL + "/upload/" + attachmentID)
+		}
+		contractTerms.Documents = append(contractTerms.Documents, document)
+		server.writeJSON(res, 201, &documentCopy)
+	case len(path) == 9 && req.Method == http.MethodPost && path[4] == "contract_terms" && path[8] == "complete":
+		document := server.findDocument(path[1], path[3], path[5], path[7])
+		if document == nil {
+			server.writeNotFound(res)
+			return
+		}
+		server.writeJSON(res, 200, document)
+	case len(path) == 8 && req.Method == http.MethodGet && path[4] == "contract_terms" && path[6] == "documents":
+		document := server.findDocument(path[1], path[3], path[5], path[7])
+		if document == nil {
+			server.writeNotFound(res)
+			return
+		}
+		documentCopy := server.signDocument(document)
+		server.writeJSON(res, 200, &documentCopy)
+	default:
+		server.writeJSON(res, 405, map[string]interface{}{
+			"errors": []interface{}{map[string]string{"code": "not_implemented", "message": fmt.Sprintf("%s %s", req.Method, req.URL.Path)}},
+		})
+	}
+}
+
+// signDocument returns a copy of "document" with signed URLs that change with every request, like the service's.
+func (server *catalogServer) signDocument(document *dpxv1.ContractTermsDocument) dpxv1.ContractTermsDocument {
+	documentCopy := *document
+	documentCopy.UploadURL = core.StringPtr(fmt.Sprintf("%s/upload/%d", server.URL, len(server.requests)))
+	if document.Attachment != nil {
+		documentCopy.URL = core.StringPtr(fmt.Sprintf("%s/files/%s?signature=%d", server.URL, *document.Attachment.ID, len(server.requests)))
+	}
+	return documentCopy
+}
+
+// applyPatch applies JSON patch operations on top-level properties to a copy of a version.
+func applyPatch(version *dpxv1.DataProductVersion, patch []dpxv1.JSONPatchOperation) *dpxv1.DataProductVersion {
+	data, err := json.Marshal(version)
+	Expect(err).To(BeNil())
+	fields := map[string]interface{}{}
+	Expect(json.Unmarshal(data, &fields)).To(Succeed())
+	for _, operation := range patch {
+		field := strings.TrimPrefix(*operation.Path, "/")
+		if *operation.Op == dpxv1.JSONPatchOperation_Op_Remove {
+			delete(fields, field)
+		} else {
+			fields[field] = operation.Value
+		}
+	}
+	data, err = json.Marshal(fields)
+	Expect(err).To(BeNil())
+	patched := &dpxv1.DataProductVersion{}
+	Expect(json.Unmarshal(data, patched)).To(Succeed())
+	return patched
+}
+
+// findDocument returns a contract terms document of a version, or nil.
+func (server *catalogServer) findDocument(dataProductID string, versionID string, contractTermsID string, documentID string) *dpxv1.ContractTermsDocument {
+	version := server.versions[dataProductID][versionID]
+	if version == nil {
+		return nil
+	}
+	for i := range version.ContractTerms {
+		if *version.ContractTerms[i].ID != contractTermsID {
+			continue
+		}
+		for j := range version.ContractTerms[i].Documents {
+			if *version.ContractTerms[i].Documents[j].ID == documentID {
+				return &version.ContractTerms[i].Documents[j]
+			}
+		}
+	}
+	return nil
+}
+
+func (server *catalogServer) sortedDataProductIDs() []string {
+	dataProductIDs := make([]string, 0, len(server.versions))
+	for dataProductID := range server.versions {
+		dataProductIDs = append(dataProductIDs, dataProductID)
+	}
+	sort.Strings(dataProductIDs)
+	return dataProductIDs
+}
+
+func (server *catalogServer) sortedVersions(dataProductID string) (versions []*dpxv1.DataProductVersion) {
+	for _, version := range server.versions[dataProductID] {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return *versions[i].ID < *versions[j].ID })
+	return
+}
+
+func (server *catalogServer) writeNotFound(res http.ResponseWriter) {
+	server.writeJSON(res, 404, map[string]interface{}{
+		"errors": []interface{}{map[string]string{"code": "does_not_exist", "message": "Resource not found"}},
+	})
+}
+
+func (server *catalogServer) writeJSON(res http.ResponseWriter, statusCode int, body interface{}) {
+	res.Header().Set("Content-type", "application/json")
+	res.WriteHeader(statusCode)
+	_ = json.NewEncoder(res).Encode(body)
+}
+
+// testVersion returns a data product version for the tests of the catalog helpers.
+func testVersion(dataProductID string, versionID string, state string, name string) *dpxv1.DataProductVersion {
+	return &dpxv1.DataProductVersion{
+		Version:     core.StringPtr("1.0.0"),
+		State:       core.StringPtr(state),
+		DataProduct: &dpxv1.DataProductIdentity{ID: core.StringPtr(dataProductID)},
+		Name:        core.StringPtr(name),
+		Description: core.StringPtr("The " + name + " data product"),
+		ID:          core.StringPtr(versionID),
+		Asset: &dpxv1.AssetReference{
+			ID:        core.StringPtr("asset-" + versionID),
+			Container: &dpxv1.ContainerReference{ID: core.StringPtr("catalog-1"), Type: core.StringPtr("catalog")},
+		},
+	}
+}
+
+// pathRecordingTransport is an http.RoundTripper that records the paths of the requests it sends.
+type pathRecordingTransport struct {
+	mu        sync.Mutex
+	sentPaths []string
+}
+
+func (transport *pathRecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport.mu.Lock()
+	transport.sentPaths = append(transport.sentPaths, req.URL.Path)
+	transport.mu.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// paths returns the paths of the requests sent so far.
+func (transport *pathRecordingTransport) paths() []string {
+	transport.mu.Lock()
+	defer transport.mu.Unlock()
+	return append([]string(nil), transport.sentPaths...)
+}
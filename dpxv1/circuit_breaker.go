@@ -0,0 +1,391 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+// Constants associated with CircuitState.
+const (
+	// Requests are sent normally and failures are counted.
+	CircuitClosed CircuitState = iota
+	// Requests fail fast with a CircuitOpenError without being sent.
+	CircuitOpen
+	// A limited number of trial requests are sent to probe whether the service has recovered.
+	CircuitHalfOpen
+)
+
+// String returns the name of the circuit state.
+func (state CircuitState) String() string {
+	switch state {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(state))
+}
+
+// MarshalText encodes the state as its name, e.g. for JSON health reports.
+func (state CircuitState) MarshalText() ([]byte, error) {
+	return []byte(state.String()), nil
+}
+
+// ErrCircuitOpen is matched (via errors.Is) by the error returned for requests rejected by an open circuit breaker.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned by service methods when a request is rejected because the circuit breaker is open.
+type CircuitOpenError struct {
+	// The operation that was rejected (e.g. "GetDataProduct").
+	OperationID string
+
+	// The state of the circuit breaker when the request was rejected.
+	State CircuitState
+
+	// The time after which the circuit breaker will allow a trial request.
+	RetryAt time.Time
+}
+
+// Error returns the error message.
+func (err *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s: %s rejected until %s", ErrCircuitOpen.Error(), err.OperationID, err.RetryAt.Format(time.RFC3339))
+}
+
+// Is returns true if "target" is ErrCircuitOpen.
+func (err *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// Default configuration values for a CircuitBreaker.
+const (
+	DefaultCircuitBreakerOpenTimeout             = 30 * time.Second
+	DefaultCircuitBreakerHalfOpenMaxRequests     = 1
+	DefaultCircuitBreakerTransportErrorThreshold = 5
+	DefaultCircuitBreakerServerErrorThreshold    = 5
+	DefaultCircuitBreakerErrorCodeThreshold      = 3
+)
+
+// CircuitBreakerOptions : Circuit breaker options
+//
+// A failure threshold is the number of consecutive failures of a given kind that opens the circuit.
+// A successful response resets all failure counts; responses that match no threshold
+// (such as 4xx client errors by default) are neither failures nor successes.
+type CircuitBreakerOptions struct {
+	// Failure thresholds by HTTP status class, keyed by the hundreds digit of the status code (e.g. 5 for 5xx responses).
+	// Defaults to DefaultCircuitBreakerServerErrorThreshold for 5xx responses.
+	StatusClassThresholds map[int]int
+
+	// Failure thresholds by ErrorModelResource code (e.g. ErrorModelResource_Code_DependentServiceError).
+	// An error code threshold takes precedence over the status class of the response.
+	// Defaults to DefaultCircuitBreakerErrorCodeThreshold for "dependent_service_error" and "unexpected_exception".
+	ErrorCodeThresholds map[string]int
+
+	// Failure threshold for requests that received no response, such as connection errors and timeouts.
+	// Requests cancelled by the caller are not counted. Defaults to DefaultCircuitBreakerTransportErrorThreshold;
+	// specify a negative value to ignore transport errors.
+	TransportErrorThreshold int
+
+	// How long the circuit stays open before allowing trial requests. Defaults to DefaultCircuitBreakerOpenTimeout.
+	OpenTimeout time.Duration
+
+	// The number of concurrent trial requests allowed while half-open. Defaults to DefaultCircuitBreakerHalfOpenMaxRequests.
+	HalfOpenMaxRequests int
+
+	// Optional function invoked (without locks held) whenever the circuit changes state.
+	OnStateChange func(from CircuitState, to CircuitState)
+}
+
+// CircuitBreakerStats : A point-in-time view of a CircuitBreaker, suitable for health endpoints.
+type CircuitBreakerStats struct {
+	// The current state of the circuit.
+	State CircuitState `json:"state"`
+
+	// Consecutive failure counts by kind (e.g. "5xx", "dependent_service_error", "transport").
+	ConsecutiveFailures map[string]int `json:"consecutive_failures,omitempty"`
+
+	// The time the circuit last opened (zero if it has never opened).
+	OpenedAt time.Time `json:"opened_at,omitempty"`
+
+	// The time after which an open circuit allows trial requests.
+	RetryAt time.Time `json:"retry_at,omitempty"`
+
+	// The reason the circuit last opened, e.g. "5xx" or "dependent_service_error".
+	LastFailure string `json:"last_failure,omitempty"`
+}
+
+// CircuitBreaker protects the service from being flooded with requests while it is degraded.
+//
+// A CircuitBreaker is safe for concurrent use and may be shared between service instances.
+type CircuitBreaker struct {
+	mu sync.Mutex
+
+	options CircuitBreakerOptions
+
+	state            CircuitState
+	generation       uint64
+	failures         map[string]int
+	openedAt         time.Time
+	lastFailure      string
+	halfOpenInFlight int
+
+	// now returns the current time; replaced in tests.
+	now func() time.Time
+}
+
+// Failure kind used for requests that received no response.
+const circuitFailureTransport = "transport"
+
+// NewCircuitBreaker returns a closed CircuitBreaker configured with "options" (which may be nil to use the defaults).
+func NewCircuitBreaker(options *CircuitBreakerOptions) *CircuitBreaker {
+	resolved := CircuitBreakerOptions{}
+	if options != nil {
+		resolved = *options
+	}
+	if resolved.StatusClassThresholds == nil {
+		resolved.StatusClassThresholds = map[int]int{5: DefaultCircuitBreakerServerErrorThreshold}
+	}
+	if resolved.ErrorCodeThresholds == nil {
+		resolved.ErrorCodeThresholds = map[string]int{
+			ErrorModelResource_Code_DependentServiceError: DefaultCircuitBreakerErrorCodeThreshold,
+			ErrorModelResource_Code_UnexpectedException:   DefaultCircuitBreakerErrorCodeThreshold,
+		}
+	}
+	if resolved.TransportErrorThreshold == 0 {
+		resolved.TransportErrorThreshold = DefaultCircuitBreakerTransportErrorThreshold
+	}
+	if resolved.OpenTimeout <= 0 {
+		resolved.OpenTimeout = DefaultCircuitBreakerOpenTimeout
+	}
+	if resolved.HalfOpenMaxRequests <= 0 {
+		resolved.HalfOpenMaxRequests = DefaultCircuitBreakerHalfOpenMaxRequests
+	}
+	return &CircuitBreaker{
+		options:  resolved,
+		failures: make(map[string]int),
+		now:      time.Now,
+	}
+}
+
+// State returns the current state of the circuit.
+func (breaker *CircuitBreaker) State() CircuitState {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	state, _ := breaker.currentState()
+	return state
+}
+
+// Stats returns a point-in-time view of the circuit breaker.
+func (breaker *CircuitBreaker) Stats() CircuitBreakerStats {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	state, _ := breaker.currentState()
+	stats := CircuitBreakerStats{
+		State:       state,
+		OpenedAt:    breaker.openedAt,
+		LastFailure: breaker.lastFailure,
+	}
+	if state == CircuitOpen {
+		stats.RetryAt = breaker.openedAt.Add(breaker.options.OpenTimeout)
+	}
+	if len(breaker.failures) > 0 {
+		stats.ConsecutiveFailures = make(map[string]int, len(breaker.failures))
+		for kind, count := range breaker.failures {
+			stats.ConsecutiveFailures[kind] = count
+		}
+	}
+	return stats
+}
+
+// Reset closes the circuit and clears all failure counts.
+func (breaker *CircuitBreaker) Reset() {
+	breaker.mu.Lock()
+	from := breaker.state
+	breaker.transition(CircuitClosed)
+	breaker.mu.Unlock()
+	breaker.notify(from, CircuitClosed)
+}
+
+// currentState returns the state of the circuit, taking the expiry of the open timeout into account,
+// along with the time at which an open circuit will allow trial requests.
+func (breaker *CircuitBreaker) currentState() (CircuitState, time.Time) {
+	if breaker.state == CircuitOpen {
+		retryAt := breaker.openedAt.Add(breaker.options.OpenTimeout)
+		if !breaker.now().Before(retryAt) {
+			return CircuitHalfOpen, time.Time{}
+		}
+		return CircuitOpen, retryAt
+	}
+	return breaker.state, time.Time{}
+}
+
+// transition moves the circuit to "to" and starts a new generation, so that
+// the outcome of requests allowed in an earlier state is ignored.
+func (breaker *CircuitBreaker) transition(to CircuitState) {
+	breaker.state = to
+	breaker.generation++
+	breaker.halfOpenInFlight = 0
+	breaker.failures = make(map[string]int)
+	if to == CircuitOpen {
+		breaker.openedAt = breaker.now()
+	}
+}
+
+func (breaker *CircuitBreaker) notify(from CircuitState, to CircuitState) {
+	if from != to && breaker.options.OnStateChange != nil {
+		breaker.options.OnStateChange(from, to)
+	}
+}
+
+// allow returns a non-nil error if a request for the specified operation must not be sent.
+// Otherwise, it returns the generation that must be passed to record() or release().
+func (breaker *CircuitBreaker) allow(operationID string) (generation uint64, err error) {
+	breaker.mu.Lock()
+	from := breaker.state
+	state, retryAt := breaker.currentState()
+	if state == CircuitHalfOpen && from == CircuitOpen {
+		breaker.transition(CircuitHalfOpen)
+	}
+
+	switch {
+	case state == CircuitOpen:
+		err = &CircuitOpenError{OperationID: operationID, State: state, RetryAt: retryAt}
+	case state == CircuitHalfOpen && breaker.halfOpenInFlight >= breaker.options.HalfOpenMaxRequests:
+		err = &CircuitOpenError{OperationID: operationID, State: state, RetryAt: breaker.now()}
+	case state == CircuitHalfOpen:
+		breaker.halfOpenInFlight++
+	}
+	generation = breaker.generation
+	to := breaker.state
+	breaker.mu.Unlock()
+
+	breaker.notify(from, to)
+	return
+}
+
+// release gives back a request slot obtained from allow() for a request that was never sent.
+func (breaker *CircuitBreaker) release(generation uint64) {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	if generation == breaker.generation && breaker.state == CircuitHalfOpen && breaker.halfOpenInFlight > 0 {
+		breaker.halfOpenInFlight--
+	}
+}
+
+// record updates the circuit with the outcome of a request allowed by allow().
+func (breaker *CircuitBreaker) record(generation uint64, response *core.DetailedResponse, err error) {
+	kind, failed, counted := breaker.classify(response, err)
+
+	breaker.mu.Lock()
+	if generation != breaker.generation {
+		// The circuit changed state while the request was in flight.
+		breaker.mu.Unlock()
+		return
+	}
+	from := breaker.state
+	if from == CircuitHalfOpen && breaker.halfOpenInFlight > 0 {
+		breaker.halfOpenInFlight--
+	}
+
+	switch {
+	case !counted:
+	case failed && from == CircuitHalfOpen:
+		breaker.lastFailure = kind
+		breaker.transition(CircuitOpen)
+	case failed:
+		breaker.failures[kind]++
+		if breaker.failures[kind] >= breaker.threshold(kind) {
+			breaker.lastFailure = kind
+			breaker.transition(CircuitOpen)
+		}
+	case from == CircuitHalfOpen:
+		breaker.transition(CircuitClosed)
+	default:
+		if len(breaker.failures) > 0 {
+			breaker.failures = make(map[string]int)
+		}
+	}
+	to := breaker.state
+	breaker.mu.Unlock()
+
+	breaker.notify(from, to)
+}
+
+// classify determines the failure kind (if any) of a request outcome.
+// "counted" is false for outcomes that should not affect the circuit at all.
+func (breaker *CircuitBreaker) classify(response *core.DetailedResponse, err error) (kind string, failed bool, counted bool) {
+	if response == nil {
+		if err == nil {
+			return "", false, true
+		}
+		if errors.Is(err, context.Canceled) || breaker.options.TransportErrorThreshold < 0 {
+			return "", false, false
+		}
+		return circuitFailureTransport, true, true
+	}
+
+	if code := getResponseErrorCode(response); code != "" {
+		if threshold, ok := breaker.options.ErrorCodeThresholds[code]; ok && threshold > 0 {
+			return code, true, true
+		}
+	}
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return "", false, true
+	}
+	class := response.StatusCode / 100
+	if threshold, ok := breaker.options.StatusClassThresholds[class]; ok && threshold > 0 {
+		return fmt.Sprintf("%dxx", class), true, true
+	}
+	return "", false, false
+}
+
+// threshold returns the number of consecutive failures of the specified kind that opens the circuit.
+func (breaker *CircuitBreaker) threshold(kind string) int {
+	if kind == circuitFailureTransport {
+		return breaker.options.TransportErrorThreshold
+	}
+	if threshold, ok := breaker.options.ErrorCodeThresholds[kind]; ok {
+		return threshold
+	}
+	var class int
+	if _, err := fmt.Sscanf(kind, "%dxx", &class); err == nil {
+		return breaker.options.StatusClassThresholds[class]
+	}
+	return 1
+}
+
+// SetCircuitBreaker sets the circuit breaker that guards requests sent by this service instance.
+// Specify nil to disable the circuit breaker.
+func (dpx *DpxV1) SetCircuitBreaker(breaker *CircuitBreaker) {
+	dpx.circuitBreaker = breaker
+}
+
+// GetCircuitBreaker returns the circuit breaker that guards requests sent by this service instance.
+func (dpx *DpxV1) GetCircuitBreaker() *CircuitBreaker {
+	return dpx.circuitBreaker
+}
@@ -0,0 +1,179 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`CircuitBreaker`, func() {
+	var testServer *httptest.Server
+	var requestCount int32
+	var failing atomic.Value
+	var dpxService *dpxv1.DpxV1
+
+	BeforeEach(func() {
+		atomic.StoreInt32(&requestCount, 0)
+		failing.Store("dependent")
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&requestCount, 1)
+			res.Header().Set("Content-type", "application/json")
+			switch failing.Load().(string) {
+			case "dependent":
+				res.WriteHeader(500)
+				fmt.Fprint(res, `{"errors": [{"code": "dependent_service_error", "message": "Dependent service error"}], "trace": "abc"}`)
+			case "server":
+				res.WriteHeader(503)
+				fmt.Fprint(res, `{"errors": [{"code": "unable_to_perform", "message": "Unavailable"}]}`)
+			case "notfound":
+				res.WriteHeader(404)
+				fmt.Fprint(res, `{"errors": [{"code": "does_not_exist", "message": "Not found"}]}`)
+			default:
+				res.WriteHeader(200)
+				fmt.Fprint(res, `{"id": "testString", "container": {"id": "testString", "type": "catalog"}}`)
+			}
+		}))
+		var serviceErr error
+		dpxService, serviceErr = dpxv1.NewDpxV1(&dpxv1.DpxV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(serviceErr).To(BeNil())
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	getDataProduct := func() error {
+		_, _, err := dpxService.GetDataProduct(dpxService.NewGetDataProductOptions("testString"))
+		return err
+	}
+
+	It(`Opens after consecutive error code failures and fails fast`, func() {
+		breaker := dpxv1.NewCircuitBreaker(nil)
+		dpxService.SetCircuitBreaker(breaker)
+		Expect(dpxService.GetCircuitBreaker()).To(Equal(breaker))
+
+		for i := 0; i < dpxv1.DefaultCircuitBreakerErrorCodeThreshold; i++ {
+			Expect(breaker.State()).To(Equal(dpxv1.CircuitClosed))
+			Expect(getDataProduct()).ToNot(BeNil())
+		}
+		Expect(breaker.State()).To(Equal(dpxv1.CircuitOpen))
+
+		err := getDataProduct()
+		Expect(errors.Is(err, dpxv1.ErrCircuitOpen)).To(BeTrue())
+		var openErr *dpxv1.CircuitOpenError
+		Expect(errors.As(err, &openErr)).To(BeTrue())
+		Expect(openErr.OperationID).To(Equal("GetDataProduct"))
+		Expect(atomic.LoadInt32(&requestCount)).To(Equal(int32(dpxv1.DefaultCircuitBreakerErrorCodeThreshold)))
+
+		stats := breaker.Stats()
+		Expect(stats.LastFailure).To(Equal(dpxv1.ErrorModelResource_Code_DependentServiceError))
+		Expect(stats.RetryAt.IsZero()).To(BeFalse())
+		buf, _ := json.Marshal(stats)
+		Expect(string(buf)).To(ContainSubstring(`"state":"open"`))
+	})
+	It(`Counts failures by status class and ignores client errors`, func() {
+		breaker := dpxv1.NewCircuitBreaker(&dpxv1.CircuitBreakerOptions{
+			StatusClassThresholds: map[int]int{5: 2},
+		})
+		dpxService.SetCircuitBreaker(breaker)
+
+		failing.Store("notfound")
+		for i := 0; i < 5; i++ {
+			Expect(getDataProduct()).ToNot(BeNil())
+		}
+		Expect(breaker.State()).To(Equal(dpxv1.CircuitClosed))
+
+		failing.Store("server")
+		Expect(getDataProduct()).ToNot(BeNil())
+		Expect(breaker.Stats().ConsecutiveFailures).To(HaveKeyWithValue("5xx", 1))
+		Expect(getDataProduct()).ToNot(BeNil())
+		Expect(breaker.State()).To(Equal(dpxv1.CircuitOpen))
+	})
+	It(`Resets failure counts after a success`, func() {
+		breaker := dpxv1.NewCircuitBreaker(&dpxv1.CircuitBreakerOptions{
+			ErrorCodeThresholds: map[string]int{dpxv1.ErrorModelResource_Code_DependentServiceError: 2},
+		})
+		dpxService.SetCircuitBreaker(breaker)
+
+		Expect(getDataProduct()).ToNot(BeNil())
+		failing.Store("")
+		Expect(getDataProduct()).To(BeNil())
+		failing.Store("dependent")
+		Expect(getDataProduct()).ToNot(BeNil())
+		Expect(breaker.State()).To(Equal(dpxv1.CircuitClosed))
+	})
+	It(`Closes again after a successful trial request`, func() {
+		var transitions []string
+		var mu sync.Mutex
+		breaker := dpxv1.NewCircuitBreaker(&dpxv1.CircuitBreakerOptions{
+			ErrorCodeThresholds: map[string]int{dpxv1.ErrorModelResource_Code_DependentServiceError: 1},
+			OpenTimeout:         20 * time.Millisecond,
+			OnStateChange: func(from dpxv1.CircuitState, to dpxv1.CircuitState) {
+				mu.Lock()
+				defer mu.Unlock()
+				transitions = append(transitions, from.String()+"->"+to.String())
+			},
+		})
+		dpxService.SetCircuitBreaker(breaker)
+
+		Expect(getDataProduct()).ToNot(BeNil())
+		Expect(breaker.State()).To(Equal(dpxv1.CircuitOpen))
+
+		time.Sleep(30 * time.Millisecond)
+		Expect(breaker.State()).To(Equal(dpxv1.CircuitHalfOpen))
+
+		// A failed trial re-opens the circuit.
+		Expect(getDataProduct()).ToNot(BeNil())
+		Expect(breaker.State()).To(Equal(dpxv1.CircuitOpen))
+
+		time.Sleep(30 * time.Millisecond)
+		failing.Store("")
+		Expect(getDataProduct()).To(BeNil())
+		Expect(breaker.State()).To(Equal(dpxv1.CircuitClosed))
+
+		mu.Lock()
+		defer mu.Unlock()
+		Expect(transitions).To(Equal([]string{
+			"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed",
+		}))
+	})
+	It(`Can be reset`, func() {
+		breaker := dpxv1.NewCircuitBreaker(&dpxv1.CircuitBreakerOptions{
+			ErrorCodeThresholds: map[string]int{dpxv1.ErrorModelResource_Code_DependentServiceError: 1},
+		})
+		dpxService.SetCircuitBreaker(breaker)
+		Expect(getDataProduct()).ToNot(BeNil())
+		Expect(breaker.State()).To(Equal(dpxv1.CircuitOpen))
+		breaker.Reset()
+		Expect(breaker.State()).To(Equal(dpxv1.CircuitClosed))
+		Expect(dpxv1.CircuitHalfOpen.String()).To(Equal("half-open"))
+	})
+})
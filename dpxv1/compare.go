@@ -0,0 +1,414 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Constants associated with the VersionChange.Kind property.
+const (
+	// An element was added to a list (e.g. a tag).
+	VersionChange_Kind_Added = "added"
+
+	// The value of a property changed.
+	VersionChange_Kind_Changed = "changed"
+
+	// An element was removed from a list.
+	VersionChange_Kind_Removed = "removed"
+
+	// A contract terms document was replaced by a document of the same type and name, or its content changed.
+	VersionChange_Kind_Replaced = "replaced"
+)
+
+// Constants associated with the VersionChange.Field property.
+const (
+	VersionChange_Field_ContractTermsDocuments  = "contract_terms.documents"
+	VersionChange_Field_Description             = "description"
+	VersionChange_Field_Domain                  = "domain"
+	VersionChange_Field_IsRestricted            = "is_restricted"
+	VersionChange_Field_Name                    = "name"
+	VersionChange_Field_PartsOut                = "parts_out"
+	VersionChange_Field_PartsOutDeliveryMethods = "parts_out.delivery_methods"
+	VersionChange_Field_Tags                    = "tags"
+	VersionChange_Field_UseCases                = "use_cases"
+)
+
+// VersionDiff : The changes between two data product versions (see CompareVersions).
+type VersionDiff struct {
+	// The changes, grouped by field.
+	Changes []VersionChange `json:"changes"`
+}
+
+// VersionChange : A change of a field of a data product version.
+type VersionChange struct {
+	// The changed field.
+	Field string `json:"field"`
+
+	// The kind of change.
+	Kind string `json:"kind"`
+
+	// The element of a list that was added, removed or replaced, e.g. a tag or the name of a document. For
+	// parts_out.delivery_methods, the ID of the delivery method.
+	Item string `json:"item,omitempty"`
+
+	// The asset ID of the part whose delivery methods changed (for parts_out.delivery_methods).
+	Part string `json:"part,omitempty"`
+
+	// The previous value (for changed and replaced), as displayed: strings are quoted, and a document is
+	// represented by its URL or attachment.
+	Old string `json:"old,omitempty"`
+
+	// The new value (for changed and replaced), as displayed.
+	New string `json:"new,omitempty"`
+}
+
+// HasChanges returns true if the versions differ.
+func (diff VersionDiff) HasChanges() bool {
+	return len(diff.Changes) > 0
+}
+
+// String returns the diff as text (see Text).
+func (diff VersionDiff) String() string {
+	return diff.Text()
+}
+
+// Text returns the diff as plain text, with one line per change.
+func (diff VersionDiff) Text() string {
+	if !diff.HasChanges() {
+		return "No changes.\n"
+	}
+	var builder strings.Builder
+	for _, change := range diff.Changes {
+		fmt.Fprintf(&builder, "%s\n", change)
+	}
+	return builder.String()
+}
+
+// Markdown returns the diff as a Markdown table, for code reviews and pull requests.
+func (diff VersionDiff) Markdown() string {
+	if !diff.HasChanges() {
+		return "_No changes._\n"
+	}
+	var builder strings.Builder
+	builder.WriteString("| Field | Change | Item | Before | After |\n")
+	builder.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, change := range diff.Changes {
+		item := change.Item
+		if change.Part != "" {
+			item = change.Part + " / " + item
+		}
+		fmt.Fprintf(&builder, "| `%s` | %s | %s | %s | %s |\n", change.Field, change.Kind,
+			markdownCell(item), markdownCell(change.Old), markdownCell(change.New))
+	}
+	return builder.String()
+}
+
+// String returns a description of the change, e.g. `tags: added "finance"`.
+func (change VersionChange) String() string {
+	field := change.Field
+	if change.Part != "" {
+		field = fmt.Sprintf("%s of part %q", field, change.Part)
+	}
+	switch change.Kind {
+	case VersionChange_Kind_Added, VersionChange_Kind_Removed:
+		return fmt.Sprintf("%s: %s %q", field, change.Kind, change.Item)
+	case VersionChange_Kind_Replaced:
+		return fmt.Sprintf("%s: replaced %q (%s -> %s)", field, change.Item, displayValue(change.Old), displayValue(change.New))
+	}
+	return fmt.Sprintf("%s: %s -> %s", field, displayValue(change.Old), displayValue(change.New))
+}
+
+// CompareVersions returns the changes from version "a" to version "b" (e.g. from the latest release to a draft):
+// the name, description, domain and restriction, the tags and use cases, the parts and their delivery methods,
+// and the contract terms documents. A nil version has no properties. The state, the identifiers and the
+// publication and creation details are not compared.
+func CompareVersions(a, b *DataProductVersion) VersionDiff {
+	if a == nil {
+		a = &DataProductVersion{}
+	}
+	if b == nil {
+		b = &DataProductVersion{}
+	}
+	diff := &VersionDiff{}
+	diff.compareValues(VersionChange_Field_Name, quotedValue(a.Name), quotedValue(b.Name))
+	diff.compareValues(VersionChange_Field_Description, quotedValue(a.Description), quotedValue(b.Description))
+	diff.compareLists(VersionChange_Field_Tags, "", a.Tags, b.Tags)
+	diff.compareUseCases(a.UseCases, b.UseCases)
+	diff.compareDomains(a.Domain, b.Domain)
+	diff.compareParts(a.PartsOut, b.PartsOut)
+	diff.compareDocuments(versionDocuments(a), versionDocuments(b))
+	diff.compareValues(VersionChange_Field_IsRestricted,
+		strconv.FormatBool(a.IsRestricted != nil && *a.IsRestricted), strconv.FormatBool(b.IsRestricted != nil && *b.IsRestricted))
+	return *diff
+}
+
+func (diff *VersionDiff) compareValues(field string, old string, new string) {
+	if old != new {
+		diff.Changes = append(diff.Changes, VersionChange{Field: field, Kind: VersionChange_Kind_Changed, Old: old, New: new})
+	}
+}
+
+// compareDomains reports a change of domain. Domains are compared by ID (or by name if neither has an ID), and
+// displayed by name, with their IDs if the names are the same.
+func (diff *VersionDiff) compareDomains(old *Domain, new *Domain) {
+	oldID, newID := domainID(old), domainID(new)
+	if oldID == "" && newID == "" {
+		oldID, newID = domainName(old), domainName(new)
+	}
+	if oldID == newID {
+		return
+	}
+	oldValue, newValue := domainName(old), domainName(new)
+	if oldValue == newValue {
+		oldValue, newValue = domainLabel(old), domainLabel(new)
+	}
+	diff.Changes = append(diff.Changes, VersionChange{Field: VersionChange_Field_Domain, Kind: VersionChange_Kind_Changed, Old: oldValue, New: newValue})
+}
+
+// compareUseCases reports the use cases of "new" that are not in "old" as added, then the use cases of "old" that
+// are not in "new" as removed. Use cases are compared by container and ID (or by name if they have no ID), and
+// displayed by name, with their IDs if several of the added and removed use cases have the same name.
+func (diff *VersionDiff) compareUseCases(old []UseCase, new []UseCase) {
+	oldKeys, newKeys := useCaseKeys(old), useCaseKeys(new)
+	var added, removed []UseCase
+	for i, key := range newKeys {
+		if !slices.Contains(oldKeys, key) {
+			added = append(added, new[i])
+		}
+	}
+	for i, key := range oldKeys {
+		if !slices.Contains(newKeys, key) {
+			removed = append(removed, old[i])
+		}
+	}
+	names := make(map[string]int)
+	for _, useCase := range append(append([]UseCase(nil), added...), removed...) {
+		names[useCaseName(useCase)]++
+	}
+	item := func(useCase UseCase) string {
+		if names[useCaseName(useCase)] > 1 {
+			return useCaseLabel(useCase)
+		}
+		return useCaseName(useCase)
+	}
+	for _, useCase := range added {
+		diff.Changes = append(diff.Changes, VersionChange{Field: VersionChange_Field_UseCases, Kind: VersionChange_Kind_Added, Item: item(useCase)})
+	}
+	for _, useCase := range removed {
+		diff.Changes = append(diff.Changes, VersionChange{Field: VersionChange_Field_UseCases, Kind: VersionChange_Kind_Removed, Item: item(useCase)})
+	}
+}
+
+// compareLists reports the elements of "new" that are not in "old" as added, then the elements of "old" that are
+// not in "new" as removed.
+func (diff *VersionDiff) compareLists(field string, part string, old []string, new []string) {
+	for _, item := range new {
+		if !slices.Contains(old, item) {
+			diff.Changes = append(diff.Changes, VersionChange{Field: field, Kind: VersionChange_Kind_Added, Item: item, Part: part})
+		}
+	}
+	for _, item := range old {
+		if !slices.Contains(new, item) {
+			diff.Changes = append(diff.Changes, VersionChange{Field: field, Kind: VersionChange_Kind_Removed, Item: item, Part: part})
+		}
+	}
+}
+
+// compareParts compares the parts by asset ID, then the delivery methods of the parts of both versions.
+func (diff *VersionDiff) compareParts(old []DataProductPart, new []DataProductPart) {
+	oldAssets, newAssets := partAssetIDs(old), partAssetIDs(new)
+	diff.compareLists(VersionChange_Field_PartsOut, "", oldAssets, newAssets)
+	for i, assetID := range newAssets {
+		j := slices.Index(oldAssets, assetID)
+		if j < 0 {
+			continue
+		}
+		diff.compareLists(VersionChange_Field_PartsOutDeliveryMethods, assetID,
+			deliveryMethodIDs(old[j].DeliveryMethods), deliveryMethodIDs(new[i].DeliveryMethods))
+	}
+}
+
+// compareDocuments compares the contract terms documents by ID, then pairs the remaining documents by type and name.
+func (diff *VersionDiff) compareDocuments(old []ContractTermsDocument, new []ContractTermsDocument) {
+	matched := make(map[int]bool)
+	var added []ContractTermsDocument
+	for _, document := range new {
+		i := slices.IndexFunc(old, func(candidate ContractTermsDocument) bool {
+			return document.ID != nil && stringValue(candidate.ID) == *document.ID
+		})
+		if i < 0 {
+			added = append(added, document)
+			continue
+		}
+		matched[i] = true
+		if documentContent(old[i]) != documentContent(document) || documentLabel(old[i]) != documentLabel(document) {
+			diff.Changes = append(diff.Changes, VersionChange{
+				Field: VersionChange_Field_ContractTermsDocuments,
+				Kind:  VersionChange_Kind_Replaced,
+				Item:  documentLabel(document),
+				Old:   documentContent(old[i]),
+				New:   documentContent(document),
+			})
+		}
+	}
+	for _, document := range added {
+		i := slices.IndexFunc(old, func(candidate ContractTermsDocument) bool {
+			return documentLabel(candidate) == documentLabel(document)
+		})
+		if i >= 0 && !matched[i] {
+			matched[i] = true
+			diff.Changes = append(diff.Changes, VersionChange{
+				Field: VersionChange_Field_ContractTermsDocuments,
+				Kind:  VersionChange_Kind_Replaced,
+				Item:  documentLabel(document),
+				Old:   documentContent(old[i]),
+				New:   documentContent(document),
+			})
+			continue
+		}
+		diff.Changes = append(diff.Changes, VersionChange{
+			Field: VersionChange_Field_ContractTermsDocuments, Kind: VersionChange_Kind_Added, Item: documentLabel(document),
+		})
+	}
+	for i, document := range old {
+		if !matched[i] {
+			diff.Changes = append(diff.Changes, VersionChange{
+				Field: VersionChange_Field_ContractTermsDocuments, Kind: VersionChange_Kind_Removed, Item: documentLabel(document),
+			})
+		}
+	}
+}
+
+// versionDocuments returns the documents of every contract terms of a version.
+func versionDocuments(version *DataProductVersion) (documents []ContractTermsDocument) {
+	for _, contractTerms := range version.ContractTerms {
+		documents = append(documents, contractTerms.Documents...)
+	}
+	return
+}
+
+// documentLabel returns the name and type of a document, e.g. `SLA (sla)`.
+func documentLabel(document ContractTermsDocument) string {
+	return fmt.Sprintf("%s (%s)", stringValue(document.Name), stringValue(document.Type))
+}
+
+// documentContent returns what a document refers to: its URL, or its attachment.
+func documentContent(document ContractTermsDocument) string {
+	if document.Attachment != nil {
+		return "attachment " + stringValue(document.Attachment.ID)
+	}
+	return stringValue(document.URL)
+}
+
+// useCaseKeys returns the keys by which use cases are compared: their container and ID, or their name if they
+// have no ID.
+func useCaseKeys(useCases []UseCase) (keys []string) {
+	for _, useCase := range useCases {
+		if useCase.ID != nil {
+			keys = append(keys, containerID(useCase.Container)+"/"+*useCase.ID)
+		} else {
+			keys = append(keys, "name:"+stringValue(useCase.Name))
+		}
+	}
+	return
+}
+
+// useCaseName returns the name of a use case, or its ID if it has no name.
+func useCaseName(useCase UseCase) string {
+	if useCase.Name != nil {
+		return *useCase.Name
+	}
+	return stringValue(useCase.ID)
+}
+
+// useCaseLabel returns the name and the ID of a use case, e.g. `Forecasting (use-case-1)`.
+func useCaseLabel(useCase UseCase) string {
+	if useCase.Name == nil || useCase.ID == nil {
+		return useCaseName(useCase)
+	}
+	return fmt.Sprintf("%s (%s)", *useCase.Name, *useCase.ID)
+}
+
+func domainID(domain *Domain) string {
+	if domain == nil {
+		return ""
+	}
+	return stringValue(domain.ID)
+}
+
+// domainName returns the quoted name of a domain (or its ID if it has no name), or "" for no domain.
+func domainName(domain *Domain) string {
+	if domain == nil {
+		return ""
+	}
+	if domain.Name != nil {
+		return strconv.Quote(*domain.Name)
+	}
+	return strconv.Quote(stringValue(domain.ID))
+}
+
+// domainLabel returns the quoted name and the ID of a domain, e.g. `"Sales" (domain-1)`.
+func domainLabel(domain *Domain) string {
+	if domain == nil || domain.Name == nil || domain.ID == nil {
+		return domainName(domain)
+	}
+	return fmt.Sprintf("%s (%s)", strconv.Quote(*domain.Name), *domain.ID)
+}
+
+func partAssetIDs(parts []DataProductPart) (assetIDs []string) {
+	for _, part := range parts {
+		if part.Asset != nil {
+			assetIDs = append(assetIDs, stringValue(part.Asset.ID))
+		} else {
+			assetIDs = append(assetIDs, "")
+		}
+	}
+	return
+}
+
+func deliveryMethodIDs(deliveryMethods []DeliveryMethod) (ids []string) {
+	for _, deliveryMethod := range deliveryMethods {
+		ids = append(ids, stringValue(deliveryMethod.ID))
+	}
+	return
+}
+
+// quotedValue returns the quoted value of a string property, or "" if it is not set.
+func quotedValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Quote(*value)
+}
+
+// displayValue returns "value", or "(none)" if it is empty.
+func displayValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+// markdownCell escapes a value for a cell of a Markdown table.
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.ReplaceAll(value, "\n", "<br>")
+}
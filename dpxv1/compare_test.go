@@ -0,0 +1,133 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`CompareVersions`, func() {
+	var release, draft *dpxv1.DataProductVersion
+
+	BeforeEach(func() {
+		release = testRelease("product-1", "release-1")
+		release.Tags = []string{"finance", "quarterly"}
+		release.UseCases = []dpxv1.UseCase{{ID: core.StringPtr("use-case-1"), Name: core.StringPtr("Forecasting")}}
+		release.Domain = &dpxv1.Domain{ID: core.StringPtr("domain-1"), Name: core.StringPtr("Sales")}
+		release.PartsOut[0].DeliveryMethods = []dpxv1.DeliveryMethod{{ID: core.StringPtr("download"), Container: release.Asset.Container}}
+		draft = testRelease("product-1", "draft-1")
+		draft.Tags = []string{"finance", "quarterly"}
+		draft.UseCases = release.UseCases
+		draft.Domain = release.Domain
+		draft.PartsOut[0].DeliveryMethods = release.PartsOut[0].DeliveryMethods
+	})
+
+	It(`Reports no changes for equivalent versions`, func() {
+		diff := dpxv1.CompareVersions(release, draft)
+		Expect(diff.HasChanges()).To(BeFalse())
+		Expect(diff.Text()).To(Equal("No changes.\n"))
+		Expect(diff.Markdown()).To(Equal("_No changes._\n"))
+	})
+	It(`Reports the field-level changes`, func() {
+		draft.Name = core.StringPtr("Sales v2")
+		draft.Description = nil
+		draft.Tags = []string{"finance", "monthly"}
+		draft.UseCases = append(draft.UseCases, dpxv1.UseCase{ID: core.StringPtr("use-case-2")})
+		draft.Domain = &dpxv1.Domain{ID: core.StringPtr("domain-2"), Name: core.StringPtr("Finance")}
+		draft.PartsOut = append([]dpxv1.DataProductPart(nil), draft.PartsOut...)
+		draft.PartsOut[0].DeliveryMethods = []dpxv1.DeliveryMethod{{ID: core.StringPtr("flight"), Container: draft.Asset.Container}}
+		draft.PartsOut = append(draft.PartsOut, dpxv1.DataProductPart{
+			Asset: &dpxv1.AssetPartReference{ID: core.StringPtr("part-2"), Container: draft.Asset.Container},
+		})
+		documents := draft.ContractTerms[0].Documents
+		documents[0] = dpxv1.ContractTermsDocument{
+			ID:         core.StringPtr("document-3"),
+			Name:       core.StringPtr("SLA"),
+			Type:       core.StringPtr(dpxv1.ContractTermsDocument_Type_Sla),
+			Attachment: &dpxv1.ContractTermsDocumentAttachment{ID: core.StringPtr("attachment-2")},
+		}
+		draft.ContractTerms[0].Documents = documents[:1]
+		draft.ContractTerms = append(draft.ContractTerms, dpxv1.DataProductContractTerms{
+			ID: core.StringPtr("terms-2"),
+			Documents: []dpxv1.ContractTermsDocument{{
+				ID:   core.StringPtr("document-4"),
+				Name: core.StringPtr("Privacy"),
+				Type: core.StringPtr(dpxv1.ContractTermsDocument_Type_TermsAndConditions),
+				URL:  core.StringPtr("https://example.com/privacy"),
+			}},
+		})
+		draft.IsRestricted = core.BoolPtr(true)
+
+		diff := dpxv1.CompareVersions(release, draft)
+		Expect(diff.Changes).To(HaveLen(13))
+		Expect(diff.Changes[0]).To(Equal(dpxv1.VersionChange{
+			Field: dpxv1.VersionChange_Field_Name, Kind: dpxv1.VersionChange_Kind_Changed, Old: `"Sales"`, New: `"Sales v2"`,
+		}))
+		Expect(diff.Text()).To(Equal(`name: "Sales" -> "Sales v2"
+description: "The Sales data product" -> (none)
+tags: added "monthly"
+tags: removed "quarterly"
+use_cases: added "use-case-2"
+domain: "Sales" -> "Finance"
+parts_out: added "part-2"
+parts_out.delivery_methods of part "part-1": added "flight"
+parts_out.delivery_methods of part "part-1": removed "download"
+contract_terms.documents: replaced "SLA (sla)" (attachment attachment-1 -> attachment attachment-2)
+contract_terms.documents: added "Privacy (terms_and_conditions)"
+contract_terms.documents: removed "Terms (terms_and_conditions)"
+is_restricted: false -> true
+`))
+		Expect(diff.Markdown()).To(HavePrefix("| Field | Change | Item | Before | After |\n| --- | --- | --- | --- | --- |\n" +
+			"| `name` | changed |  | \"Sales\" | \"Sales v2\" |\n"))
+		Expect(diff.Markdown()).To(ContainSubstring("| `parts_out.delivery_methods` | added | part-1 / flight |  |  |\n"))
+	})
+	It(`Compares domains by ID`, func() {
+		draft.Domain = &dpxv1.Domain{ID: core.StringPtr("domain-1"), Name: core.StringPtr("Sales & Marketing")}
+		Expect(dpxv1.CompareVersions(release, draft).HasChanges()).To(BeFalse())
+
+		draft.Domain = &dpxv1.Domain{ID: core.StringPtr("domain-2"), Name: core.StringPtr("Sales")}
+		Expect(dpxv1.CompareVersions(release, draft).Text()).To(Equal("domain: \"Sales\" (domain-1) -> \"Sales\" (domain-2)\n"))
+
+		draft.Domain = nil
+		Expect(dpxv1.CompareVersions(release, draft).Text()).To(Equal("domain: \"Sales\" -> (none)\n"))
+	})
+	It(`Compares use cases by ID`, func() {
+		draft.UseCases = []dpxv1.UseCase{{ID: core.StringPtr("use-case-1"), Name: core.StringPtr("Demand forecasting")}}
+		Expect(dpxv1.CompareVersions(release, draft).HasChanges()).To(BeFalse())
+
+		draft.UseCases = []dpxv1.UseCase{{ID: core.StringPtr("use-case-3"), Name: core.StringPtr("Forecasting")}}
+		Expect(dpxv1.CompareVersions(release, draft).Text()).To(Equal("use_cases: added \"Forecasting (use-case-3)\"\n" +
+			"use_cases: removed \"Forecasting (use-case-1)\"\n"))
+
+		draft.UseCases = []dpxv1.UseCase{{ID: core.StringPtr("use-case-2"), Name: core.StringPtr("Churn")}}
+		Expect(dpxv1.CompareVersions(release, draft).Text()).To(Equal("use_cases: added \"Churn\"\nuse_cases: removed \"Forecasting\"\n"))
+	})
+	It(`Reports a document whose URL changed as replaced`, func() {
+		draft.ContractTerms[0].Documents[1].URL = core.StringPtr("https://example.com/terms|v2")
+		diff := dpxv1.CompareVersions(release, draft)
+		Expect(diff.Changes).To(HaveLen(1))
+		Expect(diff.Changes[0].Kind).To(Equal(dpxv1.VersionChange_Kind_Replaced))
+		Expect(diff.Markdown()).To(ContainSubstring(`| https://example.com/terms\|v2 |`))
+	})
+	It(`Compares with a nil version`, func() {
+		diff := dpxv1.CompareVersions(nil, testVersion("product-1", "draft-1", dpxv1.DataProductVersion_State_Draft, "Sales"))
+		Expect(diff.String()).To(Equal("name: (none) -> \"Sales\"\ndescription: (none) -> \"The Sales data product\"\n"))
+	})
+})
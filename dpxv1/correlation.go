@@ -0,0 +1,124 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// CorrelationIDHeader is the header that carries the correlation ID of every request. The same header of the
+// response holds the correlation ID of the operation (see GetCorrelationID).
+const CorrelationIDHeader = "X-Correlation-Id"
+
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a copy of "ctx" that carries a correlation ID. The operations invoked with the
+// context (the "WithContext" forms of the service methods) send this ID instead of a generated one, e.g. to
+// reuse the ID of an incoming request.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationIDFromContext returns the correlation ID carried by "ctx", or "".
+func CorrelationIDFromContext(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDKey{}).(string)
+	return correlationID
+}
+
+// GetCorrelationID returns the correlation ID of the operation that returned "response", or "".
+func GetCorrelationID(response *core.DetailedResponse) string {
+	if response == nil {
+		return ""
+	}
+	return response.Headers.Get(CorrelationIDHeader)
+}
+
+// CorrelatedError is returned by service methods when an operation fails after its request was built. It
+// identifies the request in the logs of the client (see CorrelationIDHeader) and of the service (Trace).
+type CorrelatedError struct {
+	// The correlation ID of the request.
+	CorrelationID string
+
+	// The "trace" value reported by the service, if any.
+	Trace string
+
+	// The error returned for the operation.
+	Err error
+}
+
+// Error returns the error message, followed by the correlation ID and the trace.
+func (err *CorrelatedError) Error() string {
+	if err.Trace == "" {
+		return fmt.Sprintf("%s [correlation_id: %s]", err.Err.Error(), err.CorrelationID)
+	}
+	return fmt.Sprintf("%s [correlation_id: %s, trace: %s]", err.Err.Error(), err.CorrelationID, err.Trace)
+}
+
+// Unwrap returns the error returned for the operation.
+func (err *CorrelatedError) Unwrap() error {
+	return err.Err
+}
+
+// setCorrelationID sets the correlation ID header of "request", unless the caller specified it: to the ID
+// carried by the context of the request, or to a new random ID. It returns the correlation ID.
+func setCorrelationID(request *http.Request) string {
+	correlationID := request.Header.Get(CorrelationIDHeader)
+	if correlationID != "" {
+		return correlationID
+	}
+	correlationID = CorrelationIDFromContext(request.Context())
+	if correlationID == "" {
+		correlationID = newCorrelationID()
+	}
+	request.Header.Set(CorrelationIDHeader, correlationID)
+	return correlationID
+}
+
+// newCorrelationID returns a random (version 4) UUID.
+func newCorrelationID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16])
+}
+
+// correlateResponse records the correlation ID of an operation in its response, unless the service echoed
+// one, and embeds it (with the trace reported by the service) in the error of the operation.
+func correlateResponse(correlationID string, response *core.DetailedResponse, err error) (*core.DetailedResponse, error) {
+	if response != nil {
+		if response.Headers == nil {
+			response.Headers = make(http.Header)
+		}
+		if response.Headers.Get(CorrelationIDHeader) == "" {
+			response.Headers.Set(CorrelationIDHeader, correlationID)
+		}
+	}
+	if err != nil {
+		err = &CorrelatedError{
+			CorrelationID: correlationID,
+			Trace:         getResponseTrace(response),
+			Err:           err,
+		}
+	}
+	return response, err
+}
@@ -0,0 +1,101 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Correlation IDs`, func() {
+	var testServer *httptest.Server
+	var dpxService *dpxv1.DpxV1
+	var mu sync.Mutex
+	var received []string
+
+	BeforeEach(func() {
+		received = nil
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			received = append(received, req.Header.Get(dpxv1.CorrelationIDHeader))
+			mu.Unlock()
+			res.Header().Set("Content-Type", "application/json")
+			if req.URL.Path == "/data_product_exchange/v1/data_products/missing" {
+				res.WriteHeader(404)
+				fmt.Fprint(res, `{"errors": [{"code": "not_found", "message": "Data product not found"}], "trace": "trace-123"}`)
+				return
+			}
+			fmt.Fprint(res, `{"id": "product-1"}`)
+		}))
+		var err error
+		dpxService, err = dpxv1.NewDpxV1(&dpxv1.DpxV1Options{URL: testServer.URL, Authenticator: &core.NoAuthAuthenticator{}})
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`Generates a correlation ID per call and exposes it on the response`, func() {
+		_, first, err := dpxService.GetDataProduct(dpxService.NewGetDataProductOptions("product-1"))
+		Expect(err).To(BeNil())
+		_, second, err := dpxService.GetDataProduct(dpxService.NewGetDataProductOptions("product-1"))
+		Expect(err).To(BeNil())
+
+		Expect(received).To(HaveLen(2))
+		Expect(received[0]).To(MatchRegexp(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`))
+		Expect(received[1]).ToNot(Equal(received[0]))
+		Expect(dpxv1.GetCorrelationID(first)).To(Equal(received[0]))
+		Expect(dpxv1.GetCorrelationID(second)).To(Equal(received[1]))
+		Expect(dpxv1.GetCorrelationID(nil)).To(BeEmpty())
+	})
+	It(`Takes the correlation ID from the context or the request headers`, func() {
+		ctx := dpxv1.WithCorrelationID(context.Background(), "incoming-1")
+		Expect(dpxv1.CorrelationIDFromContext(ctx)).To(Equal("incoming-1"))
+		_, response, err := dpxService.GetDataProductWithContext(ctx, dpxService.NewGetDataProductOptions("product-1"))
+		Expect(err).To(BeNil())
+		Expect(dpxv1.GetCorrelationID(response)).To(Equal("incoming-1"))
+
+		options := dpxService.NewGetDataProductOptions("product-1")
+		options.SetHeaders(map[string]string{dpxv1.CorrelationIDHeader: "explicit-1"})
+		_, _, err = dpxService.GetDataProductWithContext(ctx, options)
+		Expect(err).To(BeNil())
+		Expect(received).To(Equal([]string{"incoming-1", "explicit-1"}))
+	})
+	It(`Embeds the correlation ID and the trace in errors`, func() {
+		ctx := dpxv1.WithCorrelationID(context.Background(), "incoming-2")
+		_, response, err := dpxService.GetDataProductWithContext(ctx, dpxService.NewGetDataProductOptions("missing"))
+		Expect(err).To(MatchError("Data product not found [correlation_id: incoming-2, trace: trace-123]"))
+		Expect(response.StatusCode).To(Equal(404))
+		var correlatedError *dpxv1.CorrelatedError
+		Expect(errors.As(err, &correlatedError)).To(BeTrue())
+		Expect(correlatedError.CorrelationID).To(Equal("incoming-2"))
+		Expect(correlatedError.Trace).To(Equal("trace-123"))
+
+		dpxService.SetServiceURL("http://127.0.0.1:1")
+		_, _, err = dpxService.GetDataProductWithContext(ctx, dpxService.NewGetDataProductOptions("product-1"))
+		Expect(err).To(MatchError(ContainSubstring("[correlation_id: incoming-2]")))
+	})
+})
@@ -40,6 +40,27 @@ import (
 // API Version: 1.0.0
 type DpxV1 struct {
 	Service *core.BaseService
+
+	// Client-side rate limiters applied to every request and to individual operations.
+	rateLimiter           *RateLimiter
+	operationRateLimiters map[string]*RateLimiter
+
+	// Optional circuit breaker that guards every request.
+	circuitBreaker *CircuitBreaker
+
+	// Interceptors applied to every request, in order.
+	interceptors []Interceptor
+
+	// The container ID sent by the operations that accept one when the caller does not specify it.
+	defaultContainerID string
+
+	// If true, mutating operations are built but not sent (see SetDryRun).
+	dryRun bool
+
+	// Built-in instrumentation, applied before the user's interceptors.
+	tracing *tracingInterceptor
+	metrics *MetricsCollector
+	logging *loggingInterceptor
 }
 
 // DefaultServiceName is the default key used to find external configuration information.
@@ -49,10 +70,13 @@ const DefaultServiceName = "dpx"
 type DpxV1Options struct {
 	ServiceName   string
 	URL           string
+	Region        string
 	Authenticator core.Authenticator
 }
 
 // NewDpxV1UsingExternalConfig : constructs an instance of DpxV1 with passed in options and external configuration.
+// The service URL is, in order of precedence: options.URL, the URL of options.Region, the external URL
+// (e.g. DPX_URL), or the URL of the external region (e.g. DPX_REGION, see GetServiceURLForRegion).
 func NewDpxV1UsingExternalConfig(options *DpxV1Options) (dpx *DpxV1, err error) {
 	if options.ServiceName == "" {
 		options.ServiceName = DefaultServiceName
@@ -74,14 +98,31 @@ func NewDpxV1UsingExternalConfig(options *DpxV1Options) (dpx *DpxV1, err error)
 	if err != nil {
 		return
 	}
+	dpx.installRetryHook()
 
+	if options.URL == "" && options.Region == "" {
+		var serviceProps map[string]string
+		serviceProps, err = core.GetServiceProperties(options.ServiceName)
+		if err != nil {
+			return
+		}
+		if serviceProps[core.PROPNAME_SVC_URL] == "" && serviceProps[regionKey] != "" {
+			options.URL, err = GetServiceURLForRegion(serviceProps[regionKey])
+			if err != nil {
+				return
+			}
+		}
+	}
 	if options.URL != "" {
 		err = dpx.Service.SetServiceURL(options.URL)
+	} else if options.Region != "" {
+		err = dpx.SetServiceURLForRegion(options.Region)
 	}
 	return
 }
 
 // NewDpxV1 : constructs an instance of DpxV1 with passed in options.
+// If options.URL is not set, the service URL of options.Region is used (see GetServiceURLForRegion).
 func NewDpxV1(options *DpxV1Options) (service *DpxV1, err error) {
 	serviceOptions := &core.ServiceOptions{
 		Authenticator: options.Authenticator,
@@ -92,8 +133,15 @@ func NewDpxV1(options *DpxV1Options) (service *DpxV1, err error) {
 		return
 	}
 
-	if options.URL != "" {
-		err = baseService.SetServiceURL(options.URL)
+	serviceURL := options.URL
+	if serviceURL == "" && options.Region != "" {
+		serviceURL, err = GetServiceURLForRegion(options.Region)
+		if err != nil {
+			return
+		}
+	}
+	if serviceURL != "" {
+		err = baseService.SetServiceURL(serviceURL)
 		if err != nil {
 			return
 		}
@@ -106,9 +154,19 @@ func NewDpxV1(options *DpxV1Options) (service *DpxV1, err error) {
 	return
 }
 
-// GetServiceURLForRegion returns the service URL to be used for the specified region
+// GetServiceURLForRegion returns the service URL to be used for the specified region: one of the public IBM Cloud
+// regions (e.g. "us-south", "eu-de"), or a region added with RegisterRegion.
 func GetServiceURLForRegion(region string) (string, error) {
-	return "", fmt.Errorf("service does not support regional URLs")
+	return lookupRegionURL(region)
+}
+
+// SetServiceURLForRegion sets the service URL to the URL of the specified region (see GetServiceURLForRegion).
+func (dpx *DpxV1) SetServiceURLForRegion(region string) error {
+	serviceURL, err := GetServiceURLForRegion(region)
+	if err != nil {
+		return err
+	}
+	return dpx.SetServiceURL(serviceURL)
 }
 
 // Clone makes a copy of "dpx" suitable for processing requests.
@@ -118,6 +176,13 @@ func (dpx *DpxV1) Clone() *DpxV1 {
 	}
 	clone := *dpx
 	clone.Service = dpx.Service.Clone()
+	clone.interceptors = dpx.GetInterceptors()
+	if dpx.operationRateLimiters != nil {
+		clone.operationRateLimiters = make(map[string]*RateLimiter, len(dpx.operationRateLimiters))
+		for operationID, limiter := range dpx.operationRateLimiters {
+			clone.operationRateLimiters[operationID] = limiter
+		}
+	}
 	return &clone
 }
 
@@ -150,6 +215,7 @@ func (dpx *DpxV1) GetEnableGzipCompression() bool {
 // If either parameter is specified as 0, then a default value is used instead.
 func (dpx *DpxV1) EnableRetries(maxRetries int, maxRetryInterval time.Duration) {
 	dpx.Service.EnableRetries(maxRetries, maxRetryInterval)
+	dpx.installRetryHook()
 }
 
 // DisableRetries disables automatic retries for requests invoked for this service instance.
@@ -203,7 +269,7 @@ func (dpx *DpxV1) GetInitializeStatusWithContext(ctx context.Context, getInitial
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("GetInitializeStatus", nil, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -268,6 +334,8 @@ func (dpx *DpxV1) InitializeWithContext(ctx context.Context, initializeOptions *
 	body := make(map[string]interface{})
 	if initializeOptions.Container != nil {
 		body["container"] = initializeOptions.Container
+	} else if dpx.defaultContainerID != "" {
+		body["container"] = &ContainerReference{ID: core.StringPtr(dpx.defaultContainerID)}
 	}
 	if initializeOptions.Include != nil {
 		body["include"] = initializeOptions.Include
@@ -283,7 +351,7 @@ func (dpx *DpxV1) InitializeWithContext(ctx context.Context, initializeOptions *
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("Initialize", nil, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -333,7 +401,7 @@ func (dpx *DpxV1) ManageApiKeysWithContext(ctx context.Context, manageApiKeysOpt
 		return
 	}
 
-	response, err = dpx.Service.Request(request, nil)
+	response, err = dpx.invoke("ManageApiKeys", nil, request, nil)
 
 	return
 }
@@ -382,7 +450,7 @@ func (dpx *DpxV1) ListDataProductsWithContext(ctx context.Context, listDataProdu
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("ListDataProducts", nil, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -450,7 +518,7 @@ func (dpx *DpxV1) CreateDataProductWithContext(ctx context.Context, createDataPr
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("CreateDataProduct", nil, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -510,7 +578,7 @@ func (dpx *DpxV1) GetDataProductWithContext(ctx context.Context, getDataProductO
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("GetDataProduct", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -578,7 +646,7 @@ func (dpx *DpxV1) CompleteDraftContractTermsDocumentWithContext(ctx context.Cont
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("CompleteDraftContractTermsDocument", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -651,7 +719,7 @@ func (dpx *DpxV1) ListDataProductDraftsWithContext(ctx context.Context, listData
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("ListDataProductDrafts", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -757,7 +825,7 @@ func (dpx *DpxV1) CreateDataProductDraftWithContext(ctx context.Context, createD
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("CreateDataProductDraft", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -851,7 +919,7 @@ func (dpx *DpxV1) CreateDraftContractTermsDocumentWithContext(ctx context.Contex
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("CreateDraftContractTermsDocument", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -912,7 +980,7 @@ func (dpx *DpxV1) GetDataProductDraftWithContext(ctx context.Context, getDataPro
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("GetDataProductDraft", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -971,7 +1039,7 @@ func (dpx *DpxV1) DeleteDataProductDraftWithContext(ctx context.Context, deleteD
 		return
 	}
 
-	response, err = dpx.Service.Request(request, nil)
+	response, err = dpx.invoke("DeleteDataProductDraft", pathParamsMap, request, nil)
 
 	return
 }
@@ -1031,7 +1099,7 @@ func (dpx *DpxV1) UpdateDataProductDraftWithContext(ctx context.Context, updateD
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("UpdateDataProductDraft", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1097,7 +1165,7 @@ func (dpx *DpxV1) GetDraftContractTermsDocumentWithContext(ctx context.Context,
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("GetDraftContractTermsDocument", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1160,7 +1228,7 @@ func (dpx *DpxV1) DeleteDraftContractTermsDocumentWithContext(ctx context.Contex
 		return
 	}
 
-	response, err = dpx.Service.Request(request, nil)
+	response, err = dpx.invoke("DeleteDraftContractTermsDocument", pathParamsMap, request, nil)
 
 	return
 }
@@ -1226,7 +1294,7 @@ func (dpx *DpxV1) UpdateDraftContractTermsDocumentWithContext(ctx context.Contex
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("UpdateDraftContractTermsDocument", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1287,7 +1355,7 @@ func (dpx *DpxV1) PublishDataProductDraftWithContext(ctx context.Context, publis
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("PublishDataProductDraft", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1348,7 +1416,7 @@ func (dpx *DpxV1) GetDataProductReleaseWithContext(ctx context.Context, getDataP
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("GetDataProductRelease", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1418,7 +1486,7 @@ func (dpx *DpxV1) UpdateDataProductReleaseWithContext(ctx context.Context, updat
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("UpdateDataProductRelease", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1484,7 +1552,7 @@ func (dpx *DpxV1) GetReleaseContractTermsDocumentWithContext(ctx context.Context
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("GetReleaseContractTermsDocument", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1560,7 +1628,7 @@ func (dpx *DpxV1) ListDataProductReleasesWithContext(ctx context.Context, listDa
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("ListDataProductReleases", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1621,7 +1689,7 @@ func (dpx *DpxV1) RetireDataProductReleaseWithContext(ctx context.Context, retir
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = dpx.Service.Request(request, &rawResponse)
+	response, err = dpx.invoke("RetireDataProductRelease", pathParamsMap, request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -4263,7 +4331,7 @@ func UnmarshalUseCase(m map[string]json.RawMessage, result interface{}) (err err
 type DataProductsPager struct {
 	hasNext     bool
 	options     *ListDataProductsOptions
-	client      *DpxV1
+	client      DpxV1API
 	pageContext struct {
 		next *string
 	}
@@ -4271,6 +4339,11 @@ type DataProductsPager struct {
 
 // NewDataProductsPager returns a new DataProductsPager instance.
 func (dpx *DpxV1) NewDataProductsPager(options *ListDataProductsOptions) (pager *DataProductsPager, err error) {
+	return NewDataProductsPagerForClient(dpx, options)
+}
+
+// NewDataProductsPagerForClient returns a new DataProductsPager instance that retrieves its pages using "client".
+func NewDataProductsPagerForClient(client DpxV1API, options *ListDataProductsOptions) (pager *DataProductsPager, err error) {
 	if options.Start != nil && *options.Start != "" {
 		err = fmt.Errorf("the 'options.Start' field should not be set")
 		return
@@ -4280,7 +4353,7 @@ func (dpx *DpxV1) NewDataProductsPager(options *ListDataProductsOptions) (pager
 	pager = &DataProductsPager{
 		hasNext: true,
 		options: &optionsCopy,
-		client:  dpx,
+		client:  client,
 	}
 	return
 }
@@ -4342,7 +4415,7 @@ func (pager *DataProductsPager) GetAll() (allItems []DataProductSummary, err err
 type DataProductDraftsPager struct {
 	hasNext     bool
 	options     *ListDataProductDraftsOptions
-	client      *DpxV1
+	client      DpxV1API
 	pageContext struct {
 		next *string
 	}
@@ -4350,6 +4423,11 @@ type DataProductDraftsPager struct {
 
 // NewDataProductDraftsPager returns a new DataProductDraftsPager instance.
 func (dpx *DpxV1) NewDataProductDraftsPager(options *ListDataProductDraftsOptions) (pager *DataProductDraftsPager, err error) {
+	return NewDataProductDraftsPagerForClient(dpx, options)
+}
+
+// NewDataProductDraftsPagerForClient returns a new DataProductDraftsPager instance that retrieves its pages using "client".
+func NewDataProductDraftsPagerForClient(client DpxV1API, options *ListDataProductDraftsOptions) (pager *DataProductDraftsPager, err error) {
 	if options.Start != nil && *options.Start != "" {
 		err = fmt.Errorf("the 'options.Start' field should not be set")
 		return
@@ -4359,7 +4437,7 @@ func (dpx *DpxV1) NewDataProductDraftsPager(options *ListDataProductDraftsOption
 	pager = &DataProductDraftsPager{
 		hasNext: true,
 		options: &optionsCopy,
-		client:  dpx,
+		client:  client,
 	}
 	return
 }
@@ -4421,7 +4499,7 @@ func (pager *DataProductDraftsPager) GetAll() (allItems []DataProductVersionSumm
 type DataProductReleasesPager struct {
 	hasNext     bool
 	options     *ListDataProductReleasesOptions
-	client      *DpxV1
+	client      DpxV1API
 	pageContext struct {
 		next *string
 	}
@@ -4429,6 +4507,11 @@ type DataProductReleasesPager struct {
 
 // NewDataProductReleasesPager returns a new DataProductReleasesPager instance.
 func (dpx *DpxV1) NewDataProductReleasesPager(options *ListDataProductReleasesOptions) (pager *DataProductReleasesPager, err error) {
+	return NewDataProductReleasesPagerForClient(dpx, options)
+}
+
+// NewDataProductReleasesPagerForClient returns a new DataProductReleasesPager instance that retrieves its pages using "client".
+func NewDataProductReleasesPagerForClient(client DpxV1API, options *ListDataProductReleasesOptions) (pager *DataProductReleasesPager, err error) {
 	if options.Start != nil && *options.Start != "" {
 		err = fmt.Errorf("the 'options.Start' field should not be set")
 		return
@@ -4438,7 +4521,7 @@ func (dpx *DpxV1) NewDataProductReleasesPager(options *ListDataProductReleasesOp
 	pager = &DataProductReleasesPager{
 		hasNext: true,
 		options: &optionsCopy,
-		client:  dpx,
+		client:  client,
 	}
 	return
 }
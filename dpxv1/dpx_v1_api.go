@@ -0,0 +1,163 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"context"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// DpxV1API is the interface implemented by DpxV1. It contains every operation of the service (in both its plain
+// and WithContext forms), the pager constructors, and the constructors of the operations' options and models.
+//
+// Code that depends on DpxV1API rather than on *DpxV1 can be tested with a mock implementation
+// such as dpxv1mock.MockDpxV1.
+type DpxV1API interface {
+	// GetInitializeStatus : Get resource initialization status
+	GetInitializeStatus(getInitializeStatusOptions *GetInitializeStatusOptions) (result *InitializeResource, response *core.DetailedResponse, err error)
+	GetInitializeStatusWithContext(ctx context.Context, getInitializeStatusOptions *GetInitializeStatusOptions) (result *InitializeResource, response *core.DetailedResponse, err error)
+
+	// Initialize : Initialize resources
+	Initialize(initializeOptions *InitializeOptions) (result *InitializeResource, response *core.DetailedResponse, err error)
+	InitializeWithContext(ctx context.Context, initializeOptions *InitializeOptions) (result *InitializeResource, response *core.DetailedResponse, err error)
+
+	// ManageApiKeys : Rotate credentials for a Data Product Exchange instance
+	ManageApiKeys(manageApiKeysOptions *ManageApiKeysOptions) (response *core.DetailedResponse, err error)
+	ManageApiKeysWithContext(ctx context.Context, manageApiKeysOptions *ManageApiKeysOptions) (response *core.DetailedResponse, err error)
+
+	// ListDataProducts : Retrieve a list of data products
+	ListDataProducts(listDataProductsOptions *ListDataProductsOptions) (result *DataProductSummaryCollection, response *core.DetailedResponse, err error)
+	ListDataProductsWithContext(ctx context.Context, listDataProductsOptions *ListDataProductsOptions) (result *DataProductSummaryCollection, response *core.DetailedResponse, err error)
+
+	// CreateDataProduct : Create a new data product
+	CreateDataProduct(createDataProductOptions *CreateDataProductOptions) (result *DataProduct, response *core.DetailedResponse, err error)
+	CreateDataProductWithContext(ctx context.Context, createDataProductOptions *CreateDataProductOptions) (result *DataProduct, response *core.DetailedResponse, err error)
+
+	// GetDataProduct : Retrieve a data product identified by id
+	GetDataProduct(getDataProductOptions *GetDataProductOptions) (result *DataProduct, response *core.DetailedResponse, err error)
+	GetDataProductWithContext(ctx context.Context, getDataProductOptions *GetDataProductOptions) (result *DataProduct, response *core.DetailedResponse, err error)
+
+	// CompleteDraftContractTermsDocument : Complete a contract document upload operation
+	CompleteDraftContractTermsDocument(completeDraftContractTermsDocumentOptions *CompleteDraftContractTermsDocumentOptions) (result *ContractTermsDocument, response *core.DetailedResponse, err error)
+	CompleteDraftContractTermsDocumentWithContext(ctx context.Context, completeDraftContractTermsDocumentOptions *CompleteDraftContractTermsDocumentOptions) (result *ContractTermsDocument, response *core.DetailedResponse, err error)
+
+	// ListDataProductDrafts : Retrieve a list of data product drafts
+	ListDataProductDrafts(listDataProductDraftsOptions *ListDataProductDraftsOptions) (result *DataProductDraftCollection, response *core.DetailedResponse, err error)
+	ListDataProductDraftsWithContext(ctx context.Context, listDataProductDraftsOptions *ListDataProductDraftsOptions) (result *DataProductDraftCollection, response *core.DetailedResponse, err error)
+
+	// CreateDataProductDraft : Create a new draft of an existing data product
+	CreateDataProductDraft(createDataProductDraftOptions *CreateDataProductDraftOptions) (result *DataProductVersion, response *core.DetailedResponse, err error)
+	CreateDataProductDraftWithContext(ctx context.Context, createDataProductDraftOptions *CreateDataProductDraftOptions) (result *DataProductVersion, response *core.DetailedResponse, err error)
+
+	// CreateDraftContractTermsDocument : Upload a contract document to the data product draft contract terms
+	CreateDraftContractTermsDocument(createDraftContractTermsDocumentOptions *CreateDraftContractTermsDocumentOptions) (result *ContractTermsDocument, response *core.DetailedResponse, err error)
+	CreateDraftContractTermsDocumentWithContext(ctx context.Context, createDraftContractTermsDocumentOptions *CreateDraftContractTermsDocumentOptions) (result *ContractTermsDocument, response *core.DetailedResponse, err error)
+
+	// GetDataProductDraft : Get a draft of an existing data product
+	GetDataProductDraft(getDataProductDraftOptions *GetDataProductDraftOptions) (result *DataProductVersion, response *core.DetailedResponse, err error)
+	GetDataProductDraftWithContext(ctx context.Context, getDataProductDraftOptions *GetDataProductDraftOptions) (result *DataProductVersion, response *core.DetailedResponse, err error)
+
+	// DeleteDataProductDraft : Delete a data product draft identified by ID
+	DeleteDataProductDraft(deleteDataProductDraftOptions *DeleteDataProductDraftOptions) (response *core.DetailedResponse, err error)
+	DeleteDataProductDraftWithContext(ctx context.Context, deleteDataProductDraftOptions *DeleteDataProductDraftOptions) (response *core.DetailedResponse, err error)
+
+	// UpdateDataProductDraft : Update the data product draft identified by ID
+	UpdateDataProductDraft(updateDataProductDraftOptions *UpdateDataProductDraftOptions) (result *DataProductVersion, response *core.DetailedResponse, err error)
+	UpdateDataProductDraftWithContext(ctx context.Context, updateDataProductDraftOptions *UpdateDataProductDraftOptions) (result *DataProductVersion, response *core.DetailedResponse, err error)
+
+	// GetDraftContractTermsDocument : Get a contract document
+	GetDraftContractTermsDocument(getDraftContractTermsDocumentOptions *GetDraftContractTermsDocumentOptions) (result *ContractTermsDocument, response *core.DetailedResponse, err error)
+	GetDraftContractTermsDocumentWithContext(ctx context.Context, getDraftContractTermsDocumentOptions *GetDraftContractTermsDocumentOptions) (result *ContractTermsDocument, response *core.DetailedResponse, err error)
+
+	// DeleteDraftContractTermsDocument : Delete a contract document
+	DeleteDraftContractTermsDocument(deleteDraftContractTermsDocumentOptions *DeleteDraftContractTermsDocumentOptions) (response *core.DetailedResponse, err error)
+	DeleteDraftContractTermsDocumentWithContext(ctx context.Context, deleteDraftContractTermsDocumentOptions *DeleteDraftContractTermsDocumentOptions) (response *core.DetailedResponse, err error)
+
+	// UpdateDraftContractTermsDocument : Update a contract document
+	UpdateDraftContractTermsDocument(updateDraftContractTermsDocumentOptions *UpdateDraftContractTermsDocumentOptions) (result *ContractTermsDocument, response *core.DetailedResponse, err error)
+	UpdateDraftContractTermsDocumentWithContext(ctx context.Context, updateDraftContractTermsDocumentOptions *UpdateDraftContractTermsDocumentOptions) (result *ContractTermsDocument, response *core.DetailedResponse, err error)
+
+	// PublishDataProductDraft : Publish a draft of an existing data product
+	PublishDataProductDraft(publishDataProductDraftOptions *PublishDataProductDraftOptions) (result *DataProductVersion, response *core.DetailedResponse, err error)
+	PublishDataProductDraftWithContext(ctx context.Context, publishDataProductDraftOptions *PublishDataProductDraftOptions) (result *DataProductVersion, response *core.DetailedResponse, err error)
+
+	// GetDataProductRelease : Get a release of an existing data product
+	GetDataProductRelease(getDataProductReleaseOptions *GetDataProductReleaseOptions) (result *DataProductVersion, response *core.DetailedResponse, err error)
+	GetDataProductReleaseWithContext(ctx context.Context, getDataProductReleaseOptions *GetDataProductReleaseOptions) (result *DataProductVersion, response *core.DetailedResponse, err error)
+
+	// UpdateDataProductRelease : Update the data product release identified by ID
+	UpdateDataProductRelease(updateDataProductReleaseOptions *UpdateDataProductReleaseOptions) (result *DataProductVersion, response *core.DetailedResponse, err error)
+	UpdateDataProductReleaseWithContext(ctx context.Context, updateDataProductReleaseOptions *UpdateDataProductReleaseOptions) (result *DataProductVersion, response *core.DetailedResponse, err error)
+
+	// GetReleaseContractTermsDocument : Get a contract document
+	GetReleaseContractTermsDocument(getReleaseContractTermsDocumentOptions *GetReleaseContractTermsDocumentOptions) (result *ContractTermsDocument, response *core.DetailedResponse, err error)
+	GetReleaseContractTermsDocumentWithContext(ctx context.Context, getReleaseContractTermsDocumentOptions *GetReleaseContractTermsDocumentOptions) (result *ContractTermsDocument, response *core.DetailedResponse, err error)
+
+	// ListDataProductReleases : Retrieve a list of data product releases
+	ListDataProductReleases(listDataProductReleasesOptions *ListDataProductReleasesOptions) (result *DataProductReleaseCollection, response *core.DetailedResponse, err error)
+	ListDataProductReleasesWithContext(ctx context.Context, listDataProductReleasesOptions *ListDataProductReleasesOptions) (result *DataProductReleaseCollection, response *core.DetailedResponse, err error)
+
+	// RetireDataProductRelease : Retire a release of an existing data product
+	RetireDataProductRelease(retireDataProductReleaseOptions *RetireDataProductReleaseOptions) (result *DataProductVersion, response *core.DetailedResponse, err error)
+	RetireDataProductReleaseWithContext(ctx context.Context, retireDataProductReleaseOptions *RetireDataProductReleaseOptions) (result *DataProductVersion, response *core.DetailedResponse, err error)
+
+	// Pagers
+	NewDataProductsPager(options *ListDataProductsOptions) (pager *DataProductsPager, err error)
+	NewDataProductDraftsPager(options *ListDataProductDraftsOptions) (pager *DataProductDraftsPager, err error)
+	NewDataProductReleasesPager(options *ListDataProductReleasesOptions) (pager *DataProductReleasesPager, err error)
+
+	// Constructors of options and models
+	NewAssetPartReference(container *ContainerReference) (_model *AssetPartReference, err error)
+	NewAssetReference(container *ContainerReference) (_model *AssetReference, err error)
+	NewCompleteDraftContractTermsDocumentOptions(dataProductID string, draftID string, contractTermsID string, documentID string) *CompleteDraftContractTermsDocumentOptions
+	NewContainerReference(id string) (_model *ContainerReference, err error)
+	NewContractTermsDocument(typeVar string, name string, id string) (_model *ContractTermsDocument, err error)
+	NewContractTermsDocumentPatch(contractTermsDocument *ContractTermsDocument) (_patch []JSONPatchOperation)
+	NewCreateDataProductDraftOptions(dataProductID string, asset *AssetReference) *CreateDataProductDraftOptions
+	NewCreateDataProductOptions(drafts []DataProductVersionPrototype) *CreateDataProductOptions
+	NewCreateDraftContractTermsDocumentOptions(dataProductID string, draftID string, contractTermsID string, typeVar string, name string, id string, url string) *CreateDraftContractTermsDocumentOptions
+	NewDataProductIdentity(id string) (_model *DataProductIdentity, err error)
+	NewDataProductPart(asset *AssetPartReference) (_model *DataProductPart, err error)
+	NewDataProductVersionPatch(dataProductVersion *DataProductVersion) (_patch []JSONPatchOperation)
+	NewDataProductVersionPrototype(asset *AssetReference) (_model *DataProductVersionPrototype, err error)
+	NewDeleteDataProductDraftOptions(dataProductID string, draftID string) *DeleteDataProductDraftOptions
+	NewDeleteDraftContractTermsDocumentOptions(dataProductID string, draftID string, contractTermsID string, documentID string) *DeleteDraftContractTermsDocumentOptions
+	NewDeliveryMethod(id string, container *ContainerReference) (_model *DeliveryMethod, err error)
+	NewDomain(id string) (_model *Domain, err error)
+	NewGetDataProductDraftOptions(dataProductID string, draftID string) *GetDataProductDraftOptions
+	NewGetDataProductOptions(dataProductID string) *GetDataProductOptions
+	NewGetDataProductReleaseOptions(dataProductID string, releaseID string) *GetDataProductReleaseOptions
+	NewGetDraftContractTermsDocumentOptions(dataProductID string, draftID string, contractTermsID string, documentID string) *GetDraftContractTermsDocumentOptions
+	NewGetInitializeStatusOptions() *GetInitializeStatusOptions
+	NewGetReleaseContractTermsDocumentOptions(dataProductID string, releaseID string, contractTermsID string, documentID string) *GetReleaseContractTermsDocumentOptions
+	NewInitializeOptions() *InitializeOptions
+	NewJSONPatchOperation(op string, path string) (_model *JSONPatchOperation, err error)
+	NewListDataProductDraftsOptions(dataProductID string) *ListDataProductDraftsOptions
+	NewListDataProductReleasesOptions(dataProductID string) *ListDataProductReleasesOptions
+	NewListDataProductsOptions() *ListDataProductsOptions
+	NewManageApiKeysOptions() *ManageApiKeysOptions
+	NewPublishDataProductDraftOptions(dataProductID string, draftID string) *PublishDataProductDraftOptions
+	NewRetireDataProductReleaseOptions(dataProductID string, releaseID string) *RetireDataProductReleaseOptions
+	NewUpdateDataProductDraftOptions(dataProductID string, draftID string, jsonPatchInstructions []JSONPatchOperation) *UpdateDataProductDraftOptions
+	NewUpdateDataProductReleaseOptions(dataProductID string, releaseID string, jsonPatchInstructions []JSONPatchOperation) *UpdateDataProductReleaseOptions
+	NewUpdateDraftContractTermsDocumentOptions(dataProductID string, draftID string, contractTermsID string, documentID string, jsonPatchInstructions []JSONPatchOperation) *UpdateDraftContractTermsDocumentOptions
+	NewUseCase(id string) (_model *UseCase, err error)
+}
+
+// Make sure that DpxV1 implements DpxV1API.
+var _ DpxV1API = (*DpxV1)(nil)
@@ -0,0 +1,90 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1/dpxv1mock"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`MockDpxV1`, func() {
+	var mock *dpxv1mock.MockDpxV1
+	var api dpxv1.DpxV1API
+
+	BeforeEach(func() {
+		mock = dpxv1mock.NewMockDpxV1()
+		api = mock
+	})
+
+	It(`Records calls and returns programmed responses`, func() {
+		type ctxKey struct{}
+		mock.GetDataProductFunc = func(ctx context.Context, options *dpxv1.GetDataProductOptions) (*dpxv1.DataProduct, *core.DetailedResponse, error) {
+			return &dpxv1.DataProduct{ID: options.DataProductID}, &core.DetailedResponse{StatusCode: 200}, nil
+		}
+
+		result, response, err := api.GetDataProduct(api.NewGetDataProductOptions("product-1"))
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(200))
+		Expect(*result.ID).To(Equal("product-1"))
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+		_, _, err = api.GetDataProductWithContext(ctx, api.NewGetDataProductOptions("product-2"))
+		Expect(err).To(BeNil())
+
+		calls := mock.CallsTo("GetDataProduct")
+		Expect(calls).To(HaveLen(2))
+		Expect(*calls[0].Options.(*dpxv1.GetDataProductOptions).DataProductID).To(Equal("product-1"))
+		Expect(calls[1].Context.Value(ctxKey{})).To(Equal("value"))
+
+		mock.ResetCalls()
+		Expect(mock.Calls()).To(BeEmpty())
+	})
+	It(`Returns an error from operations that are not programmed`, func() {
+		_, err := api.DeleteDataProductDraft(api.NewDeleteDataProductDraftOptions("product-1", "draft-1"))
+		Expect(errors.Is(err, dpxv1mock.ErrNotProgrammed)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("DeleteDataProductDraft"))
+		Expect(mock.Calls()).To(HaveLen(1))
+	})
+	It(`Returns pagers that retrieve their pages from the mock`, func() {
+		mock.ListDataProductsFunc = func(ctx context.Context, options *dpxv1.ListDataProductsOptions) (*dpxv1.DataProductSummaryCollection, *core.DetailedResponse, error) {
+			if options.Start == nil {
+				return &dpxv1.DataProductSummaryCollection{
+					Next:         &dpxv1.NextPage{Start: core.StringPtr("page-2")},
+					DataProducts: []dpxv1.DataProductSummary{{ID: core.StringPtr("product-1")}},
+				}, nil, nil
+			}
+			return &dpxv1.DataProductSummaryCollection{
+				DataProducts: []dpxv1.DataProductSummary{{ID: core.StringPtr("product-2")}},
+			}, nil, nil
+		}
+
+		pager, err := api.NewDataProductsPager(api.NewListDataProductsOptions())
+		Expect(err).To(BeNil())
+		allItems, err := pager.GetAll()
+		Expect(err).To(BeNil())
+		Expect(allItems).To(HaveLen(2))
+		Expect(*allItems[1].ID).To(Equal("product-2"))
+		Expect(mock.CallsTo("NewDataProductsPager")).To(HaveLen(1))
+		Expect(mock.CallsTo("ListDataProducts")).To(HaveLen(2))
+	})
+})
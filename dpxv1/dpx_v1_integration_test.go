@@ -35,17 +35,26 @@ import (
  *
  * Notes:
  *
- * The integration test will automatically skip tests if the required config file is not available.
+ * By default, the integration test replays the cassette testdata/dpx_v1_integration.cassette.json, so it runs
+ * without access to the service.
+ *
+ * Set DPX_CASSETTE to the path of another cassette file to record the traffic of the tests (using the required
+ * config file), or to replay a previously recorded cassette without it. Set DPX_CASSETTE_MODE to "record"
+ * or "replay" to override the default mode, which is to replay the cassette if the file exists. When
+ * recording, the tests are skipped if the required config file is not available.
  */
 
 var _ = Describe(`DpxV1 Integration Tests`, func() {
 	const externalConfigFile = "../dpx_v1.env"
+	const replayServiceURL = "https://dpx.replay.test"
+	const defaultCassetteFile = "testdata/dpx_v1_integration.cassette.json"
 
 	var (
 		err        error
 		dpxService *dpxv1.DpxV1
 		serviceURL string
 		config     map[string]string
+		recorder   *dpxv1.Recorder
 
 		// Variables to hold link values
 		containerIdLink           string
@@ -63,6 +72,23 @@ var _ = Describe(`DpxV1 Integration Tests`, func() {
 
 	Describe(`External configuration`, func() {
 		It("Successfully load the configuration", func() {
+			cassetteFile := os.Getenv("DPX_CASSETTE")
+			cassetteMode := dpxv1.RecorderMode(os.Getenv("DPX_CASSETTE_MODE"))
+			if cassetteFile == "" {
+				cassetteFile = defaultCassetteFile
+				if cassetteMode == "" {
+					cassetteMode = dpxv1.RecorderModeReplay
+				}
+			}
+			recorder, err = dpxv1.NewRecorder(cassetteFile, &dpxv1.RecorderOptions{Mode: cassetteMode})
+			Expect(err).To(BeNil())
+			if recorder.Mode() == dpxv1.RecorderModeReplay {
+				serviceURL = replayServiceURL
+				fmt.Fprintf(GinkgoWriter, "Replaying cassette: %v\n", cassetteFile)
+				shouldSkipTest = func() {}
+				return
+			}
+
 			_, err = os.Stat(externalConfigFile)
 			if err != nil {
 				Skip("External configuration file not found, skipping tests: " + err.Error())
@@ -90,13 +116,20 @@ var _ = Describe(`DpxV1 Integration Tests`, func() {
 		It("Successfully construct the service client instance", func() {
 			dpxServiceOptions := &dpxv1.DpxV1Options{}
 
-			dpxService, err = dpxv1.NewDpxV1UsingExternalConfig(dpxServiceOptions)
+			if recorder.Mode() == dpxv1.RecorderModeReplay {
+				dpxServiceOptions.URL = serviceURL
+				dpxServiceOptions.Authenticator = &core.NoAuthAuthenticator{}
+				dpxService, err = dpxv1.NewDpxV1(dpxServiceOptions)
+			} else {
+				dpxService, err = dpxv1.NewDpxV1UsingExternalConfig(dpxServiceOptions)
+			}
 			Expect(err).To(BeNil())
 			Expect(dpxService).ToNot(BeNil())
 			Expect(dpxService.Service.Options.URL).To(Equal(serviceURL))
 
 			core.SetLogger(core.NewLogger(core.LevelDebug, log.New(GinkgoWriter, "", log.LstdFlags), log.New(GinkgoWriter, "", log.LstdFlags)))
 			dpxService.EnableRetries(4, 30*time.Second)
+			dpxService.SetRecorder(recorder)
 		})
 	})
 
@@ -969,6 +1002,14 @@ var _ = Describe(`DpxV1 Integration Tests`, func() {
 		})
 	})
 
+	Describe(`Cassette`, func() {
+		BeforeEach(func() {
+			shouldSkipTest()
+		})
+		It("Successfully save the recorded cassette", func() {
+			Expect(recorder.Save()).To(BeNil())
+		})
+	})
 })
 
 //
@@ -0,0 +1,644 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package dpxv1mock provides a mock implementation of the dpxv1.DpxV1API interface for use in tests.
+package dpxv1mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// ErrNotProgrammed is returned (wrapped) by the operations of a MockDpxV1 whose behavior has not been programmed.
+var ErrNotProgrammed = errors.New("mock operation not programmed")
+
+// Call : A call to an operation or pager constructor of a MockDpxV1.
+type Call struct {
+	// The name of the operation or pager constructor (e.g. "GetDataProduct" for both GetDataProduct and
+	// GetDataProductWithContext).
+	Method string
+
+	// The Context passed to the operation, or context.Background() for the forms without a Context.
+	Context context.Context
+
+	// The options passed to the operation (e.g. *dpxv1.GetDataProductOptions).
+	Options interface{}
+}
+
+// MockDpxV1 is a mock implementation of dpxv1.DpxV1API that records its calls and returns programmable responses.
+//
+// The behavior of each operation is programmed by setting the corresponding "Func" field; an operation whose
+// function is nil returns an error that wraps ErrNotProgrammed. The pagers returned by the pager constructors
+// retrieve their pages from the mock's List operations, and the constructors of options and models behave like
+// those of dpxv1.DpxV1. A MockDpxV1 is safe for concurrent use once its functions have been set.
+type MockDpxV1 struct {
+	// The functions that implement the operations of the mock, named after the operations.
+	GetInitializeStatusFunc                func(ctx context.Context, getInitializeStatusOptions *dpxv1.GetInitializeStatusOptions) (*dpxv1.InitializeResource, *core.DetailedResponse, error)
+	InitializeFunc                         func(ctx context.Context, initializeOptions *dpxv1.InitializeOptions) (*dpxv1.InitializeResource, *core.DetailedResponse, error)
+	ManageApiKeysFunc                      func(ctx context.Context, manageApiKeysOptions *dpxv1.ManageApiKeysOptions) (*core.DetailedResponse, error)
+	ListDataProductsFunc                   func(ctx context.Context, listDataProductsOptions *dpxv1.ListDataProductsOptions) (*dpxv1.DataProductSummaryCollection, *core.DetailedResponse, error)
+	CreateDataProductFunc                  func(ctx context.Context, createDataProductOptions *dpxv1.CreateDataProductOptions) (*dpxv1.DataProduct, *core.DetailedResponse, error)
+	GetDataProductFunc                     func(ctx context.Context, getDataProductOptions *dpxv1.GetDataProductOptions) (*dpxv1.DataProduct, *core.DetailedResponse, error)
+	CompleteDraftContractTermsDocumentFunc func(ctx context.Context, completeDraftContractTermsDocumentOptions *dpxv1.CompleteDraftContractTermsDocumentOptions) (*dpxv1.ContractTermsDocument, *core.DetailedResponse, error)
+	ListDataProductDraftsFunc              func(ctx context.Context, listDataProductDraftsOptions *dpxv1.ListDataProductDraftsOptions) (*dpxv1.DataProductDraftCollection, *core.DetailedResponse, error)
+	CreateDataProductDraftFunc             func(ctx context.Context, createDataProductDraftOptions *dpxv1.CreateDataProductDraftOptions) (*dpxv1.DataProductVersion, *core.DetailedResponse, error)
+	CreateDraftContractTermsDocumentFunc   func(ctx context.Context, createDraftContractTermsDocumentOptions *dpxv1.CreateDraftContractTermsDocumentOptions) (*dpxv1.ContractTermsDocument, *core.DetailedResponse, error)
+	GetDataProductDraftFunc                func(ctx context.Context, getDataProductDraftOptions *dpxv1.GetDataProductDraftOptions) (*dpxv1.DataProductVersion, *core.DetailedResponse, error)
+	DeleteDataProductDraftFunc             func(ctx context.Context, deleteDataProductDraftOptions *dpxv1.DeleteDataProductDraftOptions) (*core.DetailedResponse, error)
+	UpdateDataProductDraftFunc             func(ctx context.Context, updateDataProductDraftOptions *dpxv1.UpdateDataProductDraftOptions) (*dpxv1.DataProductVersion, *core.DetailedResponse, error)
+	GetDraftContractTermsDocumentFunc      func(ctx context.Context, getDraftContractTermsDocumentOptions *dpxv1.GetDraftContractTermsDocumentOptions) (*dpxv1.ContractTermsDocument, *core.DetailedResponse, error)
+	DeleteDraftContractTermsDocumentFunc   func(ctx context.Context, deleteDraftContractTermsDocumentOptions *dpxv1.DeleteDraftContractTermsDocumentOptions) (*core.DetailedResponse, error)
+	UpdateDraftContractTermsDocumentFunc   func(ctx context.Context, updateDraftContractTermsDocumentOptions *dpxv1.UpdateDraftContractTermsDocumentOptions) (*dpxv1.ContractTermsDocument, *core.DetailedResponse, error)
+	PublishDataProductDraftFunc            func(ctx context.Context, publishDataProductDraftOptions *dpxv1.PublishDataProductDraftOptions) (*dpxv1.DataProductVersion, *core.DetailedResponse, error)
+	GetDataProductReleaseFunc              func(ctx context.Context, getDataProductReleaseOptions *dpxv1.GetDataProductReleaseOptions) (*dpxv1.DataProductVersion, *core.DetailedResponse, error)
+	UpdateDataProductReleaseFunc           func(ctx context.Context, updateDataProductReleaseOptions *dpxv1.UpdateDataProductReleaseOptions) (*dpxv1.DataProductVersion, *core.DetailedResponse, error)
+	GetReleaseContractTermsDocumentFunc    func(ctx context.Context, getReleaseContractTermsDocumentOptions *dpxv1.GetReleaseContractTermsDocumentOptions) (*dpxv1.ContractTermsDocument, *core.DetailedResponse, error)
+	ListDataProductReleasesFunc            func(ctx context.Context, listDataProductReleasesOptions *dpxv1.ListDataProductReleasesOptions) (*dpxv1.DataProductReleaseCollection, *core.DetailedResponse, error)
+	RetireDataProductReleaseFunc           func(ctx context.Context, retireDataProductReleaseOptions *dpxv1.RetireDataProductReleaseOptions) (*dpxv1.DataProductVersion, *core.DetailedResponse, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Make sure that MockDpxV1 implements DpxV1API.
+var _ dpxv1.DpxV1API = (*MockDpxV1)(nil)
+
+// NewMockDpxV1 returns a new MockDpxV1 with no programmed operations.
+func NewMockDpxV1() *MockDpxV1 {
+	return &MockDpxV1{}
+}
+
+// Calls returns the calls made to the mock, in order.
+func (mock *MockDpxV1) Calls() []Call {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	return append([]Call(nil), mock.calls...)
+}
+
+// CallsTo returns the calls made to the operation or pager constructor "method", in order.
+func (mock *MockDpxV1) CallsTo(method string) (calls []Call) {
+	for _, call := range mock.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return
+}
+
+// ResetCalls forgets the calls made to the mock.
+func (mock *MockDpxV1) ResetCalls() {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	mock.calls = nil
+}
+
+func (mock *MockDpxV1) record(method string, ctx context.Context, options interface{}) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	mock.calls = append(mock.calls, Call{Method: method, Context: ctx, Options: options})
+}
+
+func notProgrammed(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotProgrammed, method)
+}
+
+// GetInitializeStatus calls GetInitializeStatusWithContext with context.Background().
+func (mock *MockDpxV1) GetInitializeStatus(getInitializeStatusOptions *dpxv1.GetInitializeStatusOptions) (result *dpxv1.InitializeResource, response *core.DetailedResponse, err error) {
+	return mock.GetInitializeStatusWithContext(context.Background(), getInitializeStatusOptions)
+}
+
+// GetInitializeStatusWithContext records the call and invokes GetInitializeStatusFunc.
+func (mock *MockDpxV1) GetInitializeStatusWithContext(ctx context.Context, getInitializeStatusOptions *dpxv1.GetInitializeStatusOptions) (result *dpxv1.InitializeResource, response *core.DetailedResponse, err error) {
+	mock.record("GetInitializeStatus", ctx, getInitializeStatusOptions)
+	if mock.GetInitializeStatusFunc == nil {
+		err = notProgrammed("GetInitializeStatus")
+		return
+	}
+	return mock.GetInitializeStatusFunc(ctx, getInitializeStatusOptions)
+}
+
+// Initialize calls InitializeWithContext with context.Background().
+func (mock *MockDpxV1) Initialize(initializeOptions *dpxv1.InitializeOptions) (result *dpxv1.InitializeResource, response *core.DetailedResponse, err error) {
+	return mock.InitializeWithContext(context.Background(), initializeOptions)
+}
+
+// InitializeWithContext records the call and invokes InitializeFunc.
+func (mock *MockDpxV1) InitializeWithContext(ctx context.Context, initializeOptions *dpxv1.InitializeOptions) (result *dpxv1.InitializeResource, response *core.DetailedResponse, err error) {
+	mock.record("Initialize", ctx, initializeOptions)
+	if mock.InitializeFunc == nil {
+		err = notProgrammed("Initialize")
+		return
+	}
+	return mock.InitializeFunc(ctx, initializeOptions)
+}
+
+// ManageApiKeys calls ManageApiKeysWithContext with context.Background().
+func (mock *MockDpxV1) ManageApiKeys(manageApiKeysOptions *dpxv1.ManageApiKeysOptions) (response *core.DetailedResponse, err error) {
+	return mock.ManageApiKeysWithContext(context.Background(), manageApiKeysOptions)
+}
+
+// ManageApiKeysWithContext records the call and invokes ManageApiKeysFunc.
+func (mock *MockDpxV1) ManageApiKeysWithContext(ctx context.Context, manageApiKeysOptions *dpxv1.ManageApiKeysOptions) (response *core.DetailedResponse, err error) {
+	mock.record("ManageApiKeys", ctx, manageApiKeysOptions)
+	if mock.ManageApiKeysFunc == nil {
+		err = notProgrammed("ManageApiKeys")
+		return
+	}
+	return mock.ManageApiKeysFunc(ctx, manageApiKeysOptions)
+}
+
+// ListDataProducts calls ListDataProductsWithContext with context.Background().
+func (mock *MockDpxV1) ListDataProducts(listDataProductsOptions *dpxv1.ListDataProductsOptions) (result *dpxv1.DataProductSummaryCollection, response *core.DetailedResponse, err error) {
+	return mock.ListDataProductsWithContext(context.Background(), listDataProductsOptions)
+}
+
+// ListDataProductsWithContext records the call and invokes ListDataProductsFunc.
+func (mock *MockDpxV1) ListDataProductsWithContext(ctx context.Context, listDataProductsOptions *dpxv1.ListDataProductsOptions) (result *dpxv1.DataProductSummaryCollection, response *core.DetailedResponse, err error) {
+	mock.record("ListDataProducts", ctx, listDataProductsOptions)
+	if mock.ListDataProductsFunc == nil {
+		err = notProgrammed("ListDataProducts")
+		return
+	}
+	return mock.ListDataProductsFunc(ctx, listDataProductsOptions)
+}
+
+// CreateDataProduct calls CreateDataProductWithContext with context.Background().
+func (mock *MockDpxV1) CreateDataProduct(createDataProductOptions *dpxv1.CreateDataProductOptions) (result *dpxv1.DataProduct, response *core.DetailedResponse, err error) {
+	return mock.CreateDataProductWithContext(context.Background(), createDataProductOptions)
+}
+
+// CreateDataProductWithContext records the call and invokes CreateDataProductFunc.
+func (mock *MockDpxV1) CreateDataProductWithContext(ctx context.Context, createDataProductOptions *dpxv1.CreateDataProductOptions) (result *dpxv1.DataProduct, response *core.DetailedResponse, err error) {
+	mock.record("CreateDataProduct", ctx, createDataProductOptions)
+	if mock.CreateDataProductFunc == nil {
+		err = notProgrammed("CreateDataProduct")
+		return
+	}
+	return mock.CreateDataProductFunc(ctx, createDataProductOptions)
+}
+
+// GetDataProduct calls GetDataProductWithContext with context.Background().
+func (mock *MockDpxV1) GetDataProduct(getDataProductOptions *dpxv1.GetDataProductOptions) (result *dpxv1.DataProduct, response *core.DetailedResponse, err error) {
+	return mock.GetDataProductWithContext(context.Background(), getDataProductOptions)
+}
+
+// GetDataProductWithContext records the call and invokes GetDataProductFunc.
+func (mock *MockDpxV1) GetDataProductWithContext(ctx context.Context, getDataProductOptions *dpxv1.GetDataProductOptions) (result *dpxv1.DataProduct, response *core.DetailedResponse, err error) {
+	mock.record("GetDataProduct", ctx, getDataProductOptions)
+	if mock.GetDataProductFunc == nil {
+		err = notProgrammed("GetDataProduct")
+		return
+	}
+	return mock.GetDataProductFunc(ctx, getDataProductOptions)
+}
+
+// CompleteDraftContractTermsDocument calls CompleteDraftContractTermsDocumentWithContext with context.Background().
+func (mock *MockDpxV1) CompleteDraftContractTermsDocument(completeDraftContractTermsDocumentOptions *dpxv1.CompleteDraftContractTermsDocumentOptions) (result *dpxv1.ContractTermsDocument, response *core.DetailedResponse, err error) {
+	return mock.CompleteDraftContractTermsDocumentWithContext(context.Background(), completeDraftContractTermsDocumentOptions)
+}
+
+// CompleteDraftContractTermsDocumentWithContext records the call and invokes CompleteDraftContractTermsDocumentFunc.
+func (mock *MockDpxV1) CompleteDraftContractTermsDocumentWithContext(ctx context.Context, completeDraftContractTermsDocumentOptions *dpxv1.CompleteDraftContractTermsDocumentOptions) (result *dpxv1.ContractTermsDocument, response *core.DetailedResponse, err error) {
+	mock.record("CompleteDraftContractTermsDocument", ctx, completeDraftContractTermsDocumentOptions)
+	if mock.CompleteDraftContractTermsDocumentFunc == nil {
+		err = notProgrammed("CompleteDraftContractTermsDocument")
+		return
+	}
+	return mock.CompleteDraftContractTermsDocumentFunc(ctx, completeDraftContractTermsDocumentOptions)
+}
+
+// ListDataProductDrafts calls ListDataProductDraftsWithContext with context.Background().
+func (mock *MockDpxV1) ListDataProductDrafts(listDataProductDraftsOptions *dpxv1.ListDataProductDraftsOptions) (result *dpxv1.DataProductDraftCollection, response *core.DetailedResponse, err error) {
+	return mock.ListDataProductDraftsWithContext(context.Background(), listDataProductDraftsOptions)
+}
+
+// ListDataProductDraftsWithContext records the call and invokes ListDataProductDraftsFunc.
+func (mock *MockDpxV1) ListDataProductDraftsWithContext(ctx context.Context, listDataProductDraftsOptions *dpxv1.ListDataProductDraftsOptions) (result *dpxv1.DataProductDraftCollection, response *core.DetailedResponse, err error) {
+	mock.record("ListDataProductDrafts", ctx, listDataProductDraftsOptions)
+	if mock.ListDataProductDraftsFunc == nil {
+		err = notProgrammed("ListDataProductDrafts")
+		return
+	}
+	return mock.ListDataProductDraftsFunc(ctx, listDataProductDraftsOptions)
+}
+
+// CreateDataProductDraft calls CreateDataProductDraftWithContext with context.Background().
+func (mock *MockDpxV1) CreateDataProductDraft(createDataProductDraftOptions *dpxv1.CreateDataProductDraftOptions) (result *dpxv1.DataProductVersion, response *core.DetailedResponse, err error) {
+	return mock.CreateDataProductDraftWithContext(context.Background(), createDataProductDraftOptions)
+}
+
+// CreateDataProductDraftWithContext records the call and invokes CreateDataProductDraftFunc.
+func (mock *MockDpxV1) CreateDataProductDraftWithContext(ctx context.Context, createDataProductDraftOptions *dpxv1.CreateDataProductDraftOptions) (result *dpxv1.DataProductVersion, response *core.DetailedResponse, err error) {
+	mock.record("CreateDataProductDraft", ctx, createDataProductDraftOptions)
+	if mock.CreateDataProductDraftFunc == nil {
+		err = notProgrammed("CreateDataProductDraft")
+		return
+	}
+	return mock.CreateDataProductDraftFunc(ctx, createDataProductDraftOptions)
+}
+
+// CreateDraftContractTermsDocument calls CreateDraftContractTermsDocumentWithContext with context.Background().
+func (mock *MockDpxV1) CreateDraftContractTermsDocument(createDraftContractTermsDocumentOptions *dpxv1.CreateDraftContractTermsDocumentOptions) (result *dpxv1.ContractTermsDocument, response *core.DetailedResponse, err error) {
+	return mock.CreateDraftContractTermsDocumentWithContext(context.Background(), createDraftContractTermsDocumentOptions)
+}
+
+// CreateDraftContractTermsDocumentWithContext records the call and invokes CreateDraftContractTermsDocumentFunc.
+func (mock *MockDpxV1) CreateDraftContractTermsDocumentWithContext(ctx context.Context, createDraftContractTermsDocumentOptions *dpxv1.CreateDraftContractTermsDocumentOptions) (result *dpxv1.ContractTermsDocument, response *core.DetailedResponse, err error) {
+	mock.record("CreateDraftContractTermsDocument", ctx, createDraftContractTermsDocumentOptions)
+	if mock.CreateDraftContractTermsDocumentFunc == nil {
+		err = notProgrammed("CreateDraftContractTermsDocument")
+		return
+	}
+	return mock.CreateDraftContractTermsDocumentFunc(ctx, createDraftContractTermsDocumentOptions)
+}
+
+// GetDataProductDraft calls GetDataProductDraftWithContext with context.Background().
+func (mock *MockDpxV1) GetDataProductDraft(getDataProductDraftOptions *dpxv1.GetDataProductDraftOptions) (result *dpxv1.DataProductVersion, response *core.DetailedResponse, err error) {
+	return mock.GetDataProductDraftWithContext(context.Background(), getDataProductDraftOptions)
+}
+
+// GetDataProductDraftWithContext records the call and invokes GetDataProductDraftFunc.
+func (mock *MockDpxV1) GetDataProductDraftWithContext(ctx context.Context, getDataProductDraftOptions *dpxv1.GetDataProductDraftOptions) (result *dpxv1.DataProductVersion, response *core.DetailedResponse, err error) {
+	mock.record("GetDataProductDraft", ctx, getDataProductDraftOptions)
+	if mock.GetDataProductDraftFunc == nil {
+		err = notProgrammed("GetDataProductDraft")
+		return
+	}
+	return mock.GetDataProductDraftFunc(ctx, getDataProductDraftOptions)
+}
+
+// DeleteDataProductDraft calls DeleteDataProductDraftWithContext with context.Background().
+func (mock *MockDpxV1) DeleteDataProductDraft(deleteDataProductDraftOptions *dpxv1.DeleteDataProductDraftOptions) (response *core.DetailedResponse, err error) {
+	return mock.DeleteDataProductDraftWithContext(context.Background(), deleteDataProductDraftOptions)
+}
+
+// DeleteDataProductDraftWithContext records the call and invokes DeleteDataProductDraftFunc.
+func (mock *MockDpxV1) DeleteDataProductDraftWithContext(ctx context.Context, deleteDataProductDraftOptions *dpxv1.DeleteDataProductDraftOptions) (response *core.DetailedResponse, err error) {
+	mock.record("DeleteDataProductDraft", ctx, deleteDataProductDraftOptions)
+	if mock.DeleteDataProductDraftFunc == nil {
+		err = notProgrammed("DeleteDataProductDraft")
+		return
+	}
+	return mock.DeleteDataProductDraftFunc(ctx, deleteDataProductDraftOptions)
+}
+
+// UpdateDataProductDraft calls UpdateDataProductDraftWithContext with context.Background().
+func (mock *MockDpxV1) UpdateDataProductDraft(updateDataProductDraftOptions *dpxv1.UpdateDataProductDraftOptions) (result *dpxv1.DataProductVersion, response *core.DetailedResponse, err error) {
+	return mock.UpdateDataProductDraftWithContext(context.Background(), updateDataProductDraftOptions)
+}
+
+// UpdateDataProductDraftWithContext records the call and invokes UpdateDataProductDraftFunc.
+func (mock *MockDpxV1) UpdateDataProductDraftWithContext(ctx context.Context, updateDataProductDraftOptions *dpxv1.UpdateDataProductDraftOptions) (result *dpxv1.DataProductVersion, response *core.DetailedResponse, err error) {
+	mock.record("UpdateDataProductDraft", ctx, updateDataProductDraftOptions)
+	if mock.UpdateDataProductDraftFunc == nil {
+		err = notProgrammed("UpdateDataProductDraft")
+		return
+	}
+	return mock.UpdateDataProductDraftFunc(ctx, updateDataProductDraftOptions)
+}
+
+// GetDraftContractTermsDocument calls GetDraftContractTermsDocumentWithContext with context.Background().
+func (mock *MockDpxV1) GetDraftContractTermsDocument(getDraftContractTermsDocumentOptions *dpxv1.GetDraftContractTermsDocumentOptions) (result *dpxv1.ContractTermsDocument, response *core.DetailedResponse, err error) {
+	return mock.GetDraftContractTermsDocumentWithContext(context.Background(), getDraftContractTermsDocumentOptions)
+}
+
+// GetDraftContractTermsDocumentWithContext records the call and invokes GetDraftContractTermsDocumentFunc.
+func (mock *MockDpxV1) GetDraftContractTermsDocumentWithContext(ctx context.Context, getDraftContractTermsDocumentOptions *dpxv1.GetDraftContractTermsDocumentOptions) (result *dpxv1.ContractTermsDocument, response *core.DetailedResponse, err error) {
+	mock.record("GetDraftContractTermsDocument", ctx, getDraftContractTermsDocumentOptions)
+	if mock.GetDraftContractTermsDocumentFunc == nil {
+		err = notProgrammed("GetDraftContractTermsDocument")
+		return
+	}
+	return mock.GetDraftContractTermsDocumentFunc(ctx, getDraftContractTermsDocumentOptions)
+}
+
+// DeleteDraftContractTermsDocument calls DeleteDraftContractTermsDocumentWithContext with context.Background().
+func (mock *MockDpxV1) DeleteDraftContractTermsDocument(deleteDraftContractTermsDocumentOptions *dpxv1.DeleteDraftContractTermsDocumentOptions) (response *core.DetailedResponse, err error) {
+	return mock.DeleteDraftContractTermsDocumentWithContext(context.Background(), deleteDraftContractTermsDocumentOptions)
+}
+
+// DeleteDraftContractTermsDocumentWithContext records the call and invokes DeleteDraftContractTermsDocumentFunc.
+func (mock *MockDpxV1) DeleteDraftContractTermsDocumentWithContext(ctx context.Context, deleteDraftContractTermsDocumentOptions *dpxv1.DeleteDraftContractTermsDocumentOptions) (response *core.DetailedResponse, err error) {
+	mock.record("DeleteDraftContractTermsDocument", ctx, deleteDraftContractTermsDocumentOptions)
+	if mock.DeleteDraftContractTermsDocumentFunc == nil {
+		err = notProgrammed("DeleteDraftContractTermsDocument")
+		return
+	}
+	return mock.DeleteDraftContractTermsDocumentFunc(ctx, deleteDraftContractTermsDocumentOptions)
+}
+
+// UpdateDraftContractTermsDocument calls UpdateDraftContractTermsDocumentWithContext with context.Background().
+func (mock *MockDpxV1) UpdateDraftContractTermsDocument(updateDraftContractTermsDocumentOptions *dpxv1.UpdateDraftContractTermsDocumentOptions) (result *dpxv1.ContractTermsDocument, response *core.DetailedResponse, err error) {
+	return mock.UpdateDraftContractTermsDocumentWithContext(context.Background(), updateDraftContractTermsDocumentOptions)
+}
+
+// UpdateDraftContractTermsDocumentWithContext records the call and invokes UpdateDraftContractTermsDocumentFunc.
+func (mock *MockDpxV1) UpdateDraftContractTermsDocumentWithContext(ctx context.Context, updateDraftContractTermsDocumentOptions *dpxv1.UpdateDraftContractTermsDocumentOptions) (result *dpxv1.ContractTermsDocument, response *core.DetailedResponse, err error) {
+	mock.record("UpdateDraftContractTermsDocument", ctx, updateDraftContractTermsDocumentOptions)
+	if mock.UpdateDraftContractTermsDocumentFunc == nil {
+		err = notProgrammed("UpdateDraftContractTermsDocument")
+		return
+	}
+	return mock.UpdateDraftContractTermsDocumentFunc(ctx, updateDraftContractTermsDocumentOptions)
+}
+
+// PublishDataProductDraft calls PublishDataProductDraftWithContext with context.Background().
+func (mock *MockDpxV1) PublishDataProductDraft(publishDataProductDraftOptions *dpxv1.PublishDataProductDraftOptions) (result *dpxv1.DataProductVersion, response *core.DetailedResponse, err error) {
+	return mock.PublishDataProductDraftWithContext(context.Background(), publishDataProductDraftOptions)
+}
+
+// PublishDataProductDraftWithContext records the call and invokes PublishDataProductDraftFunc.
+func (mock *MockDpxV1) PublishDataProductDraftWithContext(ctx context.Context, publishDataProductDraftOptions *dpxv1.PublishDataProductDraftOptions) (result *dpxv1.DataProductVersion, response *core.DetailedResponse, err error) {
+	mock.record("PublishDataProductDraft", ctx, publishDataProductDraftOptions)
+	if mock.PublishDataProductDraftFunc == nil {
+		err = notProgrammed("PublishDataProductDraft")
+		return
+	}
+	return mock.PublishDataProductDraftFunc(ctx, publishDataProductDraftOptions)
+}
+
+// GetDataProductRelease calls GetDataProductReleaseWithContext with context.Background().
+func (mock *MockDpxV1) GetDataProductRelease(getDataProductReleaseOptions *dpxv1.GetDataProductReleaseOptions) (result *dpxv1.DataProductVersion, response *core.DetailedResponse, err error) {
+	return mock.GetDataProductReleaseWithContext(context.Background(), getDataProductReleaseOptions)
+}
+
+// GetDataProductReleaseWithContext records the call and invokes GetDataProductReleaseFunc.
+func (mock *MockDpxV1) GetDataProductReleaseWithContext(ctx context.Context, getDataProductReleaseOptions *dpxv1.GetDataProductReleaseOptions) (result *dpxv1.DataProductVersion, response *core.DetailedResponse, err error) {
+	mock.record("GetDataProductRelease", ctx, getDataProductReleaseOptions)
+	if mock.GetDataProductReleaseFunc == nil {
+		err = notProgrammed("GetDataProductRelease")
+		return
+	}
+	return mock.GetDataProductReleaseFunc(ctx, getDataProductReleaseOptions)
+}
+
+// UpdateDataProductRelease calls UpdateDataProductReleaseWithContext with context.Background().
+func (mock *MockDpxV1) UpdateDataProductRelease(updateDataProductReleaseOptions *dpxv1.UpdateDataProductReleaseOptions) (result *dpxv1.DataProductVersion, response *core.DetailedResponse, err error) {
+	return mock.UpdateDataProductReleaseWithContext(context.Background(), updateDataProductReleaseOptions)
+}
+
+// UpdateDataProductReleaseWithContext records the call and invokes UpdateDataProductReleaseFunc.
+func (mock *MockDpxV1) UpdateDataProductReleaseWithContext(ctx context.Context, updateDataProductReleaseOptions *dpxv1.UpdateDataProductReleaseOptions) (result *dpxv1.DataProductVersion, response *core.DetailedResponse, err error) {
+	mock.record("UpdateDataProductRelease", ctx, updateDataProductReleaseOptions)
+	if mock.UpdateDataProductReleaseFunc == nil {
+		err = notProgrammed("UpdateDataProductRelease")
+		return
+	}
+	return mock.UpdateDataProductReleaseFunc(ctx, updateDataProductReleaseOptions)
+}
+
+// GetReleaseContractTermsDocument calls GetReleaseContractTermsDocumentWithContext with context.Background().
+func (mock *MockDpxV1) GetReleaseContractTermsDocument(getReleaseContractTermsDocumentOptions *dpxv1.GetReleaseContractTermsDocumentOptions) (result *dpxv1.ContractTermsDocument, response *core.DetailedResponse, err error) {
+	return mock.GetReleaseContractTermsDocumentWithContext(context.Background(), getReleaseContractTermsDocumentOptions)
+}
+
+// GetReleaseContractTermsDocumentWithContext records the call and invokes GetReleaseContractTermsDocumentFunc.
+func (mock *MockDpxV1) GetReleaseContractTermsDocumentWithContext(ctx context.Context, getReleaseContractTermsDocumentOptions *dpxv1.GetReleaseContractTermsDocumentOptions) (result *dpxv1.ContractTermsDocument, response *core.DetailedResponse, err error) {
+	mock.record("GetReleaseContractTermsDocument", ctx, getReleaseContractTermsDocumentOptions)
+	if mock.GetReleaseContractTermsDocumentFunc == nil {
+		err = notProgrammed("GetReleaseContractTermsDocument")
+		return
+	}
+	return mock.GetReleaseContractTermsDocumentFunc(ctx, getReleaseContractTermsDocumentOptions)
+}
+
+// ListDataProductReleases calls ListDataProductReleasesWithContext with context.Background().
+func (mock *MockDpxV1) ListDataProductReleases(listDataProductReleasesOptions *dpxv1.ListDataProductReleasesOptions) (result *dpxv1.DataProductReleaseCollection, response *core.DetailedResponse, err error) {
+	return mock.ListDataProductReleasesWithContext(context.Background(), listDataProductReleasesOptions)
+}
+
+// ListDataProductReleasesWithContext records the call and invokes ListDataProductReleasesFunc.
+func (mock *MockDpxV1) ListDataProductReleasesWithContext(ctx context.Context, listDataProductReleasesOptions *dpxv1.ListDataProductReleasesOptions) (result *dpxv1.DataProductReleaseCollection, response *core.DetailedResponse, err error) {
+	mock.record("ListDataProductReleases", ctx, listDataProductReleasesOptions)
+	if mock.ListDataProductReleasesFunc == nil {
+		err = notProgrammed("ListDataProductReleases")
+		return
+	}
+	return mock.ListDataProductReleasesFunc(ctx, listDataProductReleasesOptions)
+}
+
+// RetireDataProductRelease calls RetireDataProductReleaseWithContext with context.Background().
+func (mock *MockDpxV1) RetireDataProductRelease(retireDataProductReleaseOptions *dpxv1.RetireDataProductReleaseOptions) (result *dpxv1.DataProductVersion, response *core.DetailedResponse, err error) {
+	return mock.RetireDataProductReleaseWithContext(context.Background(), retireDataProductReleaseOptions)
+}
+
+// RetireDataProductReleaseWithContext records the call and invokes RetireDataProductReleaseFunc.
+func (mock *MockDpxV1) RetireDataProductReleaseWithContext(ctx context.Context, retireDataProductReleaseOptions *dpxv1.RetireDataProductReleaseOptions) (result *dpxv1.DataProductVersion, response *core.DetailedResponse, err error) {
+	mock.record("RetireDataProductRelease", ctx, retireDataProductReleaseOptions)
+	if mock.RetireDataProductReleaseFunc == nil {
+		err = notProgrammed("RetireDataProductRelease")
+		return
+	}
+	return mock.RetireDataProductReleaseFunc(ctx, retireDataProductReleaseOptions)
+}
+
+// NewDataProductsPager records the call and returns a pager that retrieves its pages from the mock.
+func (mock *MockDpxV1) NewDataProductsPager(options *dpxv1.ListDataProductsOptions) (pager *dpxv1.DataProductsPager, err error) {
+	mock.record("NewDataProductsPager", context.Background(), options)
+	return dpxv1.NewDataProductsPagerForClient(mock, options)
+}
+
+// NewDataProductDraftsPager records the call and returns a pager that retrieves its pages from the mock.
+func (mock *MockDpxV1) NewDataProductDraftsPager(options *dpxv1.ListDataProductDraftsOptions) (pager *dpxv1.DataProductDraftsPager, err error) {
+	mock.record("NewDataProductDraftsPager", context.Background(), options)
+	return dpxv1.NewDataProductDraftsPagerForClient(mock, options)
+}
+
+// NewDataProductReleasesPager records the call and returns a pager that retrieves its pages from the mock.
+func (mock *MockDpxV1) NewDataProductReleasesPager(options *dpxv1.ListDataProductReleasesOptions) (pager *dpxv1.DataProductReleasesPager, err error) {
+	mock.record("NewDataProductReleasesPager", context.Background(), options)
+	return dpxv1.NewDataProductReleasesPagerForClient(mock, options)
+}
+
+// NewAssetPartReference calls (*dpxv1.DpxV1).NewAssetPartReference.
+func (*MockDpxV1) NewAssetPartReference(container *dpxv1.ContainerReference) (_model *dpxv1.AssetPartReference, err error) {
+	return (*dpxv1.DpxV1)(nil).NewAssetPartReference(container)
+}
+
+// NewAssetReference calls (*dpxv1.DpxV1).NewAssetReference.
+func (*MockDpxV1) NewAssetReference(container *dpxv1.ContainerReference) (_model *dpxv1.AssetReference, err error) {
+	return (*dpxv1.DpxV1)(nil).NewAssetReference(container)
+}
+
+// NewCompleteDraftContractTermsDocumentOptions calls (*dpxv1.DpxV1).NewCompleteDraftContractTermsDocumentOptions.
+func (*MockDpxV1) NewCompleteDraftContractTermsDocumentOptions(dataProductID string, draftID string, contractTermsID string, documentID string) *dpxv1.CompleteDraftContractTermsDocumentOptions {
+	return (*dpxv1.DpxV1)(nil).NewCompleteDraftContractTermsDocumentOptions(dataProductID, draftID, contractTermsID, documentID)
+}
+
+// NewContainerReference calls (*dpxv1.DpxV1).NewContainerReference.
+func (*MockDpxV1) NewContainerReference(id string) (_model *dpxv1.ContainerReference, err error) {
+	return (*dpxv1.DpxV1)(nil).NewContainerReference(id)
+}
+
+// NewContractTermsDocument calls (*dpxv1.DpxV1).NewContractTermsDocument.
+func (*MockDpxV1) NewContractTermsDocument(typeVar string, name string, id string) (_model *dpxv1.ContractTermsDocument, err error) {
+	return (*dpxv1.DpxV1)(nil).NewContractTermsDocument(typeVar, name, id)
+}
+
+// NewContractTermsDocumentPatch calls (*dpxv1.DpxV1).NewContractTermsDocumentPatch.
+func (*MockDpxV1) NewContractTermsDocumentPatch(contractTermsDocument *dpxv1.ContractTermsDocument) (_patch []dpxv1.JSONPatchOperation) {
+	return (*dpxv1.DpxV1)(nil).NewContractTermsDocumentPatch(contractTermsDocument)
+}
+
+// NewCreateDataProductDraftOptions calls (*dpxv1.DpxV1).NewCreateDataProductDraftOptions.
+func (*MockDpxV1) NewCreateDataProductDraftOptions(dataProductID string, asset *dpxv1.AssetReference) *dpxv1.CreateDataProductDraftOptions {
+	return (*dpxv1.DpxV1)(nil).NewCreateDataProductDraftOptions(dataProductID, asset)
+}
+
+// NewCreateDataProductOptions calls (*dpxv1.DpxV1).NewCreateDataProductOptions.
+func (*MockDpxV1) NewCreateDataProductOptions(drafts []dpxv1.DataProductVersionPrototype) *dpxv1.CreateDataProductOptions {
+	return (*dpxv1.DpxV1)(nil).NewCreateDataProductOptions(drafts)
+}
+
+// NewCreateDraftContractTermsDocumentOptions calls (*dpxv1.DpxV1).NewCreateDraftContractTermsDocumentOptions.
+func (*MockDpxV1) NewCreateDraftContractTermsDocumentOptions(dataProductID string, draftID string, contractTermsID string, typeVar string, name string, id string, url string) *dpxv1.CreateDraftContractTermsDocumentOptions {
+	return (*dpxv1.DpxV1)(nil).NewCreateDraftContractTermsDocumentOptions(dataProductID, draftID, contractTermsID, typeVar, name, id, url)
+}
+
+// NewDataProductIdentity calls (*dpxv1.DpxV1).NewDataProductIdentity.
+func (*MockDpxV1) NewDataProductIdentity(id string) (_model *dpxv1.DataProductIdentity, err error) {
+	return (*dpxv1.DpxV1)(nil).NewDataProductIdentity(id)
+}
+
+// NewDataProductPart calls (*dpxv1.DpxV1).NewDataProductPart.
+func (*MockDpxV1) NewDataProductPart(asset *dpxv1.AssetPartReference) (_model *dpxv1.DataProductPart, err error) {
+	return (*dpxv1.DpxV1)(nil).NewDataProductPart(asset)
+}
+
+// NewDataProductVersionPatch calls (*dpxv1.DpxV1).NewDataProductVersionPatch.
+func (*MockDpxV1) NewDataProductVersionPatch(dataProductVersion *dpxv1.DataProductVersion) (_patch []dpxv1.JSONPatchOperation) {
+	return (*dpxv1.DpxV1)(nil).NewDataProductVersionPatch(dataProductVersion)
+}
+
+// NewDataProductVersionPrototype calls (*dpxv1.DpxV1).NewDataProductVersionPrototype.
+func (*MockDpxV1) NewDataProductVersionPrototype(asset *dpxv1.AssetReference) (_model *dpxv1.DataProductVersionPrototype, err error) {
+	return (*dpxv1.DpxV1)(nil).NewDataProductVersionPrototype(asset)
+}
+
+// NewDeleteDataProductDraftOptions calls (*dpxv1.DpxV1).NewDeleteDataProductDraftOptions.
+func (*MockDpxV1) NewDeleteDataProductDraftOptions(dataProductID string, draftID string) *dpxv1.DeleteDataProductDraftOptions {
+	return (*dpxv1.DpxV1)(nil).NewDeleteDataProductDraftOptions(dataProductID, draftID)
+}
+
+// NewDeleteDraftContractTermsDocumentOptions calls (*dpxv1.DpxV1).NewDeleteDraftContractTermsDocumentOptions.
+func (*MockDpxV1) NewDeleteDraftContractTermsDocumentOptions(dataProductID string, draftID string, contractTermsID string, documentID string) *dpxv1.DeleteDraftContractTermsDocumentOptions {
+	return (*dpxv1.DpxV1)(nil).NewDeleteDraftContractTermsDocumentOptions(dataProductID, draftID, contractTermsID, documentID)
+}
+
+// NewDeliveryMethod calls (*dpxv1.DpxV1).NewDeliveryMethod.
+func (*MockDpxV1) NewDeliveryMethod(id string, container *dpxv1.ContainerReference) (_model *dpxv1.DeliveryMethod, err error) {
+	return (*dpxv1.DpxV1)(nil).NewDeliveryMethod(id, container)
+}
+
+// NewDomain calls (*dpxv1.DpxV1).NewDomain.
+func (*MockDpxV1) NewDomain(id string) (_model *dpxv1.Domain, err error) {
+	return (*dpxv1.DpxV1)(nil).NewDomain(id)
+}
+
+// NewGetDataProductDraftOptions calls (*dpxv1.DpxV1).NewGetDataProductDraftOptions.
+func (*MockDpxV1) NewGetDataProductDraftOptions(dataProductID string, draftID string) *dpxv1.GetDataProductDraftOptions {
+	return (*dpxv1.DpxV1)(nil).NewGetDataProductDraftOptions(dataProductID, draftID)
+}
+
+// NewGetDataProductOptions calls (*dpxv1.DpxV1).NewGetDataProductOptions.
+func (*MockDpxV1) NewGetDataProductOptions(dataProductID string) *dpxv1.GetDataProductOptions {
+	return (*dpxv1.DpxV1)(nil).NewGetDataProductOptions(dataProductID)
+}
+
+// NewGetDataProductReleaseOptions calls (*dpxv1.DpxV1).NewGetDataProductReleaseOptions.
+func (*MockDpxV1) NewGetDataProductReleaseOptions(dataProductID string, releaseID string) *dpxv1.GetDataProductReleaseOptions {
+	return (*dpxv1.DpxV1)(nil).NewGetDataProductReleaseOptions(dataProductID, releaseID)
+}
+
+// NewGetDraftContractTermsDocumentOptions calls (*dpxv1.DpxV1).NewGetDraftContractTermsDocumentOptions.
+func (*MockDpxV1) NewGetDraftContractTermsDocumentOptions(dataProductID string, draftID string, contractTermsID string, documentID string) *dpxv1.GetDraftContractTermsDocumentOptions {
+	return (*dpxv1.DpxV1)(nil).NewGetDraftContractTermsDocumentOptions(dataProductID, draftID, contractTermsID, documentID)
+}
+
+// NewGetInitializeStatusOptions calls (*dpxv1.DpxV1).NewGetInitializeStatusOptions.
+func (*MockDpxV1) NewGetInitializeStatusOptions() *dpxv1.GetInitializeStatusOptions {
+	return (*dpxv1.DpxV1)(nil).NewGetInitializeStatusOptions()
+}
+
+// NewGetReleaseContractTermsDocumentOptions calls (*dpxv1.DpxV1).NewGetReleaseContractTermsDocumentOptions.
+func (*MockDpxV1) NewGetReleaseContractTermsDocumentOptions(dataProductID string, releaseID string, contractTermsID string, documentID string) *dpxv1.GetReleaseContractTermsDocumentOptions {
+	return (*dpxv1.DpxV1)(nil).NewGetReleaseContractTermsDocumentOptions(dataProductID, releaseID, contractTermsID, documentID)
+}
+
+// NewInitializeOptions calls (*dpxv1.DpxV1).NewInitializeOptions.
+func (*MockDpxV1) NewInitializeOptions() *dpxv1.InitializeOptions {
+	return (*dpxv1.DpxV1)(nil).NewInitializeOptions()
+}
+
+// NewJSONPatchOperation calls (*dpxv1.DpxV1).NewJSONPatchOperation.
+func (*MockDpxV1) NewJSONPatchOperation(op string, path string) (_model *dpxv1.JSONPatchOperation, err error) {
+	return (*dpxv1.DpxV1)(nil).NewJSONPatchOperation(op, path)
+}
+
+// NewListDataProductDraftsOptions calls (*dpxv1.DpxV1).NewListDataProductDraftsOptions.
+func (*MockDpxV1) NewListDataProductDraftsOptions(dataProductID string) *dpxv1.ListDataProductDraftsOptions {
+	return (*dpxv1.DpxV1)(nil).NewListDataProductDraftsOptions(dataProductID)
+}
+
+// NewListDataProductReleasesOptions calls (*dpxv1.DpxV1).NewListDataProductReleasesOptions.
+func (*MockDpxV1) NewListDataProductReleasesOptions(dataProductID string) *dpxv1.ListDataProductReleasesOptions {
+	return (*dpxv1.DpxV1)(nil).NewListDataProductReleasesOptions(dataProductID)
+}
+
+// NewListDataProductsOptions calls (*dpxv1.DpxV1).NewListDataProductsOptions.
+func (*MockDpxV1) NewListDataProductsOptions() *dpxv1.ListDataProductsOptions {
+	return (*dpxv1.DpxV1)(nil).NewListDataProductsOptions()
+}
+
+// NewManageApiKeysOptions calls (*dpxv1.DpxV1).NewManageApiKeysOptions.
+func (*MockDpxV1) NewManageApiKeysOptions() *dpxv1.ManageApiKeysOptions {
+	return (*dpxv1.DpxV1)(nil).NewManageApiKeysOptions()
+}
+
+// NewPublishDataProductDraftOptions calls (*dpxv1.DpxV1).NewPublishDataProductDraftOptions.
+func (*MockDpxV1) NewPublishDataProductDraftOptions(dataProductID string, draftID string) *dpxv1.PublishDataProductDraftOptions {
+	return (*dpxv1.DpxV1)(nil).NewPublishDataProductDraftOptions(dataProductID, draftID)
+}
+
+// NewRetireDataProductReleaseOptions calls (*dpxv1.DpxV1).NewRetireDataProductReleaseOptions.
+func (*MockDpxV1) NewRetireDataProductReleaseOptions(dataProductID string, releaseID string) *dpxv1.RetireDataProductReleaseOptions {
+	return (*dpxv1.DpxV1)(nil).NewRetireDataProductReleaseOptions(dataProductID, releaseID)
+}
+
+// NewUpdateDataProductDraftOptions calls (*dpxv1.DpxV1).NewUpdateDataProductDraftOptions.
+func (*MockDpxV1) NewUpdateDataProductDraftOptions(dataProductID string, draftID string, jsonPatchInstructions []dpxv1.JSONPatchOperation) *dpxv1.UpdateDataProductDraftOptions {
+	return (*dpxv1.DpxV1)(nil).NewUpdateDataProductDraftOptions(dataProductID, draftID, jsonPatchInstructions)
+}
+
+// NewUpdateDataProductReleaseOptions calls (*dpxv1.DpxV1).NewUpdateDataProductReleaseOptions.
+func (*MockDpxV1) NewUpdateDataProductReleaseOptions(dataProductID string, releaseID string, jsonPatchInstructions []dpxv1.JSONPatchOperation) *dpxv1.UpdateDataProductReleaseOptions {
+	return (*dpxv1.DpxV1)(nil).NewUpdateDataProductReleaseOptions(dataProductID, releaseID, jsonPatchInstructions)
+}
+
+// NewUpdateDraftContractTermsDocumentOptions calls (*dpxv1.DpxV1).NewUpdateDraftContractTermsDocumentOptions.
+func (*MockDpxV1) NewUpdateDraftContractTermsDocumentOptions(dataProductID string, draftID string, contractTermsID string, documentID string, jsonPatchInstructions []dpxv1.JSONPatchOperation) *dpxv1.UpdateDraftContractTermsDocumentOptions {
+	return (*dpxv1.DpxV1)(nil).NewUpdateDraftContractTermsDocumentOptions(dataProductID, draftID, contractTermsID, documentID, jsonPatchInstructions)
+}
+
+// NewUseCase calls (*dpxv1.DpxV1).NewUseCase.
+func (*MockDpxV1) NewUseCase(id string) (_model *dpxv1.UseCase, err error) {
+	return (*dpxv1.DpxV1)(nil).NewUseCase(id)
+}
@@ -0,0 +1,148 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrDryRun is matched (via errors.Is) by the error returned by mutating operations in dry-run mode.
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// dryRunOperations are the mutating operations that are not sent in dry-run mode.
+var dryRunOperations = map[string]bool{
+	"Initialize":                         true,
+	"ManageApiKeys":                      true,
+	"CreateDataProduct":                  true,
+	"CreateDataProductDraft":             true,
+	"UpdateDataProductDraft":             true,
+	"DeleteDataProductDraft":             true,
+	"PublishDataProductDraft":            true,
+	"UpdateDataProductRelease":           true,
+	"RetireDataProductRelease":           true,
+	"CreateDraftContractTermsDocument":   true,
+	"UpdateDraftContractTermsDocument":   true,
+	"DeleteDraftContractTermsDocument":   true,
+	"CompleteDraftContractTermsDocument": true,
+}
+
+// DryRunRequest : The request that a mutating operation would have sent.
+type DryRunRequest struct {
+	// The operation (e.g. "CreateDataProduct").
+	OperationID string `json:"operation_id"`
+
+	// The HTTP method.
+	Method string `json:"method"`
+
+	// The URL, with the secret query parameters redacted.
+	URL string `json:"url"`
+
+	// The headers, with the secret headers redacted. The Authorization header is added only when a request is
+	// sent, so it is absent.
+	Headers http.Header `json:"headers,omitempty"`
+
+	// The JSON body (uncompressed), with the secret properties redacted, if any.
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// DryRunError is returned by mutating operations in dry-run mode, instead of sending their request.
+type DryRunError struct {
+	// The request that would have been sent.
+	Request *DryRunRequest
+}
+
+// Error returns the error message, with the method and URL of the request.
+func (err *DryRunError) Error() string {
+	return fmt.Sprintf("%s: %s %s", ErrDryRun.Error(), err.Request.Method, err.Request.URL)
+}
+
+// Is returns true if "target" is ErrDryRun.
+func (err *DryRunError) Is(target error) bool {
+	return target == ErrDryRun
+}
+
+type dryRunKey struct{}
+
+// WithDryRun returns a copy of "ctx" that enables or disables dry-run mode (see SetDryRun) for the operations
+// invoked with the context, regardless of the mode of the client.
+func WithDryRun(ctx context.Context, dryRun bool) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, dryRun)
+}
+
+// SetDryRun enables or disables dry-run mode for this service instance. In dry-run mode, the mutating
+// operations (e.g. CreateDataProduct, PublishDataProductDraft, ManageApiKeys and the contract terms document
+// operations) validate and build their request, pass it through the interceptors, and return a *DryRunError
+// that describes it instead of sending it. Read operations are sent as usual.
+//
+// Review the request with errors.As:
+//
+//	_, _, err := dpxService.PublishDataProductDraft(options)
+//	var dryRun *dpxv1.DryRunError
+//	if errors.As(err, &dryRun) {
+//		fmt.Println(dryRun.Request.Method, dryRun.Request.URL, string(dryRun.Request.Body))
+//	}
+//
+// The built-in instrumentation does not count these calls as failed: the metrics record them with the status
+// MetricStatusDryRun, the logs with the attribute LogKeyDryRun, and the spans with TracingAttributeDryRun,
+// without an error. Interceptors registered with AddInterceptors receive the *DryRunError.
+func (dpx *DpxV1) SetDryRun(dryRun bool) {
+	dpx.dryRun = dryRun
+}
+
+// GetDryRun returns true if dry-run mode is enabled for this service instance.
+func (dpx *DpxV1) GetDryRun() bool {
+	return dpx.dryRun
+}
+
+// isDryRun returns true if the request of the operation "operationID" must not be sent.
+func (dpx *DpxV1) isDryRun(ctx context.Context, operationID string) bool {
+	if !dryRunOperations[operationID] {
+		return false
+	}
+	if dryRun, ok := ctx.Value(dryRunKey{}).(bool); ok {
+		return dryRun
+	}
+	return dpx.dryRun
+}
+
+// isDryRunError returns true if "err" reports that the request of an operation was not sent in dry-run mode.
+func isDryRunError(err error) bool {
+	return errors.Is(err, ErrDryRun)
+}
+
+// newDryRunError returns a *DryRunError that describes "request", or the error that occurred reading its body.
+func newDryRunError(operationID string, request *http.Request) error {
+	data, err := readRequestBody(request)
+	if err != nil {
+		return err
+	}
+	dryRunRequest := &DryRunRequest{
+		OperationID: operationID,
+		Method:      request.Method,
+		URL:         redactURL(request.URL.String()),
+		Headers:     redactHeaders(request.Header),
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		dryRunRequest.Body = json.RawMessage(redactJSON(data))
+	}
+	return &DryRunError{Request: dryRunRequest}
+}
@@ -0,0 +1,150 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var _ = Describe(`Dry-run mode`, func() {
+	var server *catalogServer
+	var dpxService *dpxv1.DpxV1
+
+	BeforeEach(func() {
+		server = newCatalogServer()
+		server.put(testVersion("product-1", "draft-1", "draft", "Sales"))
+		dpxService = server.newService()
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	dryRunRequest := func(err error) *dpxv1.DryRunRequest {
+		Expect(errors.Is(err, dpxv1.ErrDryRun)).To(BeTrue())
+		var dryRunError *dpxv1.DryRunError
+		Expect(errors.As(err, &dryRunError)).To(BeTrue())
+		return dryRunError.Request
+	}
+
+	It(`Builds mutating requests without sending them`, func() {
+		dpxService.SetDryRun(true)
+		Expect(dpxService.GetDryRun()).To(BeTrue())
+		dpxService.SetEnableGzipCompression(true)
+
+		draft := testVersion("product-2", "draft-2", "draft", "Ledger")
+		options := dpxService.NewCreateDataProductOptions([]dpxv1.DataProductVersionPrototype{{Name: draft.Name, Asset: draft.Asset}})
+		options.SetHeaders(map[string]string{"X-Api-Key": "my-api-key"})
+		result, response, err := dpxService.CreateDataProduct(options)
+		Expect(result).To(BeNil())
+		Expect(response).To(BeNil())
+		request := dryRunRequest(err)
+		Expect(request.OperationID).To(Equal("CreateDataProduct"))
+		Expect(request.Method).To(Equal("POST"))
+		Expect(request.URL).To(Equal(server.URL + "/data_product_exchange/v1/data_products"))
+		Expect(request.Headers.Get("X-Api-Key")).To(Equal(dpxv1.RedactedValue))
+		Expect(request.Headers.Get("Content-Type")).To(Equal("application/json"))
+		Expect(request.Headers.Get(dpxv1.CorrelationIDHeader)).ToNot(BeEmpty())
+		Expect(string(request.Body)).To(MatchJSON(`{"drafts": [{"name": "Ledger", "asset": {"id": "asset-draft-2", "container": {"id": "catalog-1", "type": "catalog"}}}]}`))
+		Expect(err.Error()).To(HavePrefix("dry run: request not sent: POST " + server.URL))
+
+		_, err = dpxService.DeleteDataProductDraft(dpxService.NewDeleteDataProductDraftOptions("product-1", "draft-1"))
+		request = dryRunRequest(err)
+		Expect(request.Method).To(Equal("DELETE"))
+		Expect(request.Body).To(BeNil())
+		_, _, err = dpxService.PublishDataProductDraft(dpxService.NewPublishDataProductDraftOptions("product-1", "draft-1"))
+		Expect(dryRunRequest(err).URL).To(HaveSuffix("/data_products/product-1/drafts/draft-1/publish"))
+		_, err = dpxService.ManageApiKeys(dpxService.NewManageApiKeysOptions())
+		Expect(dryRunRequest(err).OperationID).To(Equal("ManageApiKeys"))
+
+		// Read operations are sent, and invalid options are still rejected.
+		_, _, err = dpxService.GetDataProductDraft(dpxService.NewGetDataProductDraftOptions("product-1", "draft-1"))
+		Expect(err).To(BeNil())
+		_, err = dpxService.DeleteDataProductDraft(&dpxv1.DeleteDataProductDraftOptions{})
+		Expect(errors.Is(err, dpxv1.ErrDryRun)).To(BeFalse())
+		Expect(server.requestLog()).To(Equal([]string{"GET /data_product_exchange/v1/data_products/product-1/drafts/draft-1"}))
+	})
+	It(`Enables or disables dry-run mode per call`, func() {
+		ctx := dpxv1.WithDryRun(context.Background(), true)
+		_, err := dpxService.DeleteDataProductDraftWithContext(ctx, dpxService.NewDeleteDataProductDraftOptions("product-1", "draft-1"))
+		Expect(dryRunRequest(err).URL).To(HaveSuffix("/data_products/product-1/drafts/draft-1"))
+		Expect(server.requestLog()).To(BeEmpty())
+
+		dpxService.SetDryRun(true)
+		ctx = dpxv1.WithDryRun(context.Background(), false)
+		_, err = dpxService.DeleteDataProductDraftWithContext(ctx, dpxService.NewDeleteDataProductDraftOptions("product-1", "draft-1"))
+		Expect(errors.Is(err, dpxv1.ErrDryRun)).To(BeFalse())
+		Expect(server.requestLog()).To(HaveLen(1))
+	})
+	It(`Labels dry-run calls in the built-in instrumentation instead of counting them as failed`, func() {
+		collector := dpxv1.NewMetricsCollector(nil)
+		dpxService.EnableMetrics(collector)
+		var logs bytes.Buffer
+		dpxService.SetLogger(slog.New(slog.NewTextHandler(&logs, nil)), &dpxv1.LoggingOptions{Level: slog.LevelInfo})
+		spanRecorder := tracetest.NewSpanRecorder()
+		tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+		defer tracerProvider.Shutdown(context.Background())
+		dpxService.EnableTracing(&dpxv1.TracingOptions{TracerProvider: tracerProvider})
+		dpxService.SetDryRun(true)
+
+		_, err := dpxService.DeleteDataProductDraft(dpxService.NewDeleteDataProductDraftOptions("product-1", "draft-1"))
+		Expect(errors.Is(err, dpxv1.ErrDryRun)).To(BeTrue())
+
+		for _, family := range collector.Collect() {
+			switch family.Name {
+			case "dpx_client_requests_total":
+				Expect(family.Metrics).To(HaveLen(1))
+				Expect(family.Metrics[0].Labels[dpxv1.MetricLabelStatus]).To(Equal(dpxv1.MetricStatusDryRun))
+			case "dpx_client_errors_total":
+				Expect(family.Metrics).To(BeEmpty())
+			case "dpx_client_request_duration_seconds":
+				Expect(family.Metrics[0].Histogram.Count).To(BeZero())
+			}
+		}
+
+		Expect(logs.String()).To(ContainSubstring(`level=INFO msg="DPX operation not sent (dry run)"`))
+		Expect(logs.String()).To(ContainSubstring(`dry_run=true`))
+		Expect(logs.String()).ToNot(ContainSubstring(`error=`))
+
+		spans := spanRecorder.Ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Status().Code).To(Equal(codes.Unset))
+		Expect(spans[0].Events()).To(BeEmpty())
+		Expect(spans[0].Attributes()).To(ContainElement(dpxv1.TracingAttributeDryRun.Bool(true)))
+	})
+	It(`Passes dry-run requests through the interceptors`, func() {
+		linter, err := dpxv1.NewLinter(&dpxv1.LintConfig{Rules: []dpxv1.LintRuleConfig{{ID: "domain", Type: dpxv1.LintRule_Type_DomainRequired}}})
+		Expect(err).To(BeNil())
+		dpxService.AddInterceptors(dpxv1.NewLintInterceptor(dpxService, linter))
+		dpxService.SetDryRun(true)
+
+		draft := testVersion("product-2", "", "draft", "Ledger")
+		_, _, err = dpxService.CreateDataProduct(dpxService.NewCreateDataProductOptions([]dpxv1.DataProductVersionPrototype{{Name: core.StringPtr("Ledger"), Asset: draft.Asset}}))
+		Expect(errors.Is(err, dpxv1.ErrLintFailed)).To(BeTrue())
+		Expect(errors.Is(err, dpxv1.ErrDryRun)).To(BeFalse())
+	})
+})
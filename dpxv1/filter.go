@@ -0,0 +1,523 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter : A parsed filter expression, which is evaluated client-side against the JSON representation of a
+// model (e.g. DataProductVersion). See ParseFilter for the syntax.
+type Filter struct {
+	expression string
+	root       filterNode
+}
+
+// ParseFilter parses a filter expression such as
+//
+//	tags contains "finance" and domain.id == "x" and is_restricted == false
+//
+// A comparison is a field, an operator and a value. Fields are the JSON property names of the model, with
+// nested properties separated by dots (e.g. "domain.name" or "use_cases.name"); a field inside an array
+// matches if any element matches. Values are double-quoted strings, numbers, true, false, null or
+// (for "in") a list of values in square brackets. The operators are:
+//
+//	==, !=               equality (a missing field equals null, and also false, since the models omit false booleans)
+//	<, <=, >, >=         ordering of numbers, or of strings (e.g. RFC 3339 date-times)
+//	contains             membership of an array field, or substring of a string field
+//	in                   membership of the field in a list, e.g. state in ["available", "retired"]
+//
+// Comparisons can be combined with "and", "or", "not" and parentheses.
+func ParseFilter(expression string) (*Filter, error) {
+	parser := &filterParser{expression: expression}
+	err := parser.tokenize()
+	if err != nil {
+		return nil, err
+	}
+	root, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if token := parser.peek(); token.kind != filterTokenEOF {
+		return nil, parser.errorAt(token, "unexpected %q", token.text)
+	}
+	return &Filter{expression: expression, root: root}, nil
+}
+
+// String returns the expression of the filter.
+func (filter *Filter) String() string {
+	return filter.expression
+}
+
+// Fields returns the fields referenced by the filter.
+func (filter *Filter) Fields() (fields []string) {
+	seen := make(map[string]bool)
+	filter.root.walk(func(comparison *filterComparison) {
+		if !seen[comparison.field] {
+			seen[comparison.field] = true
+			fields = append(fields, comparison.field)
+		}
+	})
+	return
+}
+
+// Match returns true if "model" (e.g. a *DataProductVersion) matches the filter. The model is matched
+// through its JSON representation; it returns false if the model cannot be marshalled to JSON.
+func (filter *Filter) Match(model interface{}) bool {
+	data, err := json.Marshal(model)
+	if err != nil {
+		return false
+	}
+	var value interface{}
+	err = json.Unmarshal(data, &value)
+	if err != nil {
+		return false
+	}
+	return filter.root.eval(value)
+}
+
+// equalities returns the values compared with "==" (or "in") to each field in the top-level conjunction
+// of the filter, i.e. the values that every matching model must have.
+func (filter *Filter) equalities() map[string][]interface{} {
+	equalities := make(map[string][]interface{})
+	var collect func(node filterNode)
+	collect = func(node filterNode) {
+		switch node := node.(type) {
+		case *filterAnd:
+			collect(node.left)
+			collect(node.right)
+		case *filterComparison:
+			if node.operator == "==" {
+				equalities[node.field] = append(equalities[node.field], node.value)
+			} else if node.operator == "in" {
+				equalities[node.field] = append(equalities[node.field], node.value.([]interface{})...)
+			}
+		}
+	}
+	collect(filter.root)
+	return equalities
+}
+
+// filterNode is a node of the syntax tree of a filter expression.
+type filterNode interface {
+	eval(value interface{}) bool
+	walk(visit func(comparison *filterComparison))
+}
+
+type filterAnd struct {
+	left, right filterNode
+}
+
+func (node *filterAnd) eval(value interface{}) bool {
+	return node.left.eval(value) && node.right.eval(value)
+}
+
+func (node *filterAnd) walk(visit func(comparison *filterComparison)) {
+	node.left.walk(visit)
+	node.right.walk(visit)
+}
+
+type filterOr struct {
+	left, right filterNode
+}
+
+func (node *filterOr) eval(value interface{}) bool {
+	return node.left.eval(value) || node.right.eval(value)
+}
+
+func (node *filterOr) walk(visit func(comparison *filterComparison)) {
+	node.left.walk(visit)
+	node.right.walk(visit)
+}
+
+type filterNot struct {
+	operand filterNode
+}
+
+func (node *filterNot) eval(value interface{}) bool {
+	return !node.operand.eval(value)
+}
+
+func (node *filterNot) walk(visit func(comparison *filterComparison)) {
+	node.operand.walk(visit)
+}
+
+type filterComparison struct {
+	field    string
+	operator string
+	value    interface{} // string, float64, bool, nil or []interface{} (for "in")
+}
+
+func (node *filterComparison) walk(visit func(comparison *filterComparison)) {
+	visit(node)
+}
+
+func (node *filterComparison) eval(value interface{}) bool {
+	fieldValues := resolveFilterField(value, strings.Split(node.field, "."))
+	switch node.operator {
+	case "==":
+		return node.equals(fieldValues)
+	case "!=":
+		return !node.equals(fieldValues)
+	case "in":
+		for _, fieldValue := range fieldValues {
+			for _, listValue := range node.value.([]interface{}) {
+				if filterValuesEqual(fieldValue, listValue) {
+					return true
+				}
+			}
+		}
+		return false
+	case "contains":
+		for _, fieldValue := range fieldValues {
+			switch fieldValue := fieldValue.(type) {
+			case []interface{}:
+				for _, element := range fieldValue {
+					if filterValuesEqual(element, node.value) {
+						return true
+					}
+				}
+			case string:
+				if s, ok := node.value.(string); ok && strings.Contains(fieldValue, s) {
+					return true
+				}
+			}
+		}
+		return false
+	default:
+		for _, fieldValue := range fieldValues {
+			if compared, ok := compareFilterValues(fieldValue, node.value); ok {
+				switch node.operator {
+				case "<":
+					ok = compared < 0
+				case "<=":
+					ok = compared <= 0
+				case ">":
+					ok = compared > 0
+				case ">=":
+					ok = compared >= 0
+				}
+				if ok {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// equals returns true if any of the field values equals the value of the comparison. A missing field equals
+// null and false: optional booleans such as is_restricted are omitted from the JSON representation when unset.
+func (node *filterComparison) equals(fieldValues []interface{}) bool {
+	if len(fieldValues) == 0 && (node.value == nil || node.value == false) {
+		return true
+	}
+	for _, fieldValue := range fieldValues {
+		if filterValuesEqual(fieldValue, node.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveFilterField returns the values of the field at "path" in the JSON value "value". Arrays along the path
+// are traversed, so there is one value per matching element; the value at the end of the path is returned as is.
+func resolveFilterField(value interface{}, path []string) []interface{} {
+	if len(path) == 0 {
+		return []interface{}{value}
+	}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		child, ok := value[path[0]]
+		if !ok {
+			return nil
+		}
+		return resolveFilterField(child, path[1:])
+	case []interface{}:
+		var values []interface{}
+		for _, element := range value {
+			values = append(values, resolveFilterField(element, path)...)
+		}
+		return values
+	}
+	return nil
+}
+
+// filterValuesEqual returns true if two JSON values are equal.
+func filterValuesEqual(a interface{}, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// compareFilterValues compares two numbers or two strings.
+func compareFilterValues(a interface{}, b interface{}) (int, bool) {
+	switch a := a.(type) {
+	case float64:
+		if b, ok := b.(float64); ok {
+			switch {
+			case a < b:
+				return -1, true
+			case a > b:
+				return 1, true
+			}
+			return 0, true
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), true
+		}
+	}
+	return 0, false
+}
+
+// The kinds of the tokens of a filter expression.
+const (
+	filterTokenEOF = iota
+	filterTokenIdentifier
+	filterTokenString
+	filterTokenNumber
+	filterTokenOperator
+	filterTokenPunctuation
+)
+
+type filterToken struct {
+	kind     int
+	text     string
+	position int
+}
+
+// filterParser is a recursive descent parser of filter expressions.
+type filterParser struct {
+	expression string
+	tokens     []filterToken
+	next       int
+}
+
+func (parser *filterParser) errorAt(token filterToken, format string, args ...interface{}) error {
+	return fmt.Errorf("invalid filter expression %q at position %d: %s", parser.expression, token.position, fmt.Sprintf(format, args...))
+}
+
+func (parser *filterParser) tokenize() error {
+	runes := []rune(parser.expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case unicode.IsLetter(r) || r == '_':
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			parser.tokens = append(parser.tokens, filterToken{filterTokenIdentifier, string(runes[start:i]), start})
+		case unicode.IsDigit(r) || r == '-':
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || runes[i] == 'e' || runes[i] == 'E') {
+				i++
+			}
+			parser.tokens = append(parser.tokens, filterToken{filterTokenNumber, string(runes[start:i]), start})
+		case r == '"':
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				if runes[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(runes) {
+				return parser.errorAt(filterToken{position: start}, "unterminated string")
+			}
+			i++
+			parser.tokens = append(parser.tokens, filterToken{filterTokenString, string(runes[start:i]), start})
+		case strings.ContainsRune("=!<>", r):
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				i++
+			}
+			operator := string(runes[start:i])
+			if operator == "=" || operator == "!" {
+				return parser.errorAt(filterToken{position: start}, "unknown operator %q", operator)
+			}
+			parser.tokens = append(parser.tokens, filterToken{filterTokenOperator, operator, start})
+		case strings.ContainsRune("()[],", r):
+			i++
+			parser.tokens = append(parser.tokens, filterToken{filterTokenPunctuation, string(r), start})
+		default:
+			return parser.errorAt(filterToken{position: start}, "unexpected character %q", r)
+		}
+	}
+	parser.tokens = append(parser.tokens, filterToken{filterTokenEOF, "", len(runes)})
+	return nil
+}
+
+func (parser *filterParser) peek() filterToken {
+	return parser.tokens[parser.next]
+}
+
+func (parser *filterParser) consume() filterToken {
+	token := parser.tokens[parser.next]
+	if token.kind != filterTokenEOF {
+		parser.next++
+	}
+	return token
+}
+
+// isKeyword returns true if "token" is the keyword "keyword".
+func (token filterToken) isKeyword(keyword string) bool {
+	return token.kind == filterTokenIdentifier && token.text == keyword
+}
+
+func (parser *filterParser) parseOr() (filterNode, error) {
+	left, err := parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for parser.peek().isKeyword("or") {
+		parser.consume()
+		right, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterOr{left, right}
+	}
+	return left, nil
+}
+
+func (parser *filterParser) parseAnd() (filterNode, error) {
+	left, err := parser.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for parser.peek().isKeyword("and") {
+		parser.consume()
+		right, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterAnd{left, right}
+	}
+	return left, nil
+}
+
+func (parser *filterParser) parseUnary() (filterNode, error) {
+	token := parser.peek()
+	switch {
+	case token.isKeyword("not"):
+		parser.consume()
+		operand, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &filterNot{operand}, nil
+	case token.kind == filterTokenPunctuation && token.text == "(":
+		parser.consume()
+		node, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := parser.consume(); closing.text != ")" {
+			return nil, parser.errorAt(closing, "expected \")\"")
+		}
+		return node, nil
+	}
+	return parser.parseComparison()
+}
+
+func (parser *filterParser) parseComparison() (filterNode, error) {
+	field := parser.consume()
+	if field.kind != filterTokenIdentifier || isFilterKeyword(field.text) {
+		return nil, parser.errorAt(field, "expected a field")
+	}
+	operator := parser.consume()
+	switch {
+	case operator.kind == filterTokenOperator:
+	case operator.isKeyword("contains"):
+	case operator.isKeyword("in"):
+		values, err := parser.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return &filterComparison{field: field.text, operator: "in", value: values}, nil
+	default:
+		return nil, parser.errorAt(operator, "expected an operator after %q", field.text)
+	}
+	value, err := parser.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return &filterComparison{field: field.text, operator: operator.text, value: value}, nil
+}
+
+func (parser *filterParser) parseList() ([]interface{}, error) {
+	if opening := parser.consume(); opening.text != "[" {
+		return nil, parser.errorAt(opening, "expected \"[\"")
+	}
+	values := []interface{}{}
+	for {
+		value, err := parser.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		separator := parser.consume()
+		if separator.text == "]" {
+			return values, nil
+		}
+		if separator.text != "," {
+			return nil, parser.errorAt(separator, "expected \",\" or \"]\"")
+		}
+	}
+}
+
+func (parser *filterParser) parseValue() (interface{}, error) {
+	token := parser.consume()
+	switch {
+	case token.kind == filterTokenString:
+		value, err := strconv.Unquote(token.text)
+		if err != nil {
+			return nil, parser.errorAt(token, "invalid string %s", token.text)
+		}
+		return value, nil
+	case token.kind == filterTokenNumber:
+		value, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, parser.errorAt(token, "invalid number %s", token.text)
+		}
+		return value, nil
+	case token.isKeyword("true"):
+		return true, nil
+	case token.isKeyword("false"):
+		return false, nil
+	case token.isKeyword("null"):
+		return nil, nil
+	}
+	return nil, parser.errorAt(token, "expected a value")
+}
+
+// isFilterKeyword returns true if "identifier" is a keyword of the filter syntax.
+func isFilterKeyword(identifier string) bool {
+	switch identifier {
+	case "and", "or", "not", "contains", "in", "true", "false", "null":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Filter`, func() {
+	version := testVersion("product-1", "release-1", dpxv1.DataProductVersion_State_Available, "Sales")
+	version.Tags = []string{"finance", "quarterly"}
+	version.Domain = &dpxv1.Domain{ID: core.StringPtr("x"), Name: core.StringPtr("Sales")}
+	version.UseCases = []dpxv1.UseCase{{ID: core.StringPtr("use-case-1"), Name: core.StringPtr("Forecasting")}}
+	version.IsRestricted = core.BoolPtr(false)
+	publishedAt, _ := core.ParseDateTime("2024-02-10T00:00:00.000Z")
+	version.PublishedAt = &publishedAt
+
+	It(`Matches data product versions`, func() {
+		for expression, expected := range map[string]bool{
+			`tags contains "finance" and domain.id == "x" and is_restricted == false`: true,
+			`tags contains "payroll"`:                                      false,
+			`description contains "Sales data"`:                            true,
+			`use_cases.name == "Forecasting"`:                              true,
+			`state != "available"`:                                         false,
+			`state in ["available", "retired"]`:                            true,
+			`published_at >= "2024-01-01" and published_at < "2024-03-01"`: true,
+			`published_by == null`:                                         true,
+			`name == null`:                                                 false,
+			`not (tags contains "finance")`:                                false,
+			`name == "Ledger" or asset.container.id == "catalog-1"`:        true,
+			`name == "Sales" or name == "Ledger" and state == "retired"`:   true,
+			`name != "Sa\"les"`:                                            true,
+		} {
+			filter, err := dpxv1.ParseFilter(expression)
+			Expect(err).To(BeNil(), expression)
+			Expect(filter.String()).To(Equal(expression))
+			Expect(filter.Match(version)).To(Equal(expected), expression)
+		}
+	})
+	It(`Treats an omitted boolean as false`, func() {
+		unrestricted := testVersion("product-1", "release-2", dpxv1.DataProductVersion_State_Available, "Ledger")
+		Expect(unrestricted.IsRestricted).To(BeNil())
+		for expression, expected := range map[string]bool{
+			`is_restricted == false`: true,
+			`is_restricted != false`: false,
+			`is_restricted == true`:  false,
+			`is_restricted != true`:  true,
+			`is_restricted == null`:  true,
+		} {
+			filter, err := dpxv1.ParseFilter(expression)
+			Expect(err).To(BeNil(), expression)
+			Expect(filter.Match(unrestricted)).To(Equal(expected), expression)
+		}
+	})
+	It(`Rejects invalid expressions`, func() {
+		for expression, message := range map[string]string{
+			`name = "Sales"`:           `unknown operator "="`,
+			`name ==`:                  `expected a value`,
+			`name "Sales"`:             `expected an operator after "name"`,
+			`name == "Sales`:           `unterminated string`,
+			`(name == "Sales"`:         `expected ")"`,
+			`name == "Sales" "Ledger"`: `position 16: unexpected`,
+			`and == true`:              `expected a field`,
+		} {
+			_, err := dpxv1.ParseFilter(expression)
+			Expect(err).ToNot(BeNil(), expression)
+			Expect(err.Error()).To(ContainSubstring(message))
+		}
+	})
+	It(`Returns the referenced fields`, func() {
+		filter, err := dpxv1.ParseFilter(`tags contains "a" or (tags contains "b" and not domain.name == "c")`)
+		Expect(err).To(BeNil())
+		Expect(filter.Fields()).To(Equal([]string{"tags", "domain.name"}))
+	})
+})
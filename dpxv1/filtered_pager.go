@@ -0,0 +1,283 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// The top-level JSON properties of the items returned by the list operations. A filter that references other
+// properties requires each item to be retrieved (hydrated) with the corresponding get operation.
+var (
+	dataProductSummaryFields        = map[string]bool{"id": true, "container": true}
+	dataProductVersionSummaryFields = map[string]bool{
+		"version": true, "state": true, "data_product": true, "name": true, "description": true, "id": true, "asset": true,
+	}
+)
+
+// FilteredPager : A pager that returns only the items that match a filter expression (see ParseFilter).
+// Create one with NewFilteredDataProductsPager, NewFilteredDataProductReleasesPager or
+// NewFilteredDataProductDraftsPager.
+type FilteredPager[T any] struct {
+	filter  *Filter
+	hydrate bool
+	hasNext func() bool
+	getNext func(ctx context.Context, hydrate bool) ([]T, error)
+}
+
+// HasNext returns true if there are potentially more results to be retrieved.
+func (pager *FilteredPager[T]) HasNext() bool {
+	return pager.hasNext()
+}
+
+// Filter returns the filter of the pager.
+func (pager *FilteredPager[T]) Filter() *Filter {
+	return pager.filter
+}
+
+// Hydrated returns true if the items are retrieved individually because the filter references fields that
+// are not returned by the list operation.
+func (pager *FilteredPager[T]) Hydrated() bool {
+	return pager.hydrate
+}
+
+// GetNextWithContext returns the items of the next page of results that match the filter, using the specified
+// Context. The page may be empty even if more results are available.
+func (pager *FilteredPager[T]) GetNextWithContext(ctx context.Context) (page []T, err error) {
+	items, err := pager.getNext(ctx, pager.hydrate)
+	if err != nil {
+		return
+	}
+	page = []T{}
+	for _, item := range items {
+		if pager.filter.Match(&item) {
+			page = append(page, item)
+		}
+	}
+	return
+}
+
+// GetAllWithContext returns all the items that match the filter, using the specified Context.
+func (pager *FilteredPager[T]) GetAllWithContext(ctx context.Context) (allItems []T, err error) {
+	for pager.HasNext() {
+		var nextPage []T
+		nextPage, err = pager.GetNextWithContext(ctx)
+		if err != nil {
+			return
+		}
+		allItems = append(allItems, nextPage...)
+	}
+	return
+}
+
+// GetNext invokes GetNextWithContext() using context.Background() as the Context parameter.
+func (pager *FilteredPager[T]) GetNext() (page []T, err error) {
+	return pager.GetNextWithContext(context.Background())
+}
+
+// GetAll invokes GetAllWithContext() using context.Background() as the Context parameter.
+func (pager *FilteredPager[T]) GetAll() (allItems []T, err error) {
+	return pager.GetAllWithContext(context.Background())
+}
+
+// NewFilteredDataProductsPager returns a pager of the data products that match the filter expression "filter".
+// If the filter references fields other than id and container, each data product is retrieved with
+// GetDataProduct before it is matched; otherwise the data products only have their id and container.
+func NewFilteredDataProductsPager(client DpxV1API, options *ListDataProductsOptions, filter string) (*FilteredPager[DataProduct], error) {
+	parsedFilter, err := ParseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	pager, err := client.NewDataProductsPager(options)
+	if err != nil {
+		return nil, err
+	}
+	return &FilteredPager[DataProduct]{
+		filter:  parsedFilter,
+		hydrate: requiresHydration(parsedFilter, dataProductSummaryFields),
+		hasNext: pager.HasNext,
+		getNext: func(ctx context.Context, hydrate bool) ([]DataProduct, error) {
+			summaries, err := pager.GetNextWithContext(ctx)
+			if err != nil {
+				return nil, err
+			}
+			dataProducts := make([]DataProduct, 0, len(summaries))
+			for _, summary := range summaries {
+				if !hydrate {
+					dataProducts = append(dataProducts, DataProduct{ID: summary.ID, Container: summary.Container})
+					continue
+				}
+				dataProduct, _, err := client.GetDataProductWithContext(ctx, client.NewGetDataProductOptions(stringValue(summary.ID)))
+				if err != nil {
+					return nil, err
+				}
+				dataProducts = append(dataProducts, *dataProduct)
+			}
+			return dataProducts, nil
+		},
+	}, nil
+}
+
+// NewFilteredDataProductReleasesPager returns a pager of the releases that match the filter expression "filter".
+// The conditions on state, version and asset.container.id that every match must satisfy are pushed down to
+// the service, unless they are already set in "options". If the filter references fields that are not returned
+// by ListDataProductReleases, each release is retrieved with GetDataProductRelease before it is matched;
+// otherwise the releases only have the fields of DataProductVersionSummary.
+func NewFilteredDataProductReleasesPager(client DpxV1API, options *ListDataProductReleasesOptions, filter string) (*FilteredPager[DataProductVersion], error) {
+	parsedFilter, err := ParseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	optionsCopy := *options
+	equalities := parsedFilter.equalities()
+	if len(optionsCopy.State) == 0 {
+		optionsCopy.State = pushDownValues(equalities["state"], false)
+	}
+	if optionsCopy.Version == nil {
+		optionsCopy.Version = pushDownValue(equalities["version"])
+	}
+	if optionsCopy.AssetContainerID == nil {
+		optionsCopy.AssetContainerID = pushDownValue(equalities["asset.container.id"])
+	}
+	pager, err := client.NewDataProductReleasesPager(&optionsCopy)
+	if err != nil {
+		return nil, err
+	}
+	dataProductID := stringValue(options.DataProductID)
+	return &FilteredPager[DataProductVersion]{
+		filter:  parsedFilter,
+		hydrate: requiresHydration(parsedFilter, dataProductVersionSummaryFields),
+		hasNext: pager.HasNext,
+		getNext: func(ctx context.Context, hydrate bool) ([]DataProductVersion, error) {
+			summaries, err := pager.GetNextWithContext(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return hydrateVersions(summaries, hydrate, func(summary *DataProductVersionSummary) (*DataProductVersion, error) {
+				release, _, err := client.GetDataProductReleaseWithContext(ctx, client.NewGetDataProductReleaseOptions(dataProductID, stringValue(summary.ID)))
+				return release, err
+			})
+		},
+	}, nil
+}
+
+// NewFilteredDataProductDraftsPager returns a pager of the drafts that match the filter expression "filter".
+// The conditions on version and asset.container.id that every match must satisfy are pushed down to the
+// service, unless they are already set in "options". If the filter references fields that are not returned
+// by ListDataProductDrafts, each draft is retrieved with GetDataProductDraft before it is matched;
+// otherwise the drafts only have the fields of DataProductVersionSummary.
+func NewFilteredDataProductDraftsPager(client DpxV1API, options *ListDataProductDraftsOptions, filter string) (*FilteredPager[DataProductVersion], error) {
+	parsedFilter, err := ParseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	optionsCopy := *options
+	equalities := parsedFilter.equalities()
+	if optionsCopy.Version == nil {
+		optionsCopy.Version = pushDownValue(equalities["version"])
+	}
+	if optionsCopy.AssetContainerID == nil {
+		optionsCopy.AssetContainerID = pushDownValue(equalities["asset.container.id"])
+	}
+	pager, err := client.NewDataProductDraftsPager(&optionsCopy)
+	if err != nil {
+		return nil, err
+	}
+	dataProductID := stringValue(options.DataProductID)
+	return &FilteredPager[DataProductVersion]{
+		filter:  parsedFilter,
+		hydrate: requiresHydration(parsedFilter, dataProductVersionSummaryFields),
+		hasNext: pager.HasNext,
+		getNext: func(ctx context.Context, hydrate bool) ([]DataProductVersion, error) {
+			summaries, err := pager.GetNextWithContext(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return hydrateVersions(summaries, hydrate, func(summary *DataProductVersionSummary) (*DataProductVersion, error) {
+				draft, _, err := client.GetDataProductDraftWithContext(ctx, client.NewGetDataProductDraftOptions(dataProductID, stringValue(summary.ID)))
+				return draft, err
+			})
+		},
+	}, nil
+}
+
+// hydrateVersions converts version summaries to data product versions, retrieving each version with "get"
+// if "hydrate" is true.
+func hydrateVersions(summaries []DataProductVersionSummary, hydrate bool,
+	get func(summary *DataProductVersionSummary) (*DataProductVersion, error)) ([]DataProductVersion, error) {
+	versions := make([]DataProductVersion, 0, len(summaries))
+	for i := range summaries {
+		summary := &summaries[i]
+		if !hydrate {
+			versions = append(versions, DataProductVersion{
+				Version:     summary.Version,
+				State:       summary.State,
+				DataProduct: summary.DataProduct,
+				Name:        summary.Name,
+				Description: summary.Description,
+				ID:          summary.ID,
+				Asset:       summary.Asset,
+			})
+			continue
+		}
+		version, err := get(summary)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, *version)
+	}
+	return versions, nil
+}
+
+// requiresHydration returns true if the filter references a field whose top-level property is not in "listed".
+func requiresHydration(filter *Filter, listed map[string]bool) bool {
+	for _, field := range filter.Fields() {
+		property, _, _ := strings.Cut(field, ".")
+		if !listed[property] {
+			return true
+		}
+	}
+	return false
+}
+
+// pushDownValues returns the string values that a field must have, or nil if they are not all strings
+// (or, if "single" is true, if there is not exactly one).
+func pushDownValues(values []interface{}, single bool) (result []string) {
+	for _, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			return nil
+		}
+		if !slices.Contains(result, s) {
+			result = append(result, s)
+		}
+	}
+	if single && len(result) != 1 {
+		return nil
+	}
+	return
+}
+
+// pushDownValue returns the string value that a field must have, or nil if there is not exactly one.
+func pushDownValue(values []interface{}) *string {
+	if values := pushDownValues(values, true); values != nil {
+		return &values[0]
+	}
+	return nil
+}
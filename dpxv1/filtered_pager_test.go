@@ -0,0 +1,110 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Filtered pagers`, func() {
+	var server *catalogServer
+	var dpxService *dpxv1.DpxV1
+
+	BeforeEach(func() {
+		server = newCatalogServer()
+		dpxService = server.newService()
+
+		finance := testVersion("product-1", "release-1", dpxv1.DataProductVersion_State_Available, "Sales")
+		finance.Tags = []string{"finance"}
+		finance.Domain = &dpxv1.Domain{ID: core.StringPtr("x")}
+		server.put(finance)
+		restricted := testVersion("product-1", "release-2", dpxv1.DataProductVersion_State_Available, "Sales")
+		restricted.Version = core.StringPtr("2.0.0")
+		restricted.Tags = []string{"finance"}
+		restricted.Domain = &dpxv1.Domain{ID: core.StringPtr("x")}
+		restricted.IsRestricted = core.BoolPtr(true)
+		server.put(restricted)
+		server.put(testVersion("product-1", "release-3", dpxv1.DataProductVersion_State_Retired, "Sales"))
+		server.put(testVersion("product-1", "draft-1", dpxv1.DataProductVersion_State_Draft, "Sales draft"))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Hydrates the releases when the filter needs their details`, func() {
+		pager, err := dpxv1.NewFilteredDataProductReleasesPager(dpxService, dpxService.NewListDataProductReleasesOptions("product-1"),
+			`tags contains "finance" and domain.id == "x" and is_restricted == false`)
+		Expect(err).To(BeNil())
+		Expect(pager.Hydrated()).To(BeTrue())
+		Expect(pager.Filter().Fields()).To(Equal([]string{"tags", "domain.id", "is_restricted"}))
+
+		releases, err := pager.GetAll()
+		Expect(err).To(BeNil())
+		Expect(releases).To(HaveLen(1))
+		Expect(*releases[0].ID).To(Equal("release-1"))
+		Expect(releases[0].Tags).To(Equal([]string{"finance"}))
+		Expect(server.requestLog()).To(ContainElement("GET /data_product_exchange/v1/data_products/product-1/releases/release-3"))
+	})
+	It(`Pushes down state, version and asset.container.id and does not hydrate summary fields`, func() {
+		options := dpxService.NewListDataProductReleasesOptions("product-1")
+		pager, err := dpxv1.NewFilteredDataProductReleasesPager(dpxService, options,
+			`state in ["available", "retired"] and version == "1.0.0" and asset.container.id == "catalog-1" and name contains "Sal"`)
+		Expect(err).To(BeNil())
+		Expect(pager.Hydrated()).To(BeFalse())
+		Expect(options.State).To(BeEmpty())
+
+		releases, err := pager.GetAll()
+		Expect(err).To(BeNil())
+		Expect(releases).To(HaveLen(2))
+		Expect(*releases[1].ID).To(Equal("release-3"))
+		Expect(releases[1].Tags).To(BeNil())
+		Expect(server.requestLog()).To(Equal([]string{
+			"GET /data_product_exchange/v1/data_products/product-1/releases?asset.container.id=catalog-1&state=available%2Cretired&version=1.0.0",
+		}))
+	})
+	It(`Does not push down conditions that are not required of every match`, func() {
+		pager, err := dpxv1.NewFilteredDataProductReleasesPager(dpxService, dpxService.NewListDataProductReleasesOptions("product-1"),
+			`version == "1.0.0" or state == "retired"`)
+		Expect(err).To(BeNil())
+		releases, err := pager.GetAll()
+		Expect(err).To(BeNil())
+		Expect(releases).To(HaveLen(2))
+		Expect(server.requestLog()).To(Equal([]string{"GET /data_product_exchange/v1/data_products/product-1/releases"}))
+	})
+	It(`Filters drafts and data products`, func() {
+		draftsPager, err := dpxv1.NewFilteredDataProductDraftsPager(dpxService, dpxService.NewListDataProductDraftsOptions("product-1"),
+			`name == "Sales draft"`)
+		Expect(err).To(BeNil())
+		drafts, err := draftsPager.GetAll()
+		Expect(err).To(BeNil())
+		Expect(drafts).To(HaveLen(1))
+
+		dataProductsPager, err := dpxv1.NewFilteredDataProductsPager(dpxService, dpxService.NewListDataProductsOptions(), `id != "product-1"`)
+		Expect(err).To(BeNil())
+		Expect(dataProductsPager.Hydrated()).To(BeFalse())
+		dataProducts, err := dataProductsPager.GetAll()
+		Expect(err).To(BeNil())
+		Expect(dataProducts).To(BeEmpty())
+	})
+	It(`Returns an error for an invalid filter`, func() {
+		_, err := dpxv1.NewFilteredDataProductsPager(dpxService, dpxService.NewListDataProductsOptions(), `id ==`)
+		Expect(err).ToNot(BeNil())
+	})
+})
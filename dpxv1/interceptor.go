@@ -0,0 +1,87 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"net/http"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Operation : Describes the API operation on whose behalf a request is sent.
+type Operation struct {
+	// The operation identifier as defined in the API definition (e.g. "GetDataProduct").
+	// This is the same value that is passed to common.GetSdkHeaders().
+	ID string
+
+	// The path parameters used to build the request URL (e.g. "data_product_id"), or nil if the operation has none.
+	PathParams map[string]string
+}
+
+// Invoker sends a request and returns the resulting DetailedResponse and error.
+type Invoker func(request *http.Request) (*core.DetailedResponse, error)
+
+// Interceptor is implemented by types that observe or modify the requests sent by a DpxV1 instance.
+//
+// Intercept is called with the fully-built *http.Request of every operation. An implementation may modify
+// the request (e.g. to add headers) and must normally call "next" to continue the chain; it then sees the
+// DetailedResponse and error produced by the rest of the chain and may inspect or replace them.
+// Interceptors are invoked before the default headers and authentication are added to the request.
+type Interceptor interface {
+	Intercept(operation *Operation, request *http.Request, next Invoker) (*core.DetailedResponse, error)
+}
+
+// InterceptorFunc is an adapter that allows an ordinary function to be used as an Interceptor.
+type InterceptorFunc func(operation *Operation, request *http.Request, next Invoker) (*core.DetailedResponse, error)
+
+// Intercept calls f(operation, request, next).
+func (f InterceptorFunc) Intercept(operation *Operation, request *http.Request, next Invoker) (*core.DetailedResponse, error) {
+	return f(operation, request, next)
+}
+
+// AddInterceptors appends interceptors to the chain of this service instance.
+// Interceptors are invoked in the order in which they were added, so the first interceptor
+// sees the request first and the response last.
+func (dpx *DpxV1) AddInterceptors(interceptors ...Interceptor) {
+	for _, interceptor := range interceptors {
+		if interceptor != nil {
+			dpx.interceptors = append(dpx.interceptors, interceptor)
+		}
+	}
+}
+
+// GetInterceptors returns the chain of interceptors registered on this service instance.
+func (dpx *DpxV1) GetInterceptors() []Interceptor {
+	return append([]Interceptor(nil), dpx.interceptors...)
+}
+
+// ClearInterceptors removes all interceptors from this service instance.
+func (dpx *DpxV1) ClearInterceptors() {
+	dpx.interceptors = nil
+}
+
+// chain returns an Invoker that passes requests for "operation" through the specified interceptors before "last".
+func chain(interceptors []Interceptor, operation *Operation, last Invoker) Invoker {
+	next := last
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := interceptors[i], next
+		next = func(request *http.Request) (*core.DetailedResponse, error) {
+			return interceptor.Intercept(operation, request, inner)
+		}
+	}
+	return next
+}
@@ -0,0 +1,111 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Interceptors`, func() {
+	var testServer *httptest.Server
+	var requestCount int32
+	var dpxService *dpxv1.DpxV1
+
+	BeforeEach(func() {
+		atomic.StoreInt32(&requestCount, 0)
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&requestCount, 1)
+			res.Header().Set("Content-type", "application/json")
+			res.Header().Set("X-Audit", req.Header.Get("X-Audit"))
+			res.WriteHeader(200)
+			fmt.Fprint(res, `{"version": "1.0.0", "state": "draft", "id": "testString", "name": "My Data Product"}`)
+		}))
+		var serviceErr error
+		dpxService, serviceErr = dpxv1.NewDpxV1(&dpxv1.DpxV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(serviceErr).To(BeNil())
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`Invokes interceptors in order with the operation and response`, func() {
+		var calls []string
+		dpxService.AddInterceptors(
+			dpxv1.InterceptorFunc(func(operation *dpxv1.Operation, request *http.Request, next dpxv1.Invoker) (*core.DetailedResponse, error) {
+				calls = append(calls, "first:"+operation.ID)
+				Expect(operation.PathParams).To(HaveKeyWithValue("data_product_id", "product-1"))
+				Expect(operation.PathParams).To(HaveKeyWithValue("draft_id", "draft-1"))
+				request.Header.Set("X-Audit", "audited")
+				response, err := next(request)
+				calls = append(calls, fmt.Sprintf("first:%d", response.StatusCode))
+				return response, err
+			}),
+			dpxv1.InterceptorFunc(func(operation *dpxv1.Operation, request *http.Request, next dpxv1.Invoker) (*core.DetailedResponse, error) {
+				calls = append(calls, "second:"+request.Header.Get("X-Audit"))
+				response, err := next(request)
+				calls = append(calls, "second:"+response.Headers.Get("X-Audit"))
+				return response, err
+			}),
+		)
+		Expect(dpxService.GetInterceptors()).To(HaveLen(2))
+
+		result, response, err := dpxService.GetDataProductDraft(dpxService.NewGetDataProductDraftOptions("product-1", "draft-1"))
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(200))
+		Expect(*result.Name).To(Equal("My Data Product"))
+		Expect(calls).To(Equal([]string{"first:GetDataProductDraft", "second:audited", "second:audited", "first:200"}))
+	})
+	It(`Allows an interceptor to short-circuit the request`, func() {
+		rejected := errors.New("rejected by policy")
+		dpxService.AddInterceptors(dpxv1.InterceptorFunc(func(operation *dpxv1.Operation, request *http.Request, next dpxv1.Invoker) (*core.DetailedResponse, error) {
+			Expect(operation.ID).To(Equal("ManageApiKeys"))
+			Expect(operation.PathParams).To(BeNil())
+			return nil, rejected
+		}))
+
+		response, err := dpxService.ManageApiKeys(dpxService.NewManageApiKeysOptions())
+		Expect(err).To(MatchError(rejected))
+		Expect(response).To(BeNil())
+		Expect(atomic.LoadInt32(&requestCount)).To(Equal(int32(0)))
+	})
+	It(`Keeps the interceptors of clones independent`, func() {
+		noop := dpxv1.InterceptorFunc(func(operation *dpxv1.Operation, request *http.Request, next dpxv1.Invoker) (*core.DetailedResponse, error) {
+			return next(request)
+		})
+		dpxService.AddInterceptors(noop, nil)
+		clone := dpxService.Clone()
+		clone.AddInterceptors(noop)
+		Expect(dpxService.GetInterceptors()).To(HaveLen(1))
+		Expect(clone.GetInterceptors()).To(HaveLen(2))
+
+		clone.ClearInterceptors()
+		Expect(clone.GetInterceptors()).To(BeEmpty())
+		Expect(dpxService.GetInterceptors()).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,299 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+// callStats accumulates information about a single invocation of an operation across all of the
+// attempts made to send its request. It is carried in the request's context.
+type callStats struct {
+	// The number of times the request was re-sent.
+	retries int32
+
+	// The attempt number reported by the core's retryable HTTP client for the current send.
+	transportAttempt int32
+}
+
+type callStatsKey struct{}
+
+type operationKey struct{}
+
+// getOperation returns the Operation on whose behalf the request with context "ctx" is sent, or nil.
+func getOperation(ctx context.Context) *Operation {
+	operation, _ := ctx.Value(operationKey{}).(*Operation)
+	return operation
+}
+
+// getCallStats returns the callStats carried by "ctx", or nil.
+func getCallStats(ctx context.Context) *callStats {
+	stats, _ := ctx.Value(callStatsKey{}).(*callStats)
+	return stats
+}
+
+func (stats *callStats) getRetries() int {
+	return int(atomic.LoadInt32(&stats.retries))
+}
+
+// endSend folds the retries performed by the core's retryable HTTP client during a send into the retry count.
+func (stats *callStats) endSend() {
+	atomic.AddInt32(&stats.retries, atomic.SwapInt32(&stats.transportAttempt, 0))
+}
+
+// installRetryHook arranges for the retries performed by the core's retryable HTTP client
+// (see EnableRetries) to be counted. It must be called whenever retries are enabled.
+func (dpx *DpxV1) installRetryHook() {
+	if dpx.Service.Client == nil {
+		return
+	}
+	transport, ok := dpx.Service.Client.Transport.(*retryablehttp.RoundTripper)
+	if !ok || transport.Client == nil {
+		return
+	}
+	previous := transport.Client.RequestLogHook
+	transport.Client.RequestLogHook = func(logger retryablehttp.Logger, request *http.Request, attempt int) {
+		if stats := getCallStats(request.Context()); stats != nil {
+			atomic.StoreInt32(&stats.transportAttempt, int32(attempt))
+		}
+		if previous != nil {
+			previous(logger, request, attempt)
+		}
+	}
+}
+
+// interceptorChain returns the interceptors applied to every request:
+// the built-in instrumentation first, followed by the interceptors registered by the user.
+func (dpx *DpxV1) interceptorChain() []Interceptor {
+	var interceptors []Interceptor
+	if dpx.tracing != nil {
+		interceptors = append(interceptors, dpx.tracing)
+	}
+	if dpx.metrics != nil {
+		interceptors = append(interceptors, metricsInterceptor{dpx.metrics})
+	}
+	if dpx.logging != nil {
+		interceptors = append(interceptors, dpx.logging)
+	}
+	return append(interceptors, dpx.interceptors...)
+}
+
+// invoke sends "request" on behalf of the operation identified by "operationID" and processes the
+// response into "result". It is called by every service method in place of dpx.Service.Request()
+// so that interceptors and client-side policies such as rate limiting and circuit breaking are applied
+// consistently.
+func (dpx *DpxV1) invoke(operationID string, pathParams map[string]string, request *http.Request, result interface{}) (response *core.DetailedResponse, err error) {
+	operation := &Operation{
+		ID:         operationID,
+		PathParams: pathParams,
+	}
+	ctx := context.WithValue(request.Context(), callStatsKey{}, &callStats{})
+	ctx = context.WithValue(ctx, operationKey{}, operation)
+	request = request.WithContext(ctx)
+	dpx.applyDefaultContainerID(operationID, request)
+	correlationID := setCorrelationID(request)
+	invoker := chain(dpx.interceptorChain(), operation, func(request *http.Request) (*core.DetailedResponse, error) {
+		if dpx.isDryRun(request.Context(), operation.ID) {
+			return nil, newDryRunError(operation.ID, request)
+		}
+		return dpx.sendWithRateLimits(operation, request, result)
+	})
+	response, err = invoker(request)
+	return correlateResponse(correlationID, response, err)
+}
+
+// sendWithRateLimits sends "request", waiting for the rate limiters that apply to the operation and
+// re-sending the request if it is rejected with "too_many_requests".
+func (dpx *DpxV1) sendWithRateLimits(operation *Operation, request *http.Request, result interface{}) (response *core.DetailedResponse, err error) {
+	limiters := dpx.rateLimitersFor(operation.ID)
+	if len(limiters) == 0 {
+		return dpx.send(operation.ID, request, result, nil)
+	}
+
+	// The request may need to be sent more than once, so make sure its body can be replayed.
+	err = bufferRequestBody(request)
+	if err != nil {
+		return
+	}
+
+	maxRetries := limiters[0].GetMaxRetries()
+	for attempt := 0; ; attempt++ {
+		var attemptRequest *http.Request
+		attemptRequest, err = copyRequest(request)
+		if err != nil {
+			return
+		}
+		response, err = dpx.send(operation.ID, attemptRequest, result, limiters)
+
+		if err == nil || attempt >= maxRetries || !isTooManyRequests(response) {
+			return
+		}
+		if stats := getCallStats(request.Context()); stats != nil {
+			atomic.AddInt32(&stats.retries, 1)
+		}
+	}
+}
+
+// send sends "request" once, subject to the service's circuit breaker and the specified rate limiters.
+func (dpx *DpxV1) send(operationID string, request *http.Request, result interface{}, limiters []*RateLimiter) (response *core.DetailedResponse, err error) {
+	breaker := dpx.circuitBreaker
+	var generation uint64
+	if breaker != nil {
+		generation, err = breaker.allow(operationID)
+		if err != nil {
+			return
+		}
+	}
+
+	for _, limiter := range limiters {
+		err = limiter.Wait(request.Context())
+		if err != nil {
+			if breaker != nil {
+				breaker.release(generation)
+			}
+			return
+		}
+	}
+
+	response, err = dpx.Service.Request(request, result)
+	if stats := getCallStats(request.Context()); stats != nil {
+		stats.endSend()
+	}
+
+	if breaker != nil {
+		breaker.record(generation, response, err)
+	}
+	for _, limiter := range limiters {
+		limiter.observe(response)
+	}
+	return
+}
+
+// bufferRequestBody reads the body of "request" into memory (if needed) so that
+// copies of the request can be sent with a fresh body each time.
+func bufferRequestBody(request *http.Request) error {
+	if request.Body == nil || request.Body == http.NoBody || request.GetBody != nil {
+		return nil
+	}
+	body, err := io.ReadAll(request.Body)
+	_ = request.Body.Close()
+	if err != nil {
+		return err
+	}
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	request.Body, _ = request.GetBody()
+	return nil
+}
+
+// readRequestBody returns the body of "request", decompressed if it was compressed with gzip (see
+// SetEnableGzipCompression), or nil if the request has no body. The body is buffered first, so the
+// request can still be sent.
+func readRequestBody(request *http.Request) ([]byte, error) {
+	err := bufferRequestBody(request)
+	if err != nil || request.GetBody == nil {
+		return nil, err
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	var reader io.Reader = body
+	if request.Header.Get("Content-Encoding") == "gzip" {
+		reader, err = gzip.NewReader(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return io.ReadAll(reader)
+}
+
+// copyRequest returns a copy of "request" (including its headers and body) that can be
+// sent independently of the original.
+func copyRequest(request *http.Request) (*http.Request, error) {
+	requestCopy := request.Clone(request.Context())
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		requestCopy.Body = body
+	}
+	return requestCopy, nil
+}
+
+// getResponseErrors returns the errors reported in the body of an unsuccessful response.
+func getResponseErrors(response *core.DetailedResponse) (errors []ErrorModelResource) {
+	if response == nil {
+		return
+	}
+	resultMap, ok := response.GetResultAsMap()
+	if !ok || resultMap["errors"] == nil {
+		return
+	}
+	buf, err := json.Marshal(resultMap["errors"])
+	if err != nil {
+		return
+	}
+	_ = json.Unmarshal(buf, &errors)
+	return
+}
+
+// getResponseErrorCode returns the code of the first error reported in the body of an
+// unsuccessful response, or "" if the response does not contain an error code.
+func getResponseErrorCode(response *core.DetailedResponse) string {
+	for _, responseError := range getResponseErrors(response) {
+		if responseError.Code != nil {
+			return *responseError.Code
+		}
+	}
+	return ""
+}
+
+// getResponseTrace returns the "trace" value reported in the body of an unsuccessful response,
+// which identifies the failed request in the service's logs.
+func getResponseTrace(response *core.DetailedResponse) string {
+	if response == nil {
+		return ""
+	}
+	resultMap, ok := response.GetResultAsMap()
+	if !ok {
+		return ""
+	}
+	traceID, _ := resultMap["trace"].(string)
+	return traceID
+}
+
+// isTooManyRequests returns true if the service rejected a request because of rate limiting.
+func isTooManyRequests(response *core.DetailedResponse) bool {
+	if response == nil {
+		return false
+	}
+	return response.StatusCode == http.StatusTooManyRequests ||
+		getResponseErrorCode(response) == ErrorModelResource_Code_TooManyRequests
+}
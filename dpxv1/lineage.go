@@ -0,0 +1,411 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Constants associated with the LineageNode.Kind property.
+const (
+	LineageNode_Kind_Container      = "container"
+	LineageNode_Kind_DataProduct    = "data_product"
+	LineageNode_Kind_DeliveryMethod = "delivery_method"
+	LineageNode_Kind_Domain         = "domain"
+	LineageNode_Kind_Part           = "part"
+	LineageNode_Kind_Release        = "release"
+)
+
+// Constants associated with the LineageEdge.Kind property.
+const (
+	// From a release to its data product.
+	LineageEdge_Kind_ReleaseOf = "release_of"
+
+	// From a release to a part of its parts_out.
+	LineageEdge_Kind_PartsOut = "parts_out"
+
+	// From a release, a part or a delivery method to its container.
+	LineageEdge_Kind_Container = "container"
+
+	// From a release to its domain.
+	LineageEdge_Kind_Domain = "domain"
+
+	// From a part to one of its delivery methods.
+	LineageEdge_Kind_DeliveryMethod = "delivery_method"
+)
+
+// LineageNode : A node of a LineageGraph.
+type LineageNode struct {
+	// The ID of the node: the kind, followed by the ID of the resource (e.g. "part:catalog-1/asset-1").
+	ID string `json:"id"`
+
+	// The kind of the node.
+	Kind string `json:"kind"`
+
+	// A human-readable label, e.g. the name of a data product or the version of a release.
+	Label string `json:"label,omitempty"`
+}
+
+// LineageEdge : A directed edge of a LineageGraph.
+type LineageEdge struct {
+	// The ID of the source node.
+	From string `json:"from"`
+
+	// The ID of the target node.
+	To string `json:"to"`
+
+	// The kind of the edge.
+	Kind string `json:"kind"`
+}
+
+// LineageOptions : Options for BuildLineageGraph.
+type LineageOptions struct {
+	// The IDs of the data products whose releases are added to the graph. Defaults to every data product
+	// (as listed by ListDataProducts).
+	DataProductIDs []string
+
+	// The states of the releases that are added to the graph. Defaults to available.
+	States []string
+}
+
+// LineageGraph : A graph of data products, their releases, the asset parts they expose, and the containers,
+// domains and delivery methods they use. Build it with BuildLineageGraph, or from models with AddVersion.
+type LineageGraph struct {
+	nodes map[string]*LineageNode
+	edges map[LineageEdge]bool
+
+	// The asset of each part node, keyed by node ID.
+	parts map[string]lineagePart
+}
+
+// lineagePart identifies the asset of a part node.
+type lineagePart struct {
+	containerID string
+	assetID     string
+}
+
+// NewLineageGraph returns an empty graph.
+func NewLineageGraph() *LineageGraph {
+	return &LineageGraph{
+		nodes: make(map[string]*LineageNode),
+		edges: make(map[LineageEdge]bool),
+		parts: make(map[string]lineagePart),
+	}
+}
+
+// BuildLineageGraph lists the releases of the data products and retrieves each of them to build the graph.
+// Specify nil options to add every available release.
+func BuildLineageGraph(ctx context.Context, client DpxV1API, options *LineageOptions) (*LineageGraph, error) {
+	if options == nil {
+		options = &LineageOptions{}
+	}
+	states := options.States
+	if len(states) == 0 {
+		states = []string{ListDataProductReleasesOptions_State_Available}
+	}
+	dataProductIDs, err := listDataProductIDs(ctx, client, options.DataProductIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := NewLineageGraph()
+	for _, dataProductID := range dataProductIDs {
+		releasesOptions := client.NewListDataProductReleasesOptions(dataProductID)
+		releasesOptions.SetState(states)
+		pager, err := client.NewDataProductReleasesPager(releasesOptions)
+		if err != nil {
+			return nil, err
+		}
+		summaries, err := pager.GetAllWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range summaries {
+			release, _, err := client.GetDataProductReleaseWithContext(ctx,
+				client.NewGetDataProductReleaseOptions(dataProductID, stringValue(summary.ID)))
+			if err != nil {
+				return nil, err
+			}
+			graph.AddVersion(release)
+		}
+	}
+	return graph, nil
+}
+
+// AddVersion adds a data product version (typically a release), its data product, parts, containers, domain and
+// delivery methods to the graph. Nodes and edges that are already in the graph are not duplicated.
+func (graph *LineageGraph) AddVersion(version *DataProductVersion) {
+	dataProductID := ""
+	if version.DataProduct != nil {
+		dataProductID = stringValue(version.DataProduct.ID)
+	}
+	dataProduct := graph.addNode(LineageNode_Kind_DataProduct, dataProductID, stringValue(version.Name))
+	release := graph.addNode(LineageNode_Kind_Release, dataProductID+"/"+stringValue(version.ID), stringValue(version.Version))
+	graph.addEdge(release, dataProduct, LineageEdge_Kind_ReleaseOf)
+
+	if version.Asset != nil {
+		graph.addContainerEdge(release, version.Asset.Container)
+	}
+	if version.Domain != nil {
+		domain := graph.addNode(LineageNode_Kind_Domain, stringValue(version.Domain.ID), stringValue(version.Domain.Name))
+		graph.addEdge(release, domain, LineageEdge_Kind_Domain)
+	}
+	for _, part := range version.PartsOut {
+		if part.Asset == nil {
+			continue
+		}
+		partNode := graph.addNode(LineageNode_Kind_Part, containerID(part.Asset.Container)+"/"+stringValue(part.Asset.ID), "")
+		graph.parts[partNode] = lineagePart{containerID: containerID(part.Asset.Container), assetID: stringValue(part.Asset.ID)}
+		graph.addEdge(release, partNode, LineageEdge_Kind_PartsOut)
+		graph.addContainerEdge(partNode, part.Asset.Container)
+		for _, deliveryMethod := range part.DeliveryMethods {
+			deliveryMethodNode := graph.addNode(LineageNode_Kind_DeliveryMethod,
+				containerID(deliveryMethod.Container)+"/"+stringValue(deliveryMethod.ID), "")
+			graph.addEdge(partNode, deliveryMethodNode, LineageEdge_Kind_DeliveryMethod)
+			graph.addContainerEdge(deliveryMethodNode, deliveryMethod.Container)
+		}
+	}
+}
+
+func (graph *LineageGraph) addNode(kind string, id string, label string) string {
+	nodeID := kind + ":" + id
+	node := graph.nodes[nodeID]
+	if node == nil {
+		node = &LineageNode{ID: nodeID, Kind: kind}
+		graph.nodes[nodeID] = node
+	}
+	if label != "" {
+		node.Label = label
+	}
+	return nodeID
+}
+
+func (graph *LineageGraph) addEdge(from string, to string, kind string) {
+	graph.edges[LineageEdge{From: from, To: to, Kind: kind}] = true
+}
+
+func (graph *LineageGraph) addContainerEdge(from string, container *ContainerReference) {
+	if container == nil || container.ID == nil {
+		return
+	}
+	graph.addEdge(from, graph.addNode(LineageNode_Kind_Container, *container.ID, ""), LineageEdge_Kind_Container)
+}
+
+func containerID(container *ContainerReference) string {
+	if container == nil {
+		return ""
+	}
+	return stringValue(container.ID)
+}
+
+// Nodes returns the nodes of the graph, sorted by ID.
+func (graph *LineageGraph) Nodes() []LineageNode {
+	nodes := make([]LineageNode, 0, len(graph.nodes))
+	for _, node := range graph.nodes {
+		nodes = append(nodes, *node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes
+}
+
+// Edges returns the edges of the graph, sorted by source, target and kind.
+func (graph *LineageGraph) Edges() []LineageEdge {
+	edges := make([]LineageEdge, 0, len(graph.edges))
+	for edge := range graph.edges {
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		return edges[i].Kind < edges[j].Kind
+	})
+	return edges
+}
+
+// DataProductsExposingAsset returns the IDs of the data products that have a release with the asset "assetID" of
+// the container "containerID" in its parts_out, sorted. Specify an empty containerID to match any container.
+func (graph *LineageGraph) DataProductsExposingAsset(containerID string, assetID string) []string {
+	dataProductIDs := make(map[string]bool)
+	for edge := range graph.edges {
+		if edge.Kind != LineageEdge_Kind_PartsOut {
+			continue
+		}
+		part := graph.parts[edge.To]
+		if part.assetID == assetID && (containerID == "" || part.containerID == containerID) {
+			graph.addReleaseDataProduct(dataProductIDs, edge.From)
+		}
+	}
+	return sortedKeys(dataProductIDs)
+}
+
+// DataProductsInContainer returns the IDs of the data products that have a release whose asset, parts or delivery
+// methods are in the container "containerID", sorted.
+func (graph *LineageGraph) DataProductsInContainer(containerID string) []string {
+	return graph.containerDataProducts()[containerID]
+}
+
+// SharedContainers returns the containers that are used by more than one data product (see DataProductsInContainer),
+// with the IDs of these data products.
+func (graph *LineageGraph) SharedContainers() map[string][]string {
+	shared := make(map[string][]string)
+	for containerID, dataProductIDs := range graph.containerDataProducts() {
+		if len(dataProductIDs) > 1 {
+			shared[containerID] = dataProductIDs
+		}
+	}
+	return shared
+}
+
+// containerDataProducts returns the sorted IDs of the data products that use each container.
+func (graph *LineageGraph) containerDataProducts() map[string][]string {
+	// The releases and parts that lead to each node, following the edges backwards.
+	sources := make(map[string][]string)
+	for edge := range graph.edges {
+		if edge.Kind != LineageEdge_Kind_ReleaseOf {
+			sources[edge.To] = append(sources[edge.To], edge.From)
+		}
+	}
+	result := make(map[string][]string)
+	for nodeID, node := range graph.nodes {
+		if node.Kind != LineageNode_Kind_Container {
+			continue
+		}
+		dataProductIDs := make(map[string]bool)
+		visited := map[string]bool{nodeID: true}
+		pending := []string{nodeID}
+		for len(pending) > 0 {
+			current := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+			for _, source := range sources[current] {
+				if visited[source] {
+					continue
+				}
+				visited[source] = true
+				if graph.nodes[source].Kind == LineageNode_Kind_Release {
+					graph.addReleaseDataProduct(dataProductIDs, source)
+				} else {
+					pending = append(pending, source)
+				}
+			}
+		}
+		result[strings.TrimPrefix(nodeID, LineageNode_Kind_Container+":")] = sortedKeys(dataProductIDs)
+	}
+	return result
+}
+
+// addReleaseDataProduct adds the ID of the data product of the release node "releaseID" to "dataProductIDs".
+func (graph *LineageGraph) addReleaseDataProduct(dataProductIDs map[string]bool, releaseID string) {
+	for edge := range graph.edges {
+		if edge.From == releaseID && edge.Kind == LineageEdge_Kind_ReleaseOf {
+			dataProductIDs[strings.TrimPrefix(edge.To, LineageNode_Kind_DataProduct+":")] = true
+		}
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// JSON returns the graph as indented JSON, with a "nodes" and an "edges" array.
+func (graph *LineageGraph) JSON() ([]byte, error) {
+	return json.MarshalIndent(&struct {
+		Nodes []LineageNode `json:"nodes"`
+		Edges []LineageEdge `json:"edges"`
+	}{graph.Nodes(), graph.Edges()}, "", "  ")
+}
+
+// DOT returns the graph in the Graphviz DOT language, with one shape per kind of node.
+func (graph *LineageGraph) DOT() string {
+	shapes := map[string]string{
+		LineageNode_Kind_Container:      "cylinder",
+		LineageNode_Kind_DataProduct:    "box",
+		LineageNode_Kind_DeliveryMethod: "cds",
+		LineageNode_Kind_Domain:         "tab",
+		LineageNode_Kind_Part:           "note",
+		LineageNode_Kind_Release:        "ellipse",
+	}
+	var builder strings.Builder
+	builder.WriteString("digraph lineage {\n")
+	for _, node := range graph.Nodes() {
+		label := dotEscape(node.ID)
+		if node.Label != "" {
+			// "\n" is the line break escape of DOT.
+			label = dotEscape(node.Label) + `\n` + label
+		}
+		fmt.Fprintf(&builder, "  %s [label=\"%s\", shape=%s];\n", dotQuote(node.ID), label, shapes[node.Kind])
+	}
+	for _, edge := range graph.Edges() {
+		fmt.Fprintf(&builder, "  %s -> %s [label=%s];\n", dotQuote(edge.From), dotQuote(edge.To), dotQuote(edge.Kind))
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// dotQuote returns "s" as a quoted string of the DOT language.
+func dotQuote(s string) string {
+	return `"` + dotEscape(s) + `"`
+}
+
+// dotEscape escapes the double quotes and backslashes of "s", the only characters of a quoted DOT string that
+// need escaping.
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+// GraphML returns the graph in the GraphML format, with the kind and label of the nodes and the kind of the edges
+// as data attributes.
+func (graph *LineageGraph) GraphML() string {
+	var buffer bytes.Buffer
+	escape := func(value string) string {
+		var escaped bytes.Buffer
+		_ = xml.EscapeText(&escaped, []byte(value))
+		return escaped.String()
+	}
+	buffer.WriteString(xml.Header)
+	buffer.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	buffer.WriteString(`  <key id="kind" for="all" attr.name="kind" attr.type="string"/>` + "\n")
+	buffer.WriteString(`  <key id="label" for="node" attr.name="label" attr.type="string"/>` + "\n")
+	buffer.WriteString(`  <graph id="lineage" edgedefault="directed">` + "\n")
+	for _, node := range graph.Nodes() {
+		fmt.Fprintf(&buffer, `    <node id="%s"><data key="kind">%s</data>`, escape(node.ID), node.Kind)
+		if node.Label != "" {
+			fmt.Fprintf(&buffer, `<data key="label">%s</data>`, escape(node.Label))
+		}
+		buffer.WriteString("</node>\n")
+	}
+	for _, edge := range graph.Edges() {
+		fmt.Fprintf(&buffer, `    <edge source="%s" target="%s"><data key="kind">%s</data></edge>`+"\n",
+			escape(edge.From), escape(edge.To), edge.Kind)
+	}
+	buffer.WriteString("  </graph>\n</graphml>\n")
+	return buffer.String()
+}
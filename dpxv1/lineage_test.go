@@ -0,0 +1,131 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`LineageGraph`, func() {
+	var server *catalogServer
+	var dpxService *dpxv1.DpxV1
+
+	BeforeEach(func() {
+		server = newCatalogServer()
+		dpxService = server.newService()
+
+		sales := testRelease("product-1", "release-1")
+		sales.Domain = &dpxv1.Domain{ID: core.StringPtr("domain-1"), Name: core.StringPtr("Sales & Marketing")}
+		sales.PartsOut[0].DeliveryMethods = []dpxv1.DeliveryMethod{{
+			ID:        core.StringPtr("download"),
+			Container: &dpxv1.ContainerReference{ID: core.StringPtr("catalog-1")},
+		}}
+		server.put(sales)
+		ledger := testVersion("product-2", "release-2", dpxv1.DataProductVersion_State_Available, "Ledger")
+		ledger.Asset.Container = &dpxv1.ContainerReference{ID: core.StringPtr("catalog-2")}
+		ledger.PartsOut = []dpxv1.DataProductPart{{
+			Asset: &dpxv1.AssetPartReference{ID: core.StringPtr("part-1"), Container: &dpxv1.ContainerReference{ID: core.StringPtr("catalog-1")}},
+		}}
+		server.put(ledger)
+		other := testVersion("product-4", "release-4", dpxv1.DataProductVersion_State_Retired, "Other")
+		other.Asset.Container = &dpxv1.ContainerReference{ID: core.StringPtr("catalog-3")}
+		other.PartsOut = []dpxv1.DataProductPart{
+			{Asset: &dpxv1.AssetPartReference{ID: core.StringPtr("x/part-1"), Container: &dpxv1.ContainerReference{ID: core.StringPtr("catalog-3")}}},
+			{Asset: &dpxv1.AssetPartReference{ID: core.StringPtr("part-1"), Container: &dpxv1.ContainerReference{ID: core.StringPtr("catalog-3")}}},
+		}
+		server.put(other)
+		server.put(testVersion("product-3", "release-3", dpxv1.DataProductVersion_State_Retired, "Archive"))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Builds the graph of the available releases`, func() {
+		graph, err := dpxv1.BuildLineageGraph(context.Background(), dpxService, nil)
+		Expect(err).To(BeNil())
+		Expect(graph.Nodes()).To(Equal([]dpxv1.LineageNode{
+			{ID: "container:catalog-1", Kind: dpxv1.LineageNode_Kind_Container},
+			{ID: "container:catalog-2", Kind: dpxv1.LineageNode_Kind_Container},
+			{ID: "data_product:product-1", Kind: dpxv1.LineageNode_Kind_DataProduct, Label: "Sales"},
+			{ID: "data_product:product-2", Kind: dpxv1.LineageNode_Kind_DataProduct, Label: "Ledger"},
+			{ID: "delivery_method:catalog-1/download", Kind: dpxv1.LineageNode_Kind_DeliveryMethod},
+			{ID: "domain:domain-1", Kind: dpxv1.LineageNode_Kind_Domain, Label: "Sales & Marketing"},
+			{ID: "part:catalog-1/part-1", Kind: dpxv1.LineageNode_Kind_Part},
+			{ID: "release:product-1/release-1", Kind: dpxv1.LineageNode_Kind_Release, Label: "1.0.0"},
+			{ID: "release:product-2/release-2", Kind: dpxv1.LineageNode_Kind_Release, Label: "1.0.0"},
+		}))
+		Expect(graph.Edges()).To(ContainElements(
+			dpxv1.LineageEdge{From: "release:product-1/release-1", To: "part:catalog-1/part-1", Kind: dpxv1.LineageEdge_Kind_PartsOut},
+			dpxv1.LineageEdge{From: "part:catalog-1/part-1", To: "delivery_method:catalog-1/download", Kind: dpxv1.LineageEdge_Kind_DeliveryMethod},
+			dpxv1.LineageEdge{From: "release:product-1/release-1", To: "domain:domain-1", Kind: dpxv1.LineageEdge_Kind_Domain},
+			dpxv1.LineageEdge{From: "release:product-2/release-2", To: "container:catalog-2", Kind: dpxv1.LineageEdge_Kind_Container},
+		))
+		Expect(graph.Edges()).To(HaveLen(10))
+	})
+	It(`Answers which products expose an asset or share a container`, func() {
+		graph, err := dpxv1.BuildLineageGraph(context.Background(), dpxService, &dpxv1.LineageOptions{
+			States: []string{dpxv1.ListDataProductReleasesOptions_State_Available, dpxv1.ListDataProductReleasesOptions_State_Retired},
+		})
+		Expect(err).To(BeNil())
+		Expect(graph.DataProductsExposingAsset("", "part-1")).To(Equal([]string{"product-1", "product-2", "product-4"}))
+		Expect(graph.DataProductsExposingAsset("catalog-1", "part-1")).To(Equal([]string{"product-1", "product-2"}))
+		Expect(graph.DataProductsExposingAsset("catalog-3", "x/part-1")).To(Equal([]string{"product-4"}))
+		Expect(graph.DataProductsExposingAsset("", "part-2")).To(BeEmpty())
+		Expect(graph.DataProductsInContainer("catalog-2")).To(Equal([]string{"product-2"}))
+		Expect(graph.SharedContainers()).To(Equal(map[string][]string{
+			"catalog-1": {"product-1", "product-2", "product-3"},
+		}))
+	})
+	It(`Exports to DOT, GraphML and JSON`, func() {
+		graph := dpxv1.NewLineageGraph()
+		release := testRelease("product-1", "release-1")
+		release.Domain = &dpxv1.Domain{ID: core.StringPtr("domain-1"), Name: core.StringPtr(`Sales & "Marketing"`)}
+		graph.AddVersion(release)
+		graph.AddVersion(release)
+		ledger := testVersion("product-2", "release-2", dpxv1.DataProductVersion_State_Available, "Ledger")
+		ledger.Domain = &dpxv1.Domain{ID: core.StringPtr("domain-2"), Name: core.StringPtr(`Café \ Ünïcode`)}
+		graph.AddVersion(ledger)
+
+		dot := graph.DOT()
+		Expect(dot).To(HavePrefix("digraph lineage {\n"))
+		Expect(dot).To(ContainSubstring(`  "domain:domain-1" [label="Sales & \"Marketing\"\ndomain:domain-1", shape=tab];`))
+		Expect(dot).To(ContainSubstring(`  "domain:domain-2" [label="Café \\ Ünïcode\ndomain:domain-2", shape=tab];`))
+		Expect(dot).To(ContainSubstring(`  "release:product-1/release-1" -> "data_product:product-1" [label="release_of"];`))
+
+		graphML := graph.GraphML()
+		Expect(xml.Unmarshal([]byte(graphML), new(interface{}))).To(Succeed())
+		Expect(graphML).To(ContainSubstring(`<node id="domain:domain-1"><data key="kind">domain</data><data key="label">Sales &amp; &#34;Marketing&#34;</data></node>`))
+		Expect(graphML).To(ContainSubstring(`<edge source="release:product-1/release-1" target="part:catalog-1/part-1"><data key="kind">parts_out</data></edge>`))
+
+		data, err := graph.JSON()
+		Expect(err).To(BeNil())
+		var decoded struct {
+			Nodes []dpxv1.LineageNode `json:"nodes"`
+			Edges []dpxv1.LineageEdge `json:"edges"`
+		}
+		Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+		Expect(decoded.Nodes).To(Equal(graph.Nodes()))
+		Expect(decoded.Edges).To(Equal(graph.Edges()))
+	})
+})
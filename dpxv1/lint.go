@@ -0,0 +1,359 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"gopkg.in/yaml.v3"
+)
+
+// Constants associated with the LintRuleConfig.Type property.
+const (
+	// The description must have at least "min" characters.
+	LintRule_Type_MinDescriptionLength = "min_description_length"
+
+	// Every tag must be one of "values".
+	LintRule_Type_AllowedTags = "allowed_tags"
+
+	// A restricted version (is_restricted) must have a contract terms document of type "document_type"
+	// (terms_and_conditions by default).
+	LintRule_Type_RestrictedRequiresDocument = "restricted_requires_document"
+
+	// Every part of parts_out must have at least one delivery method.
+	LintRule_Type_PartsRequireDeliveryMethod = "parts_require_delivery_method"
+
+	// The domain is required.
+	LintRule_Type_DomainRequired = "domain_required"
+)
+
+// Constants associated with the LintFinding.Severity and LintRuleConfig.Severity properties.
+const (
+	LintFinding_Severity_Error   = "error"
+	LintFinding_Severity_Warning = "warning"
+	LintFinding_Severity_Info    = "info"
+)
+
+// LintConfig : The governance rules of a Linter, declared in YAML or JSON, e.g.:
+//
+//	rules:
+//	  - type: min_description_length
+//	    min: 40
+//	  - type: allowed_tags
+//	    severity: warning
+//	    values: [finance, sales, marketing]
+//	  - type: restricted_requires_document
+//	  - type: parts_require_delivery_method
+//	  - type: domain_required
+type LintConfig struct {
+	Rules []LintRuleConfig `json:"rules" yaml:"rules"`
+}
+
+// LintRuleConfig : A rule of a LintConfig.
+type LintRuleConfig struct {
+	// The identifier reported in the findings of the rule. Defaults to the type.
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
+
+	// The type of the rule.
+	Type string `json:"type" yaml:"type"`
+
+	// The severity of the findings of the rule. Defaults to error.
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+
+	// The minimum length (for min_description_length).
+	Min int `json:"min,omitempty" yaml:"min,omitempty"`
+
+	// The allowed values (for allowed_tags).
+	Values []string `json:"values,omitempty" yaml:"values,omitempty"`
+
+	// The type of the required document (for restricted_requires_document).
+	DocumentType string `json:"document_type,omitempty" yaml:"document_type,omitempty"`
+}
+
+// LintFinding : A violation of a rule by a data product version.
+type LintFinding struct {
+	// The ID of the rule.
+	RuleID string `json:"rule_id"`
+
+	// The severity of the finding.
+	Severity string `json:"severity"`
+
+	// The JSON pointer of the offending property, e.g. "/tags/2".
+	Path string `json:"path"`
+
+	// A description of the violation.
+	Message string `json:"message"`
+}
+
+// String returns a description of the finding, e.g. `error: /domain: a domain is required (domain_required)`.
+func (finding LintFinding) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", finding.Severity, finding.Path, finding.Message, finding.RuleID)
+}
+
+// LintReport : The findings of a Linter.
+type LintReport struct {
+	Findings []LintFinding `json:"findings"`
+}
+
+// HasErrors returns true if a finding has the severity error.
+func (report LintReport) HasErrors() bool {
+	return slices.ContainsFunc(report.Findings, func(finding LintFinding) bool {
+		return finding.Severity == LintFinding_Severity_Error
+	})
+}
+
+// Err returns a *LintError with the findings of the report if it has errors, or nil.
+func (report LintReport) Err() error {
+	if !report.HasErrors() {
+		return nil
+	}
+	return &LintError{Findings: report.Findings}
+}
+
+// ErrLintFailed is matched (via errors.Is) by the errors returned for versions that violate a rule with the
+// severity error.
+var ErrLintFailed = errors.New("data product version violates governance rules")
+
+// LintError is returned for a data product version that violates a rule with the severity error.
+type LintError struct {
+	// The findings of every severity.
+	Findings []LintFinding
+}
+
+// Error returns the error message, with the findings of severity error.
+func (err *LintError) Error() string {
+	var messages []string
+	for _, finding := range err.Findings {
+		if finding.Severity == LintFinding_Severity_Error {
+			messages = append(messages, finding.Path+": "+finding.Message)
+		}
+	}
+	return fmt.Sprintf("%s: %s", ErrLintFailed.Error(), strings.Join(messages, "; "))
+}
+
+// Is returns true if "target" is ErrLintFailed.
+func (err *LintError) Is(target error) bool {
+	return target == ErrLintFailed
+}
+
+// ParseLintConfig parses a YAML or JSON lint configuration. Unknown properties are rejected.
+func ParseLintConfig(data []byte) (*LintConfig, error) {
+	config := &LintConfig{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err := decoder.Decode(config)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("error parsing lint configuration: %w", err)
+	}
+	return config, nil
+}
+
+// LoadLintConfig reads a YAML or JSON lint configuration file.
+func LoadLintConfig(path string) (*LintConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLintConfig(data)
+}
+
+// Linter : Evaluates data product versions and prototypes against governance rules. It can be run in CI before
+// CreateDataProduct (see LintPrototype), or enforced by a DpxV1 instance (see NewLintInterceptor).
+type Linter struct {
+	rules []LintRuleConfig
+}
+
+// NewLinter returns a Linter for the rules of "config", or an error if a rule is invalid.
+func NewLinter(config *LintConfig) (*Linter, error) {
+	linter := &Linter{}
+	for i, rule := range config.Rules {
+		if rule.ID == "" {
+			rule.ID = rule.Type
+		}
+		if rule.Severity == "" {
+			rule.Severity = LintFinding_Severity_Error
+		}
+		if !slices.Contains([]string{LintFinding_Severity_Error, LintFinding_Severity_Warning, LintFinding_Severity_Info}, rule.Severity) {
+			return nil, fmt.Errorf("rule %d (%s): invalid severity %q", i, rule.ID, rule.Severity)
+		}
+		switch rule.Type {
+		case LintRule_Type_MinDescriptionLength:
+			if rule.Min <= 0 {
+				return nil, fmt.Errorf("rule %d (%s): min must be positive", i, rule.ID)
+			}
+		case LintRule_Type_AllowedTags:
+			if len(rule.Values) == 0 {
+				return nil, fmt.Errorf("rule %d (%s): values is required", i, rule.ID)
+			}
+		case LintRule_Type_RestrictedRequiresDocument:
+			if rule.DocumentType == "" {
+				rule.DocumentType = ContractTermsDocument_Type_TermsAndConditions
+			}
+		case LintRule_Type_PartsRequireDeliveryMethod, LintRule_Type_DomainRequired:
+		default:
+			return nil, fmt.Errorf("rule %d (%s): unknown type %q", i, rule.ID, rule.Type)
+		}
+		linter.rules = append(linter.rules, rule)
+	}
+	return linter, nil
+}
+
+// LintVersion evaluates a draft or release against the rules.
+func (linter *Linter) LintVersion(version *DataProductVersion) LintReport {
+	report := LintReport{Findings: []LintFinding{}}
+	for _, rule := range linter.rules {
+		report.Findings = append(report.Findings, lintRule(rule, version)...)
+	}
+	return report
+}
+
+// LintPrototype evaluates a draft prototype (as passed to CreateDataProduct or CreateDataProductDraft) against
+// the rules.
+func (linter *Linter) LintPrototype(prototype *DataProductVersionPrototype) LintReport {
+	return linter.LintVersion(&DataProductVersion{
+		Version:       prototype.Version,
+		State:         prototype.State,
+		DataProduct:   prototype.DataProduct,
+		Name:          prototype.Name,
+		Description:   prototype.Description,
+		Asset:         prototype.Asset,
+		Tags:          prototype.Tags,
+		UseCases:      prototype.UseCases,
+		Domain:        prototype.Domain,
+		Types:         prototype.Types,
+		PartsOut:      prototype.PartsOut,
+		ContractTerms: prototype.ContractTerms,
+		IsRestricted:  prototype.IsRestricted,
+	})
+}
+
+func lintRule(rule LintRuleConfig, version *DataProductVersion) (findings []LintFinding) {
+	report := func(path string, format string, args ...interface{}) {
+		findings = append(findings, LintFinding{RuleID: rule.ID, Severity: rule.Severity, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	switch rule.Type {
+	case LintRule_Type_MinDescriptionLength:
+		if length := utf8.RuneCountInString(strings.TrimSpace(stringValue(version.Description))); length < rule.Min {
+			report("/description", "the description must have at least %d characters (has %d)", rule.Min, length)
+		}
+	case LintRule_Type_AllowedTags:
+		for i, tag := range version.Tags {
+			if !slices.Contains(rule.Values, tag) {
+				report(fmt.Sprintf("/tags/%d", i), "tag %q is not in the allowed taxonomy", tag)
+			}
+		}
+	case LintRule_Type_RestrictedRequiresDocument:
+		if version.IsRestricted == nil || !*version.IsRestricted {
+			return
+		}
+		for _, document := range versionDocuments(version) {
+			if stringValue(document.Type) == rule.DocumentType {
+				return
+			}
+		}
+		report("/contract_terms", "a restricted data product must have a %s document", rule.DocumentType)
+	case LintRule_Type_PartsRequireDeliveryMethod:
+		for i, part := range version.PartsOut {
+			if len(part.DeliveryMethods) == 0 {
+				assetID := ""
+				if part.Asset != nil {
+					assetID = stringValue(part.Asset.ID)
+				}
+				report(fmt.Sprintf("/parts_out/%d/delivery_methods", i), "part %q must have at least one delivery method", assetID)
+			}
+		}
+	case LintRule_Type_DomainRequired:
+		if version.Domain == nil || stringValue(version.Domain.ID) == "" {
+			report("/domain", "a domain is required")
+		}
+	}
+	return
+}
+
+// NewLintInterceptor returns an Interceptor that enforces the rules of "linter": the drafts passed to
+// CreateDataProduct and CreateDataProductDraft, and the draft retrieved (with "client") before
+// PublishDataProductDraft, must not violate a rule with the severity error. Otherwise the request is not
+// sent and a *LintError is returned.
+//
+// Example:
+//
+//	dpxService.AddInterceptors(dpxv1.NewLintInterceptor(dpxService, linter))
+func NewLintInterceptor(client DpxV1API, linter *Linter) Interceptor {
+	return InterceptorFunc(func(operation *Operation, request *http.Request, next Invoker) (*core.DetailedResponse, error) {
+		var report LintReport
+		switch operation.ID {
+		case "CreateDataProduct", "CreateDataProductDraft":
+			body, err := readRequestBody(request)
+			if err != nil {
+				return nil, err
+			}
+			report, err = linter.lintRequestBody(operation.ID, body)
+			if err != nil {
+				return nil, err
+			}
+		case "PublishDataProductDraft":
+			draft, _, err := client.GetDataProductDraftWithContext(request.Context(),
+				client.NewGetDataProductDraftOptions(operation.PathParams["data_product_id"], operation.PathParams["draft_id"]))
+			if err != nil {
+				return nil, err
+			}
+			report = linter.LintVersion(draft)
+		}
+		if err := report.Err(); err != nil {
+			return nil, err
+		}
+		return next(request)
+	})
+}
+
+// lintRequestBody evaluates the prototypes of the JSON body of a CreateDataProduct or CreateDataProductDraft
+// request. The paths of the findings of CreateDataProduct are prefixed with the index of the draft.
+func (linter *Linter) lintRequestBody(operationID string, body []byte) (report LintReport, err error) {
+	if operationID == "CreateDataProductDraft" {
+		prototype := &DataProductVersionPrototype{}
+		err = json.Unmarshal(body, prototype)
+		if err != nil {
+			return report, fmt.Errorf("error unmarshalling the draft: %w", err)
+		}
+		return linter.LintPrototype(prototype), nil
+	}
+
+	var createBody struct {
+		Drafts []DataProductVersionPrototype `json:"drafts"`
+	}
+	err = json.Unmarshal(body, &createBody)
+	if err != nil {
+		return report, fmt.Errorf("error unmarshalling the drafts: %w", err)
+	}
+	for i := range createBody.Drafts {
+		for _, finding := range linter.LintPrototype(&createBody.Drafts[i]).Findings {
+			finding.Path = fmt.Sprintf("/drafts/%d%s", i, finding.Path)
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+	return report, nil
+}
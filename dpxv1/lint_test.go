@@ -0,0 +1,174 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Linter`, func() {
+	const config = `
+rules:
+  - type: min_description_length
+    min: 30
+  - id: taxonomy
+    type: allowed_tags
+    severity: warning
+    values: [finance, sales]
+  - type: restricted_requires_document
+  - type: parts_require_delivery_method
+  - type: domain_required
+`
+	var linter *dpxv1.Linter
+
+	BeforeEach(func() {
+		lintConfig, err := dpxv1.ParseLintConfig([]byte(config))
+		Expect(err).To(BeNil())
+		linter, err = dpxv1.NewLinter(lintConfig)
+		Expect(err).To(BeNil())
+	})
+
+	It(`Reports the violations of every rule`, func() {
+		version := testRelease("product-1", "release-1")
+		version.Tags = []string{"finance", "payroll"}
+		version.IsRestricted = core.BoolPtr(true)
+		version.ContractTerms[0].Documents = version.ContractTerms[0].Documents[:1]
+
+		report := linter.LintVersion(version)
+		Expect(report.HasErrors()).To(BeTrue())
+		Expect(report.Findings).To(Equal([]dpxv1.LintFinding{
+			{RuleID: "min_description_length", Severity: "error", Path: "/description", Message: "the description must have at least 30 characters (has 22)"},
+			{RuleID: "taxonomy", Severity: "warning", Path: "/tags/1", Message: `tag "payroll" is not in the allowed taxonomy`},
+			{RuleID: "restricted_requires_document", Severity: "error", Path: "/contract_terms", Message: "a restricted data product must have a terms_and_conditions document"},
+			{RuleID: "parts_require_delivery_method", Severity: "error", Path: "/parts_out/0/delivery_methods", Message: `part "part-1" must have at least one delivery method`},
+			{RuleID: "domain_required", Severity: "error", Path: "/domain", Message: "a domain is required"},
+		}))
+		Expect(report.Findings[1].String()).To(Equal(`warning: /tags/1: tag "payroll" is not in the allowed taxonomy (taxonomy)`))
+
+		err := report.Err()
+		Expect(errors.Is(err, dpxv1.ErrLintFailed)).To(BeTrue())
+		Expect(err.Error()).To(HavePrefix("data product version violates governance rules: /description: "))
+		Expect(err.Error()).ToNot(ContainSubstring("payroll"))
+	})
+	It(`Lints prototypes and accepts compliant versions`, func() {
+		prototype := dpxv1.DataProductVersionPrototype{
+			Description: core.StringPtr("Quarterly sales figures by region"),
+			Tags:        []string{"sales", "marketing"},
+			Domain:      &dpxv1.Domain{ID: core.StringPtr("domain-1")},
+		}
+		report := linter.LintPrototype(&prototype)
+		Expect(report.HasErrors()).To(BeFalse())
+		Expect(report.Err()).To(BeNil())
+		Expect(report.Findings).To(HaveLen(1))
+	})
+	It(`Loads JSON configurations and rejects invalid rules`, func() {
+		dir, err := os.MkdirTemp("", "dpx-lint")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "lint.json")
+		Expect(os.WriteFile(path, []byte(`{"rules": [{"type": "domain_required", "severity": "info"}]}`), 0600)).To(Succeed())
+		lintConfig, err := dpxv1.LoadLintConfig(path)
+		Expect(err).To(BeNil())
+		Expect(lintConfig.Rules).To(Equal([]dpxv1.LintRuleConfig{{Type: "domain_required", Severity: "info"}}))
+
+		for yaml, message := range map[string]string{
+			"rules:\n  - type: unknown\n":                           `unknown type "unknown"`,
+			"rules:\n  - type: domain_required\n    severity: high": `invalid severity "high"`,
+			"rules:\n  - type: min_description_length\n":            "min must be positive",
+			"rules:\n  - type: allowed_tags\n":                      "values is required",
+		} {
+			lintConfig, err := dpxv1.ParseLintConfig([]byte(yaml))
+			Expect(err).To(BeNil())
+			_, err = dpxv1.NewLinter(lintConfig)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		}
+		_, err = dpxv1.ParseLintConfig([]byte("rules:\n  - type: domain_required\n    maximum: 3\n"))
+		Expect(err).To(MatchError(ContainSubstring("field maximum not found")))
+	})
+
+	Describe(`NewLintInterceptor`, func() {
+		var server *catalogServer
+		var dpxService *dpxv1.DpxV1
+
+		BeforeEach(func() {
+			server = newCatalogServer()
+			dpxService = server.newService()
+			dpxService.AddInterceptors(dpxv1.NewLintInterceptor(dpxService, linter))
+		})
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It(`Rejects the publication of a non-compliant draft`, func() {
+			server.put(testVersion("product-1", "draft-1", dpxv1.DataProductVersion_State_Draft, "Sales"))
+			_, _, err := dpxService.PublishDataProductDraft(dpxService.NewPublishDataProductDraftOptions("product-1", "draft-1"))
+			Expect(errors.Is(err, dpxv1.ErrLintFailed)).To(BeTrue())
+			Expect(server.requestLog()).To(Equal([]string{"GET /data_product_exchange/v1/data_products/product-1/drafts/draft-1"}))
+
+			draft := testVersion("product-1", "draft-1", dpxv1.DataProductVersion_State_Draft, "Sales")
+			draft.Description = core.StringPtr("Quarterly sales figures by region")
+			draft.Domain = &dpxv1.Domain{ID: core.StringPtr("domain-1")}
+			server.put(draft)
+			_, _, err = dpxService.PublishDataProductDraft(dpxService.NewPublishDataProductDraftOptions("product-1", "draft-1"))
+			Expect(errors.Is(err, dpxv1.ErrLintFailed)).To(BeFalse())
+			Expect(server.requestLog()).To(ContainElement("POST /data_product_exchange/v1/data_products/product-1/drafts/draft-1/publish"))
+		})
+		It(`Rejects the creation of non-compliant drafts`, func() {
+			compliant := dpxv1.DataProductVersionPrototype{
+				Description: core.StringPtr("Quarterly sales figures by region"),
+				Domain:      &dpxv1.Domain{ID: core.StringPtr("domain-1")},
+				Asset:       &dpxv1.AssetReference{Container: &dpxv1.ContainerReference{ID: core.StringPtr("catalog-1")}},
+			}
+			nonCompliant := compliant
+			nonCompliant.Domain = nil
+			_, _, err := dpxService.CreateDataProduct(dpxService.NewCreateDataProductOptions([]dpxv1.DataProductVersionPrototype{compliant, nonCompliant}))
+			Expect(err).To(MatchError(ContainSubstring("/drafts/1/domain: a domain is required")))
+			Expect(server.requestLog()).To(BeEmpty())
+
+			options := dpxService.NewCreateDataProductDraftOptions("product-1", compliant.Asset)
+			options.SetDescription(*compliant.Description)
+			options.SetDomain(compliant.Domain)
+			_, _, err = dpxService.CreateDataProductDraft(options)
+			Expect(err).To(BeNil())
+			Expect(server.requestLog()).To(Equal([]string{"POST /data_product_exchange/v1/data_products/product-1/drafts"}))
+		})
+		It(`Lints the drafts of compressed requests`, func() {
+			dpxService.SetEnableGzipCompression(true)
+			compliant := dpxv1.DataProductVersionPrototype{
+				Description: core.StringPtr("Quarterly sales figures by region"),
+				Domain:      &dpxv1.Domain{ID: core.StringPtr("domain-1")},
+			}
+			nonCompliant := compliant
+			nonCompliant.Domain = nil
+			_, _, err := dpxService.CreateDataProduct(dpxService.NewCreateDataProductOptions([]dpxv1.DataProductVersionPrototype{nonCompliant}))
+			Expect(errors.Is(err, dpxv1.ErrLintFailed)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("/drafts/0/domain: a domain is required")))
+			Expect(server.requestLog()).To(BeEmpty())
+
+			_, _, err = dpxService.CreateDataProduct(dpxService.NewCreateDataProductOptions([]dpxv1.DataProductVersionPrototype{compliant}))
+			Expect(errors.Is(err, dpxv1.ErrLintFailed)).To(BeFalse())
+			Expect(server.requestLog()).To(Equal([]string{"POST /data_product_exchange/v1/data_products"}))
+		})
+	})
+})
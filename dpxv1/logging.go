@@ -0,0 +1,258 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Keys of the attributes logged for each operation.
+const (
+	LogKeyOperation       = "operation"
+	LogKeyPathParams      = "path_params"
+	LogKeyMethod          = "method"
+	LogKeyURL             = "url"
+	LogKeyStatus          = "status"
+	LogKeyDuration        = "duration"
+	LogKeyRetries         = "retries"
+	LogKeyErrorCode       = "error_code"
+	LogKeyTrace           = "trace"
+	LogKeyCorrelation     = "correlation"
+	LogKeyError           = "error"
+	LogKeyRequestHeaders  = "request_headers"
+	LogKeyResponseHeaders = "response_headers"
+	LogKeyRequestBody     = "request_body"
+	LogKeyResponseBody    = "response_body"
+	LogKeyDryRun          = "dry_run"
+)
+
+// correlationHeaders are the request and response headers that identify a request across the client and
+// the service. They are logged (under LogKeyCorrelation) whenever they are present.
+var correlationHeaders = []string{"X-Correlation-Id", "X-Request-Id", "X-Global-Transaction-Id"}
+
+// LoggingOptions : Options for the structured logging of DpxV1 operations.
+type LoggingOptions struct {
+	// The level at which successful operations are logged. Defaults to slog.LevelDebug.
+	Level slog.Leveler
+
+	// The level at which failed operations are logged. Defaults to slog.LevelError.
+	ErrorLevel slog.Leveler
+
+	// If true, the request and response headers are logged. Secret headers (e.g. "Authorization") are redacted.
+	LogHeaders bool
+
+	// If true, the JSON request and response bodies are logged. Secret properties (e.g. API keys) and
+	// the signatures of pre-signed URLs (e.g. "upload_url") are redacted.
+	LogBodies bool
+}
+
+// loggingInterceptor logs one record per operation to a *slog.Logger.
+type loggingInterceptor struct {
+	logger     *slog.Logger
+	level      slog.Leveler
+	errorLevel slog.Leveler
+	logHeaders bool
+	logBodies  bool
+}
+
+// SetLogger logs each operation of this service instance to "logger", with the operation name, path params,
+// status, duration and correlation IDs. Secrets such as credentials and pre-signed URLs are redacted.
+// Specify nil options to use the defaults, or a nil logger to disable logging.
+//
+// Unlike the core's global logger (core.SetLogger), this logger is specific to this service instance
+// and never logs unredacted requests.
+func (dpx *DpxV1) SetLogger(logger *slog.Logger, options *LoggingOptions) {
+	if logger == nil {
+		dpx.logging = nil
+		return
+	}
+	if options == nil {
+		options = &LoggingOptions{}
+	}
+	dpx.logging = &loggingInterceptor{
+		logger:     logger,
+		level:      options.Level,
+		errorLevel: options.ErrorLevel,
+		logHeaders: options.LogHeaders,
+		logBodies:  options.LogBodies,
+	}
+	if dpx.logging.level == nil {
+		dpx.logging.level = slog.LevelDebug
+	}
+	if dpx.logging.errorLevel == nil {
+		dpx.logging.errorLevel = slog.LevelError
+	}
+}
+
+// GetLogger returns the logger to which the operations of this service instance are logged, or nil.
+func (dpx *DpxV1) GetLogger() *slog.Logger {
+	if dpx.logging == nil {
+		return nil
+	}
+	return dpx.logging.logger
+}
+
+// Intercept implements the Interceptor interface.
+func (interceptor *loggingInterceptor) Intercept(operation *Operation, request *http.Request, next Invoker) (*core.DetailedResponse, error) {
+	ctx := request.Context()
+	level := interceptor.level
+	if !interceptor.logger.Enabled(ctx, level.Level()) && !interceptor.logger.Enabled(ctx, interceptor.errorLevel.Level()) {
+		return next(request)
+	}
+
+	attrs := []slog.Attr{
+		slog.String(LogKeyOperation, operation.ID),
+	}
+	if len(operation.PathParams) > 0 {
+		attrs = append(attrs, slog.Attr{Key: LogKeyPathParams, Value: stringMapValue(operation.PathParams)})
+	}
+	attrs = append(attrs,
+		slog.String(LogKeyMethod, request.Method),
+		slog.String(LogKeyURL, redactURL(request.URL.String())))
+
+	var requestBody []byte
+	if interceptor.logBodies {
+		requestBody, _ = readRequestBody(request)
+	}
+	var requestHeaders http.Header
+	if interceptor.logHeaders {
+		requestHeaders = request.Header.Clone()
+	}
+
+	start := time.Now()
+	response, err := next(request)
+	duration := time.Since(start)
+
+	message := "DPX operation completed"
+	dryRun := isDryRunError(err)
+	if dryRun {
+		message = "DPX operation not sent (dry run)"
+	} else if err != nil {
+		message = "DPX operation failed"
+		level = interceptor.errorLevel
+	}
+	if !interceptor.logger.Enabled(ctx, level.Level()) {
+		return response, err
+	}
+
+	if response != nil {
+		attrs = append(attrs, slog.Int(LogKeyStatus, response.StatusCode))
+	}
+	attrs = append(attrs, slog.Duration(LogKeyDuration, duration))
+	if stats := getCallStats(ctx); stats != nil && stats.getRetries() > 0 {
+		attrs = append(attrs, slog.Int(LogKeyRetries, stats.getRetries()))
+	}
+	if correlation := getCorrelationIDs(request, response); len(correlation) > 0 {
+		attrs = append(attrs, slog.Attr{Key: LogKeyCorrelation, Value: stringMapValue(correlation)})
+	}
+	if dryRun {
+		attrs = append(attrs, slog.Bool(LogKeyDryRun, true))
+	} else if err != nil {
+		if code := getResponseErrorCode(response); code != "" {
+			attrs = append(attrs, slog.String(LogKeyErrorCode, code))
+		}
+		if trace := getResponseTrace(response); trace != "" {
+			attrs = append(attrs, slog.String(LogKeyTrace, trace))
+		}
+		attrs = append(attrs, slog.String(LogKeyError, err.Error()))
+	}
+	if interceptor.logHeaders {
+		attrs = append(attrs, slog.Attr{Key: LogKeyRequestHeaders, Value: headerValue(redactHeaders(requestHeaders))})
+		if response != nil {
+			attrs = append(attrs, slog.Attr{Key: LogKeyResponseHeaders, Value: headerValue(redactHeaders(response.Headers))})
+		}
+	}
+	if interceptor.logBodies {
+		if len(requestBody) > 0 {
+			attrs = append(attrs, slog.String(LogKeyRequestBody, string(redactJSON(requestBody))))
+		}
+		if responseBody := getResponseBody(response); len(responseBody) > 0 {
+			attrs = append(attrs, slog.String(LogKeyResponseBody, string(redactJSON(responseBody))))
+		}
+	}
+
+	interceptor.logger.LogAttrs(ctx, level.Level(), message, attrs...)
+	return response, err
+}
+
+// getCorrelationIDs returns the values of the correlation headers of "request" and "response",
+// keyed by the lower-case header name. Response headers take precedence.
+func getCorrelationIDs(request *http.Request, response *core.DetailedResponse) map[string]string {
+	correlation := make(map[string]string)
+	for _, name := range correlationHeaders {
+		value := request.Header.Get(name)
+		if response != nil && response.Headers.Get(name) != "" {
+			value = response.Headers.Get(name)
+		}
+		if value != "" {
+			correlation[strings.ToLower(name)] = value
+		}
+	}
+	return correlation
+}
+
+// getResponseBody returns the JSON body of "response", or nil if it is not available.
+func getResponseBody(response *core.DetailedResponse) []byte {
+	if response == nil {
+		return nil
+	}
+	if response.RawResult != nil {
+		return response.RawResult
+	}
+	if response.Result == nil {
+		return nil
+	}
+	if _, isStream := response.Result.(io.ReadCloser); isStream {
+		return nil
+	}
+	body, err := json.Marshal(response.Result)
+	if err != nil {
+		return nil
+	}
+	return body
+}
+
+// stringMapValue returns a group value containing the entries of "m", sorted by key.
+func stringMapValue(m map[string]string) slog.Value {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	attrs := make([]slog.Attr, len(keys))
+	for i, key := range keys {
+		attrs[i] = slog.String(key, m[key])
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// headerValue returns a group value containing the (comma-separated) values of "header", sorted by name.
+func headerValue(header http.Header) slog.Value {
+	m := make(map[string]string, len(header))
+	for name, values := range header {
+		m[name] = strings.Join(values, ", ")
+	}
+	return stringMapValue(m)
+}
@@ -0,0 +1,141 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Logging`, func() {
+	const presignedURL = `https://cos.example.com/bucket/terms.pdf?X-Amz-Credential=AKIA123&X-Amz-Signature=deadbeef&partNumber=1`
+	var testServer *httptest.Server
+	var dpxService *dpxv1.DpxV1
+	var output *bytes.Buffer
+
+	BeforeEach(func() {
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Set("Content-type", "application/json")
+			res.Header().Set("X-Request-Id", "request-123")
+			res.Header().Set("Set-Cookie", "session=secret")
+			if req.Method == http.MethodDelete {
+				res.WriteHeader(404)
+				fmt.Fprint(res, `{"errors": [{"code": "does_not_exist", "message": "Draft not found"}], "trace": "trace-123"}`)
+				return
+			}
+			res.WriteHeader(201)
+			fmt.Fprintf(res, `{"url": "https://example.com/terms", "type": "sdp", "name": "Terms", "id": "doc-1", "upload_url": %q}`, presignedURL)
+		}))
+		var serviceErr error
+		dpxService, serviceErr = dpxv1.NewDpxV1(&dpxv1.DpxV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(serviceErr).To(BeNil())
+		output = &bytes.Buffer{}
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	records := func() (records []map[string]interface{}) {
+		for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var record map[string]interface{}
+			Expect(json.Unmarshal([]byte(line), &record)).To(BeNil())
+			records = append(records, record)
+		}
+		return
+	}
+
+	It(`Logs each operation with redacted headers and bodies`, func() {
+		logger := slog.New(slog.NewJSONHandler(output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		dpxService.SetLogger(logger, &dpxv1.LoggingOptions{LogHeaders: true, LogBodies: true})
+		Expect(dpxService.GetLogger()).To(Equal(logger))
+
+		options := dpxService.NewCreateDraftContractTermsDocumentOptions("product-1", "draft-1", "terms-1", "sdp", "Terms", "doc-1", "https://example.com/terms")
+		options.SetUploadURL(presignedURL)
+		options.SetHeaders(map[string]string{"X-Api-Key": "my-api-key"})
+		_, _, err := dpxService.CreateDraftContractTermsDocument(options)
+		Expect(err).To(BeNil())
+
+		logged := records()
+		Expect(logged).To(HaveLen(1))
+		record := logged[0]
+		Expect(record["level"]).To(Equal("DEBUG"))
+		Expect(record["msg"]).To(Equal("DPX operation completed"))
+		Expect(record[dpxv1.LogKeyOperation]).To(Equal("CreateDraftContractTermsDocument"))
+		Expect(record[dpxv1.LogKeyPathParams]).To(Equal(map[string]interface{}{
+			"data_product_id": "product-1", "draft_id": "draft-1", "contract_terms_id": "terms-1",
+		}))
+		Expect(record[dpxv1.LogKeyMethod]).To(Equal("POST"))
+		Expect(record[dpxv1.LogKeyStatus]).To(Equal(float64(201)))
+		Expect(record).To(HaveKey(dpxv1.LogKeyDuration))
+		Expect(record[dpxv1.LogKeyCorrelation]).To(HaveKeyWithValue("x-request-id", "request-123"))
+		Expect(record[dpxv1.LogKeyCorrelation]).To(HaveKey("x-correlation-id"))
+
+		Expect(record[dpxv1.LogKeyRequestHeaders]).To(HaveKeyWithValue("X-Api-Key", dpxv1.RedactedValue))
+		Expect(record[dpxv1.LogKeyResponseHeaders]).To(HaveKeyWithValue("Set-Cookie", dpxv1.RedactedValue))
+
+		Expect(output.String()).ToNot(ContainSubstring("my-api-key"))
+		Expect(output.String()).ToNot(ContainSubstring("AKIA123"))
+		Expect(output.String()).ToNot(ContainSubstring("deadbeef"))
+		Expect(output.String()).ToNot(ContainSubstring("session=secret"))
+		Expect(record[dpxv1.LogKeyRequestBody]).To(ContainSubstring("partNumber=1"))
+		Expect(record[dpxv1.LogKeyResponseBody]).To(ContainSubstring(`"name":"Terms"`))
+	})
+	It(`Logs failed operations at the error level with the error code and trace`, func() {
+		logger := slog.New(slog.NewJSONHandler(output, &slog.HandlerOptions{Level: slog.LevelInfo}))
+		dpxService.SetLogger(logger, nil)
+
+		// Successful operations are logged at the debug level by default.
+		options := dpxService.NewCreateDraftContractTermsDocumentOptions("product-1", "draft-1", "terms-1", "sdp", "Terms", "doc-1", "https://example.com/terms")
+		_, _, err := dpxService.CreateDraftContractTermsDocument(options)
+		Expect(err).To(BeNil())
+		Expect(output.Len()).To(BeZero())
+
+		_, err = dpxService.DeleteDataProductDraft(dpxService.NewDeleteDataProductDraftOptions("product-1", "draft-1"))
+		Expect(err).ToNot(BeNil())
+
+		logged := records()
+		Expect(logged).To(HaveLen(1))
+		record := logged[0]
+		Expect(record["level"]).To(Equal("ERROR"))
+		Expect(record["msg"]).To(Equal("DPX operation failed"))
+		Expect(record[dpxv1.LogKeyOperation]).To(Equal("DeleteDataProductDraft"))
+		Expect(record[dpxv1.LogKeyStatus]).To(Equal(float64(404)))
+		Expect(record[dpxv1.LogKeyErrorCode]).To(Equal("does_not_exist"))
+		Expect(record[dpxv1.LogKeyTrace]).To(Equal("trace-123"))
+		Expect(record[dpxv1.LogKeyError]).To(Equal("Draft not found"))
+		Expect(record).ToNot(HaveKey(dpxv1.LogKeyRequestHeaders))
+
+		dpxService.SetLogger(nil, nil)
+		Expect(dpxService.GetLogger()).To(BeNil())
+	})
+})
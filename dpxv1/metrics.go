@@ -0,0 +1,501 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// DefaultMetricsNamespace is the prefix of the names of the metrics recorded by a MetricsCollector.
+const DefaultMetricsNamespace = "dpx_client"
+
+// Names of the metrics recorded by a MetricsCollector, without the namespace prefix.
+const (
+	MetricRequestsTotal   = "requests_total"
+	MetricErrorsTotal     = "errors_total"
+	MetricRetriesTotal    = "retries_total"
+	MetricRequestDuration = "request_duration_seconds"
+	MetricResponseSize    = "response_size_bytes"
+)
+
+// MetricStatusDryRun is the status label of the operations whose request was not sent in dry-run mode (see
+// SetDryRun). These operations are not counted as errors, and their duration is not observed.
+const MetricStatusDryRun = "dry_run"
+
+// Names of the labels of the metrics recorded by a MetricsCollector.
+const (
+	MetricLabelOperation = "operation"
+	MetricLabelStatus    = "status"
+	MetricLabelErrorCode = "error_code"
+)
+
+const (
+	metricsContentType        = "text/plain; version=0.0.4; charset=utf-8"
+	metricsStatusNoResponse   = "0"
+	metricsHistogramBucketKey = "le"
+)
+
+// DefaultLatencyBuckets are the default upper bounds (in seconds) of the request duration histogram buckets.
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// DefaultResponseSizeBuckets are the default upper bounds (in bytes) of the response size histogram buckets.
+var DefaultResponseSizeBuckets = []float64{256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304}
+
+// MetricType : The type of a metric family.
+type MetricType string
+
+// Constants associated with the MetricType.
+const (
+	MetricTypeCounter   MetricType = "counter"
+	MetricTypeHistogram MetricType = "histogram"
+)
+
+// MetricsRegistry is implemented by adapters that forward the observations of a MetricsCollector to another
+// metrics library (e.g. a Prometheus, StatsD or go-metrics registry). The metric names passed to the registry
+// include the collector's namespace.
+type MetricsRegistry interface {
+	// AddCounter adds "value" to the counter identified by "name" and "labels".
+	AddCounter(name string, labels map[string]string, value float64)
+
+	// ObserveHistogram records "value" in the histogram identified by "name" and "labels".
+	ObserveHistogram(name string, labels map[string]string, value float64)
+}
+
+// MetricsOptions : Options for a MetricsCollector.
+type MetricsOptions struct {
+	// The prefix of the metric names. Defaults to DefaultMetricsNamespace.
+	Namespace string
+
+	// The upper bounds (in seconds) of the request duration histogram buckets. Defaults to DefaultLatencyBuckets.
+	LatencyBuckets []float64
+
+	// The upper bounds (in bytes) of the response size histogram buckets. Defaults to DefaultResponseSizeBuckets.
+	ResponseSizeBuckets []float64
+
+	// An optional registry to which every observation is also forwarded.
+	Registry MetricsRegistry
+}
+
+// MetricFamily : A snapshot of all of the time series of one metric, in the style of the Prometheus data model.
+type MetricFamily struct {
+	// The name of the metric, including the namespace.
+	Name string
+
+	// A description of the metric.
+	Help string
+
+	// The type of the metric.
+	Type MetricType
+
+	// The time series of the metric, one per distinct set of label values.
+	Metrics []Metric
+}
+
+// Metric : A snapshot of a single time series.
+type Metric struct {
+	// The labels that identify the time series.
+	Labels map[string]string
+
+	// The value of a counter.
+	Value float64
+
+	// The value of a histogram, or nil for a counter.
+	Histogram *HistogramValue
+}
+
+// HistogramValue : A snapshot of a histogram.
+type HistogramValue struct {
+	// The number of observations.
+	Count uint64
+
+	// The sum of all observations.
+	Sum float64
+
+	// The cumulative bucket counts, in order of increasing upper bound (the "+Inf" bucket is implied by Count).
+	Buckets []HistogramBucket
+}
+
+// HistogramBucket : A cumulative histogram bucket.
+type HistogramBucket struct {
+	// The inclusive upper bound of the bucket.
+	UpperBound float64
+
+	// The number of observations less than or equal to UpperBound.
+	CumulativeCount uint64
+}
+
+// MetricsCollector records per-operation request counts, latencies, retries, response sizes and errors
+// for the DpxV1 instances on which it is enabled (see DpxV1.EnableMetrics).
+//
+// The recorded metrics can be scraped in the Prometheus text exposition format (MetricsCollector is an
+// http.Handler), read as a snapshot with Collect(), or forwarded to another metrics library through a
+// MetricsRegistry. A MetricsCollector is safe for concurrent use and may be shared by several DpxV1 instances.
+type MetricsCollector struct {
+	namespace           string
+	latencyBuckets      []float64
+	responseSizeBuckets []float64
+	registry            MetricsRegistry
+
+	mu         sync.Mutex
+	operations map[string]*operationMetrics
+}
+
+// operationMetrics holds the metrics recorded for a single operation.
+type operationMetrics struct {
+	requests     map[string]float64    // by status
+	errors       map[[2]string]float64 // by status and error code
+	retries      float64
+	latency      *histogram
+	responseSize *histogram
+}
+
+// histogram counts observations in buckets with fixed upper bounds.
+type histogram struct {
+	upperBounds []float64
+	counts      []uint64 // non-cumulative, one per upper bound
+	count       uint64
+	sum         float64
+}
+
+// NewMetricsCollector returns a new MetricsCollector configured with the specified options.
+// Specify nil options to use the defaults.
+func NewMetricsCollector(options *MetricsOptions) *MetricsCollector {
+	if options == nil {
+		options = &MetricsOptions{}
+	}
+	collector := &MetricsCollector{
+		namespace:           options.Namespace,
+		latencyBuckets:      sortedBuckets(options.LatencyBuckets, DefaultLatencyBuckets),
+		responseSizeBuckets: sortedBuckets(options.ResponseSizeBuckets, DefaultResponseSizeBuckets),
+		registry:            options.Registry,
+		operations:          make(map[string]*operationMetrics),
+	}
+	if collector.namespace == "" {
+		collector.namespace = DefaultMetricsNamespace
+	}
+	return collector
+}
+
+// EnableMetrics records metrics for every operation of this service instance in "collector".
+func (dpx *DpxV1) EnableMetrics(collector *MetricsCollector) {
+	dpx.metrics = collector
+}
+
+// DisableMetrics stops recording metrics for this service instance.
+func (dpx *DpxV1) DisableMetrics() {
+	dpx.metrics = nil
+}
+
+// GetMetricsCollector returns the MetricsCollector in which metrics are recorded for this service instance, or nil.
+func (dpx *DpxV1) GetMetricsCollector() *MetricsCollector {
+	return dpx.metrics
+}
+
+// Reset discards all recorded metrics.
+func (collector *MetricsCollector) Reset() {
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	collector.operations = make(map[string]*operationMetrics)
+}
+
+// observe records the outcome of one invocation of the operation identified by "operationID".
+func (collector *MetricsCollector) observe(operationID string, response *core.DetailedResponse, err error, duration time.Duration, retries int) {
+	status := metricsStatusNoResponse
+	if response != nil {
+		status = strconv.Itoa(response.StatusCode)
+	}
+	dryRun := isDryRunError(err)
+	if dryRun {
+		status, err = MetricStatusDryRun, nil
+	}
+	errorCode := ""
+	if err != nil {
+		errorCode = getResponseErrorCode(response)
+	}
+	responseSize := getResponseSize(response)
+
+	collector.mu.Lock()
+	metrics := collector.operations[operationID]
+	if metrics == nil {
+		metrics = &operationMetrics{
+			requests:     make(map[string]float64),
+			errors:       make(map[[2]string]float64),
+			latency:      newHistogram(collector.latencyBuckets),
+			responseSize: newHistogram(collector.responseSizeBuckets),
+		}
+		collector.operations[operationID] = metrics
+	}
+	metrics.requests[status]++
+	if err != nil {
+		metrics.errors[[2]string{status, errorCode}]++
+	}
+	metrics.retries += float64(retries)
+	if !dryRun {
+		metrics.latency.observe(duration.Seconds())
+	}
+	if responseSize >= 0 {
+		metrics.responseSize.observe(float64(responseSize))
+	}
+	collector.mu.Unlock()
+
+	if collector.registry != nil {
+		labels := map[string]string{MetricLabelOperation: operationID}
+		collector.registry.AddCounter(collector.namespace+"_"+MetricRequestsTotal,
+			map[string]string{MetricLabelOperation: operationID, MetricLabelStatus: status}, 1)
+		if err != nil {
+			collector.registry.AddCounter(collector.namespace+"_"+MetricErrorsTotal,
+				map[string]string{MetricLabelOperation: operationID, MetricLabelStatus: status, MetricLabelErrorCode: errorCode}, 1)
+		}
+		if retries > 0 {
+			collector.registry.AddCounter(collector.namespace+"_"+MetricRetriesTotal, labels, float64(retries))
+		}
+		if !dryRun {
+			collector.registry.ObserveHistogram(collector.namespace+"_"+MetricRequestDuration, labels, duration.Seconds())
+		}
+		if responseSize >= 0 {
+			collector.registry.ObserveHistogram(collector.namespace+"_"+MetricResponseSize, labels, float64(responseSize))
+		}
+	}
+}
+
+// Collect returns a snapshot of the recorded metrics, sorted by name and label values.
+func (collector *MetricsCollector) Collect() []MetricFamily {
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	operationIDs := make([]string, 0, len(collector.operations))
+	for operationID := range collector.operations {
+		operationIDs = append(operationIDs, operationID)
+	}
+	sort.Strings(operationIDs)
+
+	requests := MetricFamily{
+		Name: collector.namespace + "_" + MetricRequestsTotal,
+		Help: "Number of DPX operations invoked, by operation and HTTP status (0 if no response was received, dry_run if the request was not sent).",
+		Type: MetricTypeCounter,
+	}
+	errors := MetricFamily{
+		Name: collector.namespace + "_" + MetricErrorsTotal,
+		Help: "Number of DPX operations that failed, by operation, HTTP status and error code.",
+		Type: MetricTypeCounter,
+	}
+	retries := MetricFamily{
+		Name: collector.namespace + "_" + MetricRetriesTotal,
+		Help: "Number of times the requests of DPX operations were re-sent, by operation.",
+		Type: MetricTypeCounter,
+	}
+	latency := MetricFamily{
+		Name: collector.namespace + "_" + MetricRequestDuration,
+		Help: "Duration of DPX operations in seconds, including retries, by operation.",
+		Type: MetricTypeHistogram,
+	}
+	responseSize := MetricFamily{
+		Name: collector.namespace + "_" + MetricResponseSize,
+		Help: "Size of the responses to DPX operations in bytes, by operation.",
+		Type: MetricTypeHistogram,
+	}
+
+	for _, operationID := range operationIDs {
+		metrics := collector.operations[operationID]
+
+		statuses := make([]string, 0, len(metrics.requests))
+		for status := range metrics.requests {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			requests.Metrics = append(requests.Metrics, Metric{
+				Labels: map[string]string{MetricLabelOperation: operationID, MetricLabelStatus: status},
+				Value:  metrics.requests[status],
+			})
+		}
+
+		errorKeys := make([][2]string, 0, len(metrics.errors))
+		for key := range metrics.errors {
+			errorKeys = append(errorKeys, key)
+		}
+		sort.Slice(errorKeys, func(i, j int) bool {
+			if errorKeys[i][0] != errorKeys[j][0] {
+				return errorKeys[i][0] < errorKeys[j][0]
+			}
+			return errorKeys[i][1] < errorKeys[j][1]
+		})
+		for _, key := range errorKeys {
+			errors.Metrics = append(errors.Metrics, Metric{
+				Labels: map[string]string{MetricLabelOperation: operationID, MetricLabelStatus: key[0], MetricLabelErrorCode: key[1]},
+				Value:  metrics.errors[key],
+			})
+		}
+
+		labels := map[string]string{MetricLabelOperation: operationID}
+		retries.Metrics = append(retries.Metrics, Metric{Labels: labels, Value: metrics.retries})
+		latency.Metrics = append(latency.Metrics, Metric{Labels: labels, Histogram: metrics.latency.snapshot()})
+		responseSize.Metrics = append(responseSize.Metrics, Metric{Labels: labels, Histogram: metrics.responseSize.snapshot()})
+	}
+
+	return []MetricFamily{errors, latency, requests, responseSize, retries}
+}
+
+// WritePrometheus writes the recorded metrics to "writer" in the Prometheus text exposition format.
+func (collector *MetricsCollector) WritePrometheus(writer io.Writer) error {
+	buffered := bufio.NewWriter(writer)
+	for _, family := range collector.Collect() {
+		fmt.Fprintf(buffered, "# HELP %s %s\n", family.Name, family.Help)
+		fmt.Fprintf(buffered, "# TYPE %s %s\n", family.Name, family.Type)
+		for _, metric := range family.Metrics {
+			if metric.Histogram == nil {
+				fmt.Fprintf(buffered, "%s%s %s\n", family.Name, formatLabels(metric.Labels, ""), formatFloat(metric.Value))
+				continue
+			}
+			for _, bucket := range metric.Histogram.Buckets {
+				fmt.Fprintf(buffered, "%s_bucket%s %d\n", family.Name,
+					formatLabels(metric.Labels, formatFloat(bucket.UpperBound)), bucket.CumulativeCount)
+			}
+			fmt.Fprintf(buffered, "%s_bucket%s %d\n", family.Name, formatLabels(metric.Labels, "+Inf"), metric.Histogram.Count)
+			fmt.Fprintf(buffered, "%s_sum%s %s\n", family.Name, formatLabels(metric.Labels, ""), formatFloat(metric.Histogram.Sum))
+			fmt.Fprintf(buffered, "%s_count%s %d\n", family.Name, formatLabels(metric.Labels, ""), metric.Histogram.Count)
+		}
+	}
+	return buffered.Flush()
+}
+
+// ServeHTTP implements http.Handler so that the collector can be scraped by Prometheus (e.g. at "/metrics").
+func (collector *MetricsCollector) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", metricsContentType)
+	_ = collector.WritePrometheus(res)
+}
+
+// metricsInterceptor records the metrics of each operation in a MetricsCollector.
+type metricsInterceptor struct {
+	collector *MetricsCollector
+}
+
+// Intercept implements the Interceptor interface.
+func (interceptor metricsInterceptor) Intercept(operation *Operation, request *http.Request, next Invoker) (*core.DetailedResponse, error) {
+	start := time.Now()
+	response, err := next(request)
+	duration := time.Since(start)
+
+	retries := 0
+	if stats := getCallStats(request.Context()); stats != nil {
+		retries = stats.getRetries()
+	}
+	interceptor.collector.observe(operation.ID, response, err, duration, retries)
+	return response, err
+}
+
+func newHistogram(upperBounds []float64) *histogram {
+	return &histogram{
+		upperBounds: upperBounds,
+		counts:      make([]uint64, len(upperBounds)),
+	}
+}
+
+func (h *histogram) observe(value float64) {
+	h.count++
+	h.sum += value
+	if i := sort.SearchFloat64s(h.upperBounds, value); i < len(h.upperBounds) {
+		h.counts[i]++
+	}
+}
+
+func (h *histogram) snapshot() *HistogramValue {
+	value := &HistogramValue{
+		Count:   h.count,
+		Sum:     h.sum,
+		Buckets: make([]HistogramBucket, len(h.upperBounds)),
+	}
+	var cumulativeCount uint64
+	for i, upperBound := range h.upperBounds {
+		cumulativeCount += h.counts[i]
+		value.Buckets[i] = HistogramBucket{UpperBound: upperBound, CumulativeCount: cumulativeCount}
+	}
+	return value
+}
+
+// sortedBuckets returns a sorted copy of "buckets", or of "defaults" if "buckets" is empty.
+func sortedBuckets(buckets []float64, defaults []float64) []float64 {
+	if len(buckets) == 0 {
+		buckets = defaults
+	}
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return sorted
+}
+
+// getResponseSize returns the size of the response body in bytes, or -1 if it is not known.
+// The size is taken from the Content-Length header, or from the raw result of a non-JSON response.
+func getResponseSize(response *core.DetailedResponse) int64 {
+	if response == nil {
+		return -1
+	}
+	if contentLength := response.Headers.Get("Content-Length"); contentLength != "" {
+		if size, err := strconv.ParseInt(contentLength, 10, 64); err == nil {
+			return size
+		}
+	}
+	if response.RawResult != nil {
+		return int64(len(response.RawResult))
+	}
+	return -1
+}
+
+// formatLabels formats "labels" (plus the "le" label of a histogram bucket, if not empty) in the
+// Prometheus text exposition format.
+func formatLabels(labels map[string]string, upperBound string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names)+1)
+	for _, name := range names {
+		pairs = append(pairs, name+`="`+escapeLabelValue(labels[name])+`"`)
+	}
+	if upperBound != "" {
+		pairs = append(pairs, metricsHistogramBucketKey+`="`+upperBound+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,185 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testMetricsRegistry is a MetricsRegistry that records the observations forwarded to it.
+type testMetricsRegistry struct {
+	mu           sync.Mutex
+	counters     map[string]float64
+	observations map[string]int
+}
+
+func (registry *testMetricsRegistry) AddCounter(name string, labels map[string]string, value float64) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.counters[name+"/"+labels[dpxv1.MetricLabelOperation]] += value
+}
+
+func (registry *testMetricsRegistry) ObserveHistogram(name string, labels map[string]string, value float64) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.observations[name+"/"+labels[dpxv1.MetricLabelOperation]]++
+}
+
+var _ = Describe(`Metrics`, func() {
+	const releaseBody = `{"version": "1.0.0", "state": "available", "id": "release-1", "name": "My Data Product"}`
+	var testServer *httptest.Server
+	var requestCount int32
+	var dpxService *dpxv1.DpxV1
+
+	BeforeEach(func() {
+		atomic.StoreInt32(&requestCount, 0)
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Set("Content-type", "application/json")
+			switch {
+			case req.Method == http.MethodDelete:
+				res.WriteHeader(404)
+				fmt.Fprint(res, `{"errors": [{"code": "does_not_exist", "message": "Draft not found"}]}`)
+			case atomic.AddInt32(&requestCount, 1) == 1:
+				res.Header().Set("Retry-After", "0")
+				res.WriteHeader(503)
+				fmt.Fprint(res, `{"errors": [{"code": "unable_to_perform", "message": "Try again"}]}`)
+			default:
+				res.WriteHeader(200)
+				fmt.Fprint(res, releaseBody)
+			}
+		}))
+		var serviceErr error
+		dpxService, serviceErr = dpxv1.NewDpxV1(&dpxv1.DpxV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(serviceErr).To(BeNil())
+		dpxService.EnableRetries(2, 10*time.Millisecond)
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	findMetric := func(families []dpxv1.MetricFamily, name string, labels map[string]string) *dpxv1.Metric {
+		for _, family := range families {
+			if family.Name != name {
+				continue
+			}
+			for i := range family.Metrics {
+				if reflect.DeepEqual(family.Metrics[i].Labels, labels) {
+					return &family.Metrics[i]
+				}
+			}
+		}
+		return nil
+	}
+
+	It(`Records requests, errors, retries, latency and response size per operation`, func() {
+		collector := dpxv1.NewMetricsCollector(nil)
+		dpxService.EnableMetrics(collector)
+		Expect(dpxService.GetMetricsCollector()).To(Equal(collector))
+
+		_, _, err := dpxService.GetDataProductRelease(dpxService.NewGetDataProductReleaseOptions("product-1", "release-1"))
+		Expect(err).To(BeNil())
+		_, err = dpxService.DeleteDataProductDraft(dpxService.NewDeleteDataProductDraftOptions("product-1", "draft-1"))
+		Expect(err).ToNot(BeNil())
+
+		families := collector.Collect()
+		Expect(families).To(HaveLen(5))
+
+		requests := findMetric(families, "dpx_client_requests_total", map[string]string{"operation": "GetDataProductRelease", "status": "200"})
+		Expect(requests).ToNot(BeNil())
+		Expect(requests.Value).To(Equal(float64(1)))
+
+		errors := findMetric(families, "dpx_client_errors_total", map[string]string{"operation": "DeleteDataProductDraft", "status": "404", "error_code": "does_not_exist"})
+		Expect(errors).ToNot(BeNil())
+		Expect(errors.Value).To(Equal(float64(1)))
+		Expect(findMetric(families, "dpx_client_errors_total", map[string]string{"operation": "GetDataProductRelease", "status": "200", "error_code": ""})).To(BeNil())
+
+		retries := findMetric(families, "dpx_client_retries_total", map[string]string{"operation": "GetDataProductRelease"})
+		Expect(retries.Value).To(Equal(float64(1)))
+
+		latency := findMetric(families, "dpx_client_request_duration_seconds", map[string]string{"operation": "GetDataProductRelease"})
+		Expect(latency.Histogram.Count).To(Equal(uint64(1)))
+		Expect(latency.Histogram.Buckets).To(HaveLen(len(dpxv1.DefaultLatencyBuckets)))
+
+		responseSize := findMetric(families, "dpx_client_response_size_bytes", map[string]string{"operation": "GetDataProductRelease"})
+		Expect(responseSize.Histogram.Count).To(Equal(uint64(1)))
+		Expect(responseSize.Histogram.Sum).To(Equal(float64(len(releaseBody))))
+		Expect(responseSize.Histogram.Buckets[0].CumulativeCount).To(Equal(uint64(1)))
+	})
+	It(`Can be scraped in the Prometheus text format`, func() {
+		collector := dpxv1.NewMetricsCollector(&dpxv1.MetricsOptions{
+			Namespace:      "sre",
+			LatencyBuckets: []float64{60, 1},
+		})
+		dpxService.EnableMetrics(collector)
+		_, err := dpxService.DeleteDataProductDraft(dpxService.NewDeleteDataProductDraftOptions("product-1", "draft-1"))
+		Expect(err).ToNot(BeNil())
+
+		metricsServer := httptest.NewServer(collector)
+		defer metricsServer.Close()
+		res, err := http.Get(metricsServer.URL)
+		Expect(err).To(BeNil())
+		defer res.Body.Close()
+		Expect(res.Header.Get("Content-Type")).To(HavePrefix("text/plain; version=0.0.4"))
+		body, _ := io.ReadAll(res.Body)
+
+		Expect(string(body)).To(ContainSubstring("# TYPE sre_requests_total counter\n"))
+		Expect(string(body)).To(ContainSubstring(`sre_requests_total{operation="DeleteDataProductDraft",status="404"} 1` + "\n"))
+		Expect(string(body)).To(ContainSubstring(`sre_errors_total{error_code="does_not_exist",operation="DeleteDataProductDraft",status="404"} 1` + "\n"))
+		Expect(string(body)).To(ContainSubstring("# TYPE sre_request_duration_seconds histogram\n"))
+		Expect(string(body)).To(ContainSubstring(`sre_request_duration_seconds_bucket{operation="DeleteDataProductDraft",le="1"} 1` + "\n"))
+		Expect(string(body)).To(ContainSubstring(`sre_request_duration_seconds_bucket{operation="DeleteDataProductDraft",le="60"} 1` + "\n"))
+		Expect(string(body)).To(ContainSubstring(`sre_request_duration_seconds_bucket{operation="DeleteDataProductDraft",le="+Inf"} 1` + "\n"))
+		Expect(string(body)).To(ContainSubstring(`sre_request_duration_seconds_count{operation="DeleteDataProductDraft"} 1` + "\n"))
+
+		collector.Reset()
+		Expect(collector.Collect()[0].Metrics).To(BeEmpty())
+	})
+	It(`Forwards observations to a registry`, func() {
+		registry := &testMetricsRegistry{counters: map[string]float64{}, observations: map[string]int{}}
+		dpxService.EnableMetrics(dpxv1.NewMetricsCollector(&dpxv1.MetricsOptions{Registry: registry}))
+
+		_, _, err := dpxService.GetDataProductRelease(dpxService.NewGetDataProductReleaseOptions("product-1", "release-1"))
+		Expect(err).To(BeNil())
+
+		Expect(registry.counters).To(Equal(map[string]float64{
+			"dpx_client_requests_total/GetDataProductRelease": 1,
+			"dpx_client_retries_total/GetDataProductRelease":  1,
+		}))
+		Expect(registry.observations).To(Equal(map[string]int{
+			"dpx_client_request_duration_seconds/GetDataProductRelease": 1,
+			"dpx_client_response_size_bytes/GetDataProductRelease":      1,
+		}))
+
+		dpxService.DisableMetrics()
+		Expect(dpxService.GetMetricsCollector()).To(BeNil())
+	})
+})
@@ -0,0 +1,316 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoInstanceForContainer is matched (via errors.Is) by the error returned when no instance of a MultiClient
+// serves a container.
+var ErrNoInstanceForContainer = errors.New("no instance serves the container")
+
+// MultiClientConfig : The instances of a MultiClient, declared in YAML or JSON, e.g.:
+//
+//	default: us-south
+//	instances:
+//	  - name: us-south
+//	    url: https://api.dataplatform.cloud.ibm.com
+//	    auth_type: iam
+//	    apikey: ...
+//	    containers: [catalog-1, catalog-2]
+//	  - name: on-prem
+//	    service_name: dpx_onprem
+//	    containers: [catalog-3]
+type MultiClientConfig struct {
+	// The name of the instance that serves the containers of no instance.
+	Default string `json:"default,omitempty" yaml:"default,omitempty"`
+
+	// The instances.
+	Instances []MultiClientInstanceConfig `json:"instances" yaml:"instances"`
+}
+
+// MultiClientInstanceConfig : An instance of a MultiClientConfig.
+type MultiClientInstanceConfig struct {
+	// The unique name of the instance.
+	Name string `json:"name" yaml:"name"`
+
+	// The service URL. Defaults to the external configuration of ServiceName, if any, or to DefaultServiceURL.
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+
+	// The service name whose external configuration (environment, credentials file) provides the URL and the
+	// credentials, when AuthType is not set (see NewDpxV1UsingExternalConfig).
+	ServiceName string `json:"service_name,omitempty" yaml:"service_name,omitempty"`
+
+	// The authentication type: iam, bearertoken or noauth (as in the external configuration).
+	AuthType string `json:"auth_type,omitempty" yaml:"auth_type,omitempty"`
+
+	// The API key (for iam).
+	APIKey string `json:"apikey,omitempty" yaml:"apikey,omitempty"`
+
+	// The URL of the token service (for iam). Defaults to the IBM Cloud IAM URL.
+	AuthURL string `json:"auth_url,omitempty" yaml:"auth_url,omitempty"`
+
+	// The bearer token (for bearertoken).
+	BearerToken string `json:"bearer_token,omitempty" yaml:"bearer_token,omitempty"`
+
+	// The IDs of the containers (catalogs) served by the instance.
+	Containers []string `json:"containers,omitempty" yaml:"containers,omitempty"`
+}
+
+// ParseMultiClientConfig parses a YAML or JSON MultiClient configuration. Unknown properties are rejected.
+func ParseMultiClientConfig(data []byte) (*MultiClientConfig, error) {
+	config := &MultiClientConfig{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err := decoder.Decode(config)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("error parsing multi-client configuration: %w", err)
+	}
+	return config, nil
+}
+
+// LoadMultiClientConfig reads a YAML or JSON MultiClient configuration file.
+func LoadMultiClientConfig(path string) (*MultiClientConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMultiClientConfig(data)
+}
+
+// MultiClient : A registry of named DpxV1 instances (e.g. one per catalog deployment), that routes calls by
+// container ID and runs aggregated listings across the instances. It is safe for concurrent use.
+type MultiClient struct {
+	mu          sync.RWMutex
+	instances   map[string]*DpxV1
+	containers  map[string]string // the name of the instance that serves each container
+	defaultName string
+}
+
+// MultiClientItem : An element of an aggregated listing, with the name of the instance that returned it.
+type MultiClientItem[T any] struct {
+	Instance string
+	Item     T
+}
+
+// NewMultiClient returns an empty MultiClient; add instances with Add.
+func NewMultiClient() *MultiClient {
+	return &MultiClient{
+		instances:  make(map[string]*DpxV1),
+		containers: make(map[string]string),
+	}
+}
+
+// NewMultiClientFromConfig returns a MultiClient with a DpxV1 instance for each instance of "config".
+func NewMultiClientFromConfig(config *MultiClientConfig) (*MultiClient, error) {
+	multiClient := NewMultiClient()
+	for _, instance := range config.Instances {
+		client, err := newDpxV1ForInstance(instance)
+		if err != nil {
+			return nil, fmt.Errorf("instance %q: %w", instance.Name, err)
+		}
+		err = multiClient.Add(instance.Name, client, instance.Containers...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.Default != "" {
+		err := multiClient.SetDefault(config.Default)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return multiClient, nil
+}
+
+// newDpxV1ForInstance returns a DpxV1 instance with the URL and credentials of a configured instance.
+func newDpxV1ForInstance(instance MultiClientInstanceConfig) (*DpxV1, error) {
+	var authenticator core.Authenticator
+	var err error
+	switch {
+	case instance.AuthType == "":
+		return NewDpxV1UsingExternalConfig(&DpxV1Options{ServiceName: instance.ServiceName, URL: instance.URL})
+	case strings.EqualFold(instance.AuthType, core.AUTHTYPE_IAM):
+		authenticator, err = core.NewIamAuthenticatorBuilder().SetApiKey(instance.APIKey).SetURL(instance.AuthURL).Build()
+	case strings.EqualFold(instance.AuthType, core.AUTHTYPE_BEARER_TOKEN):
+		authenticator, err = core.NewBearerTokenAuthenticator(instance.BearerToken)
+	case strings.EqualFold(instance.AuthType, core.AUTHTYPE_NOAUTH):
+		authenticator = &core.NoAuthAuthenticator{}
+	default:
+		err = fmt.Errorf("unsupported auth_type %q", instance.AuthType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewDpxV1(&DpxV1Options{URL: instance.URL, Authenticator: authenticator})
+}
+
+// Add registers a DpxV1 instance under a unique name, as the instance that serves the specified containers.
+func (multiClient *MultiClient) Add(name string, client *DpxV1, containerIDs ...string) error {
+	multiClient.mu.Lock()
+	defer multiClient.mu.Unlock()
+	if name == "" {
+		return errors.New("the name of an instance must not be empty")
+	}
+	if multiClient.instances[name] != nil {
+		return fmt.Errorf("duplicate instance %q", name)
+	}
+	for _, containerID := range containerIDs {
+		if other, ok := multiClient.containers[containerID]; ok {
+			return fmt.Errorf("container %q is served by instances %q and %q", containerID, other, name)
+		}
+	}
+	multiClient.instances[name] = client
+	for _, containerID := range containerIDs {
+		multiClient.containers[containerID] = name
+	}
+	return nil
+}
+
+// SetDefault sets the instance that serves the containers of no instance.
+func (multiClient *MultiClient) SetDefault(name string) error {
+	multiClient.mu.Lock()
+	defer multiClient.mu.Unlock()
+	if multiClient.instances[name] == nil {
+		return fmt.Errorf("unknown instance %q", name)
+	}
+	multiClient.defaultName = name
+	return nil
+}
+
+// Names returns the names of the instances, sorted.
+func (multiClient *MultiClient) Names() []string {
+	multiClient.mu.RLock()
+	defer multiClient.mu.RUnlock()
+	names := make([]string, 0, len(multiClient.instances))
+	for name := range multiClient.instances {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Instance returns the instance with the specified name, or nil.
+func (multiClient *MultiClient) Instance(name string) *DpxV1 {
+	multiClient.mu.RLock()
+	defer multiClient.mu.RUnlock()
+	return multiClient.instances[name]
+}
+
+// ForContainer returns the name of the instance that serves the container "containerID" (or of the default
+// instance), and the instance. The error matches ErrNoInstanceForContainer if there is none.
+func (multiClient *MultiClient) ForContainer(containerID string) (string, *DpxV1, error) {
+	multiClient.mu.RLock()
+	defer multiClient.mu.RUnlock()
+	name, ok := multiClient.containers[containerID]
+	if !ok {
+		name = multiClient.defaultName
+	}
+	if name == "" {
+		return "", nil, fmt.Errorf("%w %q", ErrNoInstanceForContainer, containerID)
+	}
+	return name, multiClient.instances[name], nil
+}
+
+// ForAsset returns the instance that serves the container of an asset (see ForContainer), e.g. to create
+// a data product or a draft.
+func (multiClient *MultiClient) ForAsset(asset *AssetReference) (string, *DpxV1, error) {
+	if asset == nil {
+		return multiClient.ForContainer("")
+	}
+	return multiClient.ForContainer(containerID(asset.Container))
+}
+
+// ListDataProducts lists the data products of every instance with the pagers, in the order of the instance
+// names. If the listing of an instance fails, the data products of the other instances are still returned,
+// with an error that joins the errors of the instances that failed.
+func (multiClient *MultiClient) ListDataProducts(ctx context.Context) ([]MultiClientItem[DataProductSummary], error) {
+	return multiClientList(ctx, multiClient, func(ctx context.Context, client *DpxV1) ([]DataProductSummary, error) {
+		pager, err := client.NewDataProductsPager(client.NewListDataProductsOptions())
+		if err != nil {
+			return nil, err
+		}
+		return pager.GetAllWithContext(ctx)
+	})
+}
+
+// ListDataProductReleases lists the releases in the specified states (every state if empty) of every data
+// product of every instance with the pagers, like ListDataProducts.
+func (multiClient *MultiClient) ListDataProductReleases(ctx context.Context, states []string) ([]MultiClientItem[DataProductVersionSummary], error) {
+	return multiClientList(ctx, multiClient, func(ctx context.Context, client *DpxV1) (releases []DataProductVersionSummary, err error) {
+		dataProductIDs, err := listDataProductIDs(ctx, client, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, dataProductID := range dataProductIDs {
+			options := client.NewListDataProductReleasesOptions(dataProductID)
+			if len(states) > 0 {
+				options.SetState(states)
+			}
+			pager, err := client.NewDataProductReleasesPager(options)
+			if err != nil {
+				return nil, err
+			}
+			page, err := pager.GetAllWithContext(ctx)
+			if err != nil {
+				return nil, err
+			}
+			releases = append(releases, page...)
+		}
+		return releases, nil
+	})
+}
+
+// multiClientList runs "list" on every instance concurrently and merges the results in the order of the
+// instance names.
+func multiClientList[T any](ctx context.Context, multiClient *MultiClient, list func(context.Context, *DpxV1) ([]T, error)) ([]MultiClientItem[T], error) {
+	names := multiClient.Names()
+	results := make([][]T, len(names))
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			results[i], errs[i] = list(ctx, multiClient.Instance(name))
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("instance %q: %w", name, errs[i])
+			}
+		}(i, name)
+	}
+	wg.Wait()
+
+	var items []MultiClientItem[T]
+	for i, name := range names {
+		for _, item := range results[i] {
+			items = append(items, MultiClientItem[T]{Instance: name, Item: item})
+		}
+	}
+	return items, errors.Join(errs...)
+}
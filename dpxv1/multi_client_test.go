@@ -0,0 +1,136 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`MultiClient`, func() {
+	var east, west *catalogServer
+	var multiClient *dpxv1.MultiClient
+
+	BeforeEach(func() {
+		east = newCatalogServer()
+		east.put(testVersion("product-1", "release-1", "available", "Sales"))
+		east.put(testVersion("product-1", "draft-1", "draft", "Sales"))
+		west = newCatalogServer()
+		west.put(testVersion("product-2", "release-2", "available", "Ledger"))
+		west.put(testVersion("product-3", "release-3", "retired", "Archive"))
+
+		config, err := dpxv1.ParseMultiClientConfig([]byte(fmt.Sprintf(`
+default: west
+instances:
+  - name: east
+    url: %s
+    auth_type: noauth
+    containers: [catalog-1, catalog-2]
+  - name: west
+    url: %s
+    auth_type: noAuth
+    containers: [catalog-3]
+`, east.URL, west.URL)))
+		Expect(err).To(BeNil())
+		multiClient, err = dpxv1.NewMultiClientFromConfig(config)
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		east.Close()
+		west.Close()
+	})
+
+	It(`Routes by container ID`, func() {
+		Expect(multiClient.Names()).To(Equal([]string{"east", "west"}))
+		name, client, err := multiClient.ForContainer("catalog-2")
+		Expect(err).To(BeNil())
+		Expect(name).To(Equal("east"))
+		Expect(client).To(BeIdenticalTo(multiClient.Instance("east")))
+		Expect(client.GetServiceURL()).To(Equal(east.URL))
+
+		name, _, err = multiClient.ForContainer("catalog-9")
+		Expect(err).To(BeNil())
+		Expect(name).To(Equal("west"))
+
+		release := testVersion("product-1", "release-1", "available", "Sales")
+		name, client, err = multiClient.ForAsset(release.Asset)
+		Expect(err).To(BeNil())
+		Expect(name).To(Equal("east"))
+		_, _, err = client.GetDataProductRelease(client.NewGetDataProductReleaseOptions("product-1", "release-1"))
+		Expect(err).To(BeNil())
+
+		withoutDefault := dpxv1.NewMultiClient()
+		Expect(withoutDefault.Add("east", multiClient.Instance("east"), "catalog-1")).To(Succeed())
+		_, _, err = withoutDefault.ForContainer("catalog-9")
+		Expect(errors.Is(err, dpxv1.ErrNoInstanceForContainer)).To(BeTrue())
+		Expect(withoutDefault.Add("east", multiClient.Instance("west"))).To(MatchError(`duplicate instance "east"`))
+		Expect(withoutDefault.Add("west", multiClient.Instance("west"), "catalog-1")).To(
+			MatchError(`container "catalog-1" is served by instances "east" and "west"`))
+		Expect(withoutDefault.SetDefault("west")).To(MatchError(`unknown instance "west"`))
+	})
+	It(`Merges the listings of every instance`, func() {
+		dataProducts, err := multiClient.ListDataProducts(context.Background())
+		Expect(err).To(BeNil())
+		Expect(dataProducts).To(HaveLen(3))
+		Expect(dataProducts[0].Instance).To(Equal("east"))
+		Expect(*dataProducts[0].Item.ID).To(Equal("product-1"))
+		Expect(dataProducts[2].Instance).To(Equal("west"))
+		Expect(*dataProducts[2].Item.ID).To(Equal("product-3"))
+
+		releases, err := multiClient.ListDataProductReleases(context.Background(), []string{"available"})
+		Expect(err).To(BeNil())
+		Expect(releases).To(HaveLen(2))
+		Expect(releases[1].Instance).To(Equal("west"))
+		Expect(*releases[1].Item.ID).To(Equal("release-2"))
+	})
+	It(`Returns the listings of the other instances when one fails`, func() {
+		west.Close()
+		dataProducts, err := multiClient.ListDataProducts(context.Background())
+		Expect(err).To(MatchError(ContainSubstring(`instance "west": `)))
+		Expect(dataProducts).To(HaveLen(1))
+		Expect(dataProducts[0].Instance).To(Equal("east"))
+	})
+	It(`Loads configurations and rejects invalid instances`, func() {
+		dir, err := os.MkdirTemp("", "dpx-multi-client")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "instances.json")
+		Expect(os.WriteFile(path, []byte(`{"instances": [{"name": "a", "url": "https://example.com", "auth_type": "bearerToken", "bearer_token": "t"}]}`), 0600)).To(Succeed())
+		config, err := dpxv1.LoadMultiClientConfig(path)
+		Expect(err).To(BeNil())
+		loaded, err := dpxv1.NewMultiClientFromConfig(config)
+		Expect(err).To(BeNil())
+		Expect(loaded.Names()).To(Equal([]string{"a"}))
+
+		_, err = dpxv1.NewMultiClientFromConfig(&dpxv1.MultiClientConfig{Instances: []dpxv1.MultiClientInstanceConfig{{Name: "a", AuthType: "basic"}}})
+		Expect(err).To(MatchError(`instance "a": unsupported auth_type "basic"`))
+		_, err = dpxv1.NewMultiClientFromConfig(&dpxv1.MultiClientConfig{
+			Default:   "b",
+			Instances: []dpxv1.MultiClientInstanceConfig{{Name: "a", URL: "https://example.com", AuthType: "noauth"}},
+		})
+		Expect(err).To(MatchError(`unknown instance "b"`))
+		_, err = dpxv1.ParseMultiClientConfig([]byte("instances:\n  - name: a\n    region: us-south\n"))
+		Expect(err).To(MatchError(ContainSubstring("field region not found")))
+	})
+})
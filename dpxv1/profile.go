@@ -0,0 +1,244 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The environment variables that select the profiles file and the profile of NewDpxV1FromProfile.
+const (
+	ProfilesFileEnvVar = "DPX_CONFIG_FILE"
+	ProfileEnvVar      = "DPX_PROFILE"
+)
+
+// DefaultProfileName is the name of the profile used when none is selected.
+const DefaultProfileName = "default"
+
+// ProfilesConfig : The contents of a profiles file (see NewDpxV1FromProfile), in YAML or JSON, e.g.:
+//
+//	default_profile: dev
+//	profiles:
+//	  dev:
+//	    url: https://dpx.dev.example.com
+//	    auth_type: iam
+//	    apikey_env: DPX_DEV_APIKEY
+//	    container_id: catalog-dev
+//	    max_retries: 3
+//	    timeout: 30s
+//	  prod:
+//	    region: us-south
+//	    service_name: dpx_prod
+//	    gzip: true
+type ProfilesConfig struct {
+	// The profile used when none is selected. Defaults to "default".
+	DefaultProfile string `json:"default_profile,omitempty" yaml:"default_profile,omitempty"`
+
+	// The profiles, by name.
+	Profiles map[string]*Profile `json:"profiles" yaml:"profiles"`
+}
+
+// Profile : A named configuration of a DpxV1 instance.
+type Profile struct {
+	// The service URL.
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+
+	// The region whose service URL is used if URL is not set (see GetServiceURLForRegion).
+	Region string `json:"region,omitempty" yaml:"region,omitempty"`
+
+	// The service name whose external configuration (environment, credentials file) provides the credentials,
+	// and the URL if neither URL nor Region is set, when AuthType is not set.
+	ServiceName string `json:"service_name,omitempty" yaml:"service_name,omitempty"`
+
+	// The authentication type: iam, bearertoken or noauth.
+	AuthType string `json:"auth_type,omitempty" yaml:"auth_type,omitempty"`
+
+	// The API key (for iam), or the environment variable that holds it.
+	APIKey    string `json:"apikey,omitempty" yaml:"apikey,omitempty"`
+	APIKeyEnv string `json:"apikey_env,omitempty" yaml:"apikey_env,omitempty"`
+
+	// The URL of the token service (for iam).
+	AuthURL string `json:"auth_url,omitempty" yaml:"auth_url,omitempty"`
+
+	// The bearer token (for bearertoken), or the environment variable that holds it.
+	BearerToken    string `json:"bearer_token,omitempty" yaml:"bearer_token,omitempty"`
+	BearerTokenEnv string `json:"bearer_token_env,omitempty" yaml:"bearer_token_env,omitempty"`
+
+	// The container ID sent by Initialize and GetInitializeStatus when the caller does not specify one
+	// (see SetDefaultContainerID).
+	ContainerID string `json:"container_id,omitempty" yaml:"container_id,omitempty"`
+
+	// If positive, retries are enabled with this maximum number of retries (see EnableRetries).
+	MaxRetries int `json:"max_retries,omitempty" yaml:"max_retries,omitempty"`
+
+	// The maximum interval between retries, e.g. "30s".
+	MaxRetryInterval time.Duration `json:"max_retry_interval,omitempty" yaml:"max_retry_interval,omitempty"`
+
+	// The timeout of the HTTP client, e.g. "1m".
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// Whether request bodies are compressed (see SetEnableGzipCompression). If not set, the setting of the
+	// external configuration (if any) is kept.
+	Gzip *bool `json:"gzip,omitempty" yaml:"gzip,omitempty"`
+
+	// If true, the certificate of the service is not verified. Use only for development.
+	DisableSSLVerification bool `json:"disable_ssl_verification,omitempty" yaml:"disable_ssl_verification,omitempty"`
+}
+
+// DefaultProfilesFile returns the path of the profiles file: the value of the DPX_CONFIG_FILE environment
+// variable, or ~/.dpx/config.yaml.
+func DefaultProfilesFile() string {
+	if path := os.Getenv(ProfilesFileEnvVar); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".dpx", "config.yaml")
+	}
+	return filepath.Join(home, ".dpx", "config.yaml")
+}
+
+// ParseProfilesConfig parses the YAML or JSON contents of a profiles file. Unknown properties are rejected.
+func ParseProfilesConfig(data []byte) (*ProfilesConfig, error) {
+	config := &ProfilesConfig{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err := decoder.Decode(config)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("error parsing profiles: %w", err)
+	}
+	return config, nil
+}
+
+// LoadProfilesConfig reads a profiles file.
+func LoadProfilesConfig(path string) (*ProfilesConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseProfilesConfig(data)
+}
+
+// Profile returns the profile "name", or the profile selected by the DPX_PROFILE environment variable if it is
+// set, or else the default profile if "name" is empty.
+func (config *ProfilesConfig) Profile(name string) (*Profile, error) {
+	if selected := os.Getenv(ProfileEnvVar); selected != "" {
+		name = selected
+	}
+	if name == "" {
+		name = config.DefaultProfile
+	}
+	if name == "" {
+		name = DefaultProfileName
+	}
+	profile := config.Profiles[name]
+	if profile == nil {
+		return nil, fmt.Errorf("profile %q not found", name)
+	}
+	return profile, nil
+}
+
+// NewDpxV1FromProfile : constructs an instance of DpxV1 from a profile of the profiles file (see
+// DefaultProfilesFile). The DPX_PROFILE environment variable overrides "name"; if both are empty, the default
+// profile of the file is used.
+func NewDpxV1FromProfile(name string) (*DpxV1, error) {
+	config, err := LoadProfilesConfig(DefaultProfilesFile())
+	if err != nil {
+		return nil, err
+	}
+	profile, err := config.Profile(name)
+	if err != nil {
+		return nil, err
+	}
+	return profile.NewDpxV1()
+}
+
+// NewDpxV1 : constructs an instance of DpxV1 configured by the profile.
+func (profile *Profile) NewDpxV1() (*DpxV1, error) {
+	instance := MultiClientInstanceConfig{
+		URL:         profile.URL,
+		ServiceName: profile.ServiceName,
+		AuthType:    profile.AuthType,
+		APIKey:      profile.APIKey,
+		AuthURL:     profile.AuthURL,
+		BearerToken: profile.BearerToken,
+	}
+	if profile.APIKeyEnv != "" {
+		instance.APIKey = os.Getenv(profile.APIKeyEnv)
+	}
+	if profile.BearerTokenEnv != "" {
+		instance.BearerToken = os.Getenv(profile.BearerTokenEnv)
+	}
+	if instance.URL == "" && profile.Region != "" {
+		serviceURL, err := GetServiceURLForRegion(profile.Region)
+		if err != nil {
+			return nil, err
+		}
+		instance.URL = serviceURL
+	}
+
+	dpx, err := newDpxV1ForInstance(instance)
+	if err != nil {
+		return nil, err
+	}
+	if profile.MaxRetries > 0 {
+		dpx.EnableRetries(profile.MaxRetries, profile.MaxRetryInterval)
+	}
+	if profile.Timeout > 0 {
+		dpx.Service.GetHTTPClient().Timeout = profile.Timeout
+	}
+	if profile.DisableSSLVerification {
+		dpx.Service.DisableSSLVerification()
+	}
+	if profile.Gzip != nil {
+		dpx.SetEnableGzipCompression(*profile.Gzip)
+	}
+	dpx.SetDefaultContainerID(profile.ContainerID)
+	return dpx, nil
+}
+
+// SetDefaultContainerID sets the container ID sent by the operations that accept one (Initialize and
+// GetInitializeStatus) when the caller does not specify it. An empty ID disables the default.
+func (dpx *DpxV1) SetDefaultContainerID(containerID string) {
+	dpx.defaultContainerID = containerID
+}
+
+// GetDefaultContainerID returns the default container ID (see SetDefaultContainerID).
+func (dpx *DpxV1) GetDefaultContainerID() string {
+	return dpx.defaultContainerID
+}
+
+// applyDefaultContainerID adds the default container ID to the query of a GetInitializeStatus request, unless
+// the caller specified it. Initialize adds it to its body.
+func (dpx *DpxV1) applyDefaultContainerID(operationID string, request *http.Request) {
+	if dpx.defaultContainerID == "" || operationID != "GetInitializeStatus" {
+		return
+	}
+	query := request.URL.Query()
+	if query.Get("container.id") == "" {
+		query.Set("container.id", dpx.defaultContainerID)
+		request.URL.RawQuery = query.Encode()
+	}
+}
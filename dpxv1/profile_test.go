@@ -0,0 +1,132 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Configuration profiles`, func() {
+	var server *catalogServer
+	var dir string
+	var environment map[string]string
+
+	BeforeEach(func() {
+		server = newCatalogServer()
+		var err error
+		dir, err = os.MkdirTemp("", "dpx-profiles")
+		Expect(err).To(BeNil())
+		path := filepath.Join(dir, "config.yaml")
+		Expect(os.WriteFile(path, []byte(fmt.Sprintf(`
+default_profile: dev
+profiles:
+  dev:
+    url: %s
+    auth_type: noauth
+    container_id: catalog-dev
+    max_retries: 2
+    max_retry_interval: 5s
+    timeout: 30s
+    gzip: true
+  prod:
+    region: eu-de
+    auth_type: bearertoken
+    bearer_token_env: DPX_TEST_PROFILE_TOKEN
+  external:
+    service_name: dpx_external
+`, server.URL)), 0600)).To(Succeed())
+		environment = map[string]string{
+			"DPX_CONFIG_FILE":          path,
+			"DPX_PROFILE":              "",
+			"DPX_TEST_PROFILE_TOKEN":   "token",
+			"DPX_EXTERNAL_URL":         server.URL,
+			"DPX_EXTERNAL_AUTH_TYPE":   "noauth",
+			"DPX_EXTERNAL_ENABLE_GZIP": "true",
+		}
+		SetTestEnvironment(environment)
+	})
+	AfterEach(func() {
+		ClearTestEnvironment(environment)
+		os.RemoveAll(dir)
+		server.Close()
+	})
+
+	It(`Constructs the default profile`, func() {
+		dpxService, err := dpxv1.NewDpxV1FromProfile("")
+		Expect(err).To(BeNil())
+		Expect(dpxService.GetServiceURL()).To(Equal(server.URL))
+		Expect(dpxService.GetEnableGzipCompression()).To(BeTrue())
+		Expect(dpxService.GetDefaultContainerID()).To(Equal("catalog-dev"))
+		Expect(dpxService.Service.GetHTTPClient().Timeout).To(Equal(30 * time.Second))
+
+		// The fake server does not implement the operation; the request is only logged.
+		_, _, _ = dpxService.GetInitializeStatus(dpxService.NewGetInitializeStatusOptions())
+		_, _, _ = dpxService.GetInitializeStatus(dpxService.NewGetInitializeStatusOptions().SetContainerID("catalog-1"))
+		Expect(server.requestLog()).To(ContainElements(
+			"GET /data_product_exchange/v1/configuration/initialize/status?container.id=catalog-dev",
+			"GET /data_product_exchange/v1/configuration/initialize/status?container.id=catalog-1",
+		))
+
+		dpxService.SetDryRun(true)
+		_, _, err = dpxService.Initialize(dpxService.NewInitializeOptions())
+		var dryRun *dpxv1.DryRunError
+		Expect(errors.As(err, &dryRun)).To(BeTrue())
+		Expect(string(dryRun.Request.Body)).To(Equal(`{"container":{"id":"catalog-dev"}}`))
+		_, _, err = dpxService.Initialize(dpxService.NewInitializeOptions().SetContainer(&dpxv1.ContainerReference{ID: core.StringPtr("catalog-1")}))
+		Expect(errors.As(err, &dryRun)).To(BeTrue())
+		Expect(string(dryRun.Request.Body)).To(Equal(`{"container":{"id":"catalog-1"}}`))
+	})
+	It(`Keeps the external configuration of the settings that a profile does not specify`, func() {
+		dpxService, err := dpxv1.NewDpxV1FromProfile("external")
+		Expect(err).To(BeNil())
+		Expect(dpxService.GetServiceURL()).To(Equal(server.URL))
+		Expect(dpxService.GetEnableGzipCompression()).To(BeTrue())
+	})
+	It(`Selects the profile by name or with DPX_PROFILE`, func() {
+		dpxService, err := dpxv1.NewDpxV1FromProfile("prod")
+		Expect(err).To(BeNil())
+		Expect(dpxService.GetServiceURL()).To(Equal(dpxv1.RegionURL_EuDe))
+		Expect(dpxService.Service.Options.Authenticator.AuthenticationType()).To(Equal(core.AUTHTYPE_BEARER_TOKEN))
+		Expect(dpxService.GetDefaultContainerID()).To(BeEmpty())
+
+		SetTestEnvironment(map[string]string{"DPX_PROFILE": "prod"})
+		dpxService, err = dpxv1.NewDpxV1FromProfile("dev")
+		Expect(err).To(BeNil())
+		Expect(dpxService.GetServiceURL()).To(Equal(dpxv1.RegionURL_EuDe))
+
+		SetTestEnvironment(map[string]string{"DPX_PROFILE": "test"})
+		_, err = dpxv1.NewDpxV1FromProfile("")
+		Expect(err).To(MatchError(`profile "test" not found`))
+	})
+	It(`Rejects invalid profiles files`, func() {
+		_, err := dpxv1.ParseProfilesConfig([]byte("profiles:\n  dev:\n    retries: 3\n"))
+		Expect(err).To(MatchError(ContainSubstring("field retries not found")))
+
+		SetTestEnvironment(map[string]string{"DPX_CONFIG_FILE": filepath.Join(dir, "missing.yaml")})
+		_, err = dpxv1.NewDpxV1FromProfile("dev")
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,291 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// DefaultRateLimiterMaxRetries is the default number of times a request that was rejected
+// with "too_many_requests" is re-sent once the rate limiter allows it.
+const DefaultRateLimiterMaxRetries = 3
+
+// Minimum fraction of the configured rate that a RateLimiter will slow down to
+// after repeated "too_many_requests" responses.
+const rateLimiterMinRateFactor = 1.0 / 16
+
+// RateLimiter is a token-bucket rate limiter used to throttle requests sent by a DpxV1 instance.
+//
+// A RateLimiter is safe for concurrent use, so a single instance can coordinate all goroutines sharing
+// a service client. Callers are blocked (rather than failed) until a token is available, and the limiter
+// adapts to the server: it slows down after "too_many_requests" responses, pauses until the time given in a
+// Retry-After or rate-limit reset header, and gradually returns to its configured rate after successful requests.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	// The configured rate (requests per second) and burst size.
+	limit float64
+	burst float64
+
+	// The current (possibly reduced) rate and the number of available tokens.
+	rate   float64
+	tokens float64
+	last   time.Time
+
+	// No tokens are handed out before this time.
+	pausedUntil time.Time
+
+	maxRetries int
+
+	// now returns the current time; replaced in tests.
+	now func() time.Time
+}
+
+// NewRateLimiter returns a RateLimiter that allows "rate" requests per second on average,
+// with bursts of up to "burst" requests.
+// If "rate" is 0 or less, requests are not throttled but the limiter still honors server back-off hints.
+// If "burst" is less than 1, a burst size of 1 is used.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if rate < 0 {
+		rate = 0
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	limiter := &RateLimiter{
+		limit:      rate,
+		burst:      float64(burst),
+		rate:       rate,
+		tokens:     float64(burst),
+		maxRetries: DefaultRateLimiterMaxRetries,
+		now:        time.Now,
+	}
+	limiter.last = limiter.now()
+	return limiter
+}
+
+// SetMaxRetries sets the number of times a request rejected with "too_many_requests" is re-sent
+// after waiting for the limiter. A value of 0 disables re-sending.
+func (limiter *RateLimiter) SetMaxRetries(maxRetries int) *RateLimiter {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	limiter.maxRetries = maxRetries
+	return limiter
+}
+
+// GetMaxRetries returns the number of times a throttled request is re-sent.
+func (limiter *RateLimiter) GetMaxRetries() int {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	return limiter.maxRetries
+}
+
+// GetRate returns the rate (requests per second) currently enforced by the limiter.
+// This is lower than the configured rate while the limiter is backing off.
+func (limiter *RateLimiter) GetRate() float64 {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	return limiter.rate
+}
+
+// Wait blocks until the limiter permits a request or "ctx" is done.
+// It returns the context's error if the context is cancelled or its deadline is exceeded first.
+func (limiter *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := limiter.reserve()
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Pause stops the limiter from permitting requests for the specified duration.
+// Pauses never shorten a pause that is already in effect.
+func (limiter *RateLimiter) Pause(d time.Duration) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	limiter.pauseUntil(limiter.now().Add(d))
+}
+
+// reserve takes a token if one is available and returns 0,
+// otherwise it returns how long the caller should wait before trying again.
+func (limiter *RateLimiter) reserve() time.Duration {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := limiter.now()
+	if now.Before(limiter.pausedUntil) {
+		return limiter.pausedUntil.Sub(now)
+	}
+	if limiter.rate <= 0 {
+		return 0
+	}
+
+	elapsed := now.Sub(limiter.last).Seconds()
+	if elapsed > 0 {
+		limiter.tokens = math.Min(limiter.burst, limiter.tokens+elapsed*limiter.rate)
+	}
+	limiter.last = now
+
+	if limiter.tokens >= 1 {
+		limiter.tokens--
+		return 0
+	}
+	return time.Duration((1 - limiter.tokens) / limiter.rate * float64(time.Second))
+}
+
+func (limiter *RateLimiter) pauseUntil(t time.Time) {
+	if t.After(limiter.pausedUntil) {
+		limiter.pausedUntil = t
+	}
+}
+
+// observe adapts the limiter to the response received for a request it permitted.
+func (limiter *RateLimiter) observe(response *core.DetailedResponse) {
+	if response == nil {
+		return
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := limiter.now()
+	throttled := isTooManyRequests(response)
+	if throttled {
+		// Multiplicative decrease: halve the rate, but never go below a fraction of the configured rate.
+		limiter.rate = math.Max(limiter.rate/2, limiter.limit*rateLimiterMinRateFactor)
+		limiter.tokens = 0
+	} else if response.StatusCode >= 200 && response.StatusCode < 300 && limiter.rate < limiter.limit {
+		// Additive increase back towards the configured rate.
+		limiter.rate = math.Min(limiter.limit, limiter.rate+limiter.limit/10)
+	}
+
+	if retryAfter, ok := parseRetryAfter(response.Headers, now); ok {
+		limiter.pauseUntil(now.Add(retryAfter))
+	} else if reset, ok := parseRateLimitReset(response.Headers, now); ok {
+		limiter.pauseUntil(now.Add(reset))
+	} else if throttled && limiter.rate > 0 {
+		// No hint from the server, so wait for at least one token at the reduced rate.
+		limiter.pauseUntil(now.Add(time.Duration(float64(time.Second) / limiter.rate)))
+	}
+}
+
+// parseRetryAfter returns the delay requested by a Retry-After header,
+// which holds either a number of seconds or an HTTP date.
+func parseRetryAfter(headers http.Header, now time.Time) (time.Duration, bool) {
+	value := headers.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// parseRateLimitReset returns the time until the rate-limit window resets when the server reports
+// that no requests remain in the current window. Both the "RateLimit-*" and "X-RateLimit-*"
+// header conventions are recognized; reset values may be a number of seconds or a Unix timestamp.
+func parseRateLimitReset(headers http.Header, now time.Time) (time.Duration, bool) {
+	for _, prefix := range []string{"RateLimit-", "X-RateLimit-"} {
+		remaining, err := strconv.ParseFloat(headers.Get(prefix+"Remaining"), 64)
+		if err != nil || remaining > 0 {
+			continue
+		}
+		reset, err := strconv.ParseFloat(headers.Get(prefix+"Reset"), 64)
+		if err != nil || reset < 0 {
+			continue
+		}
+		// Values this large can only be Unix timestamps rather than a number of seconds.
+		if reset > 1e9 {
+			d := time.Unix(int64(reset), 0).Sub(now)
+			if d < 0 {
+				d = 0
+			}
+			return d, true
+		}
+		return time.Duration(reset * float64(time.Second)), true
+	}
+	return 0, false
+}
+
+// SetRateLimiter sets the rate limiter applied to every request sent by this service instance.
+// The limiter may be shared with other service instances to enforce a common budget.
+// Specify nil to disable instance-wide rate limiting.
+func (dpx *DpxV1) SetRateLimiter(limiter *RateLimiter) {
+	dpx.rateLimiter = limiter
+}
+
+// GetRateLimiter returns the rate limiter applied to every request sent by this service instance.
+func (dpx *DpxV1) GetRateLimiter() *RateLimiter {
+	return dpx.rateLimiter
+}
+
+// SetOperationRateLimiter sets the rate limiter applied to the operation identified by "operationID"
+// (e.g. "PublishDataProductDraft"), in addition to any instance-wide rate limiter.
+// Specify nil to remove the operation's rate limiter.
+func (dpx *DpxV1) SetOperationRateLimiter(operationID string, limiter *RateLimiter) {
+	if limiter == nil {
+		delete(dpx.operationRateLimiters, operationID)
+		return
+	}
+	if dpx.operationRateLimiters == nil {
+		dpx.operationRateLimiters = make(map[string]*RateLimiter)
+	}
+	dpx.operationRateLimiters[operationID] = limiter
+}
+
+// GetOperationRateLimiter returns the rate limiter applied to the operation identified by "operationID".
+func (dpx *DpxV1) GetOperationRateLimiter(operationID string) *RateLimiter {
+	return dpx.operationRateLimiters[operationID]
+}
+
+// rateLimitersFor returns the rate limiters that apply to the specified operation,
+// with the most specific limiter first.
+func (dpx *DpxV1) rateLimitersFor(operationID string) (limiters []*RateLimiter) {
+	if limiter := dpx.operationRateLimiters[operationID]; limiter != nil {
+		limiters = append(limiters, limiter)
+	}
+	if dpx.rateLimiter != nil {
+		limiters = append(limiters, dpx.rateLimiter)
+	}
+	return
+}
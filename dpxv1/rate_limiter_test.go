@@ -0,0 +1,176 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`RateLimiter`, func() {
+	Describe(`Token bucket`, func() {
+		It(`Allows a burst and then throttles to the configured rate`, func() {
+			limiter := dpxv1.NewRateLimiter(20, 2)
+			start := time.Now()
+			for i := 0; i < 4; i++ {
+				Expect(limiter.Wait(context.Background())).To(Succeed())
+			}
+			// Two requests are part of the burst, the other two are spaced 50ms apart.
+			Expect(time.Since(start)).To(BeNumerically(">=", 90*time.Millisecond))
+		})
+		It(`Does not throttle when the rate is 0`, func() {
+			limiter := dpxv1.NewRateLimiter(0, 1)
+			start := time.Now()
+			for i := 0; i < 100; i++ {
+				Expect(limiter.Wait(context.Background())).To(Succeed())
+			}
+			Expect(time.Since(start)).To(BeNumerically("<", 50*time.Millisecond))
+		})
+		It(`Returns the context error while paused`, func() {
+			limiter := dpxv1.NewRateLimiter(0, 1)
+			limiter.Pause(time.Minute)
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			Expect(limiter.Wait(ctx)).To(Equal(context.DeadlineExceeded))
+		})
+		It(`Configures max retries`, func() {
+			limiter := dpxv1.NewRateLimiter(10, 1)
+			Expect(limiter.GetMaxRetries()).To(Equal(dpxv1.DefaultRateLimiterMaxRetries))
+			Expect(limiter.SetMaxRetries(-1).GetMaxRetries()).To(Equal(0))
+			Expect(limiter.GetRate()).To(Equal(10.0))
+		})
+	})
+
+	Describe(`Service integration`, func() {
+		var testServer *httptest.Server
+		var requestCount int32
+
+		newService := func() *dpxv1.DpxV1 {
+			dpxService, serviceErr := dpxv1.NewDpxV1(&dpxv1.DpxV1Options{
+				URL:           testServer.URL,
+				Authenticator: &core.NoAuthAuthenticator{},
+			})
+			Expect(serviceErr).To(BeNil())
+			return dpxService
+		}
+
+		AfterEach(func() {
+			testServer.Close()
+		})
+
+		Context(`Using mock server endpoint that throttles the first request`, func() {
+			BeforeEach(func() {
+				atomic.StoreInt32(&requestCount, 0)
+				testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+					defer GinkgoRecover()
+					res.Header().Set("Content-type", "application/json")
+					if atomic.AddInt32(&requestCount, 1) == 1 {
+						res.Header().Set("Retry-After", "0")
+						res.WriteHeader(429)
+						fmt.Fprint(res, `{"errors": [{"code": "too_many_requests", "message": "Too many requests"}], "trace": "abc"}`)
+						return
+					}
+					Expect(req.Header["User-Agent"]).To(HaveLen(1))
+					res.WriteHeader(200)
+					fmt.Fprint(res, `{"id": "testString", "container": {"id": "testString", "type": "catalog"}}`)
+				}))
+			})
+			It(`Fails without a rate limiter`, func() {
+				dpxService := newService()
+				_, response, operationErr := dpxService.GetDataProduct(dpxService.NewGetDataProductOptions("testString"))
+				Expect(operationErr).ToNot(BeNil())
+				Expect(response.StatusCode).To(Equal(429))
+				Expect(atomic.LoadInt32(&requestCount)).To(Equal(int32(1)))
+			})
+			It(`Waits and re-sends the request with an instance rate limiter`, func() {
+				dpxService := newService()
+				dpxService.SetRateLimiter(dpxv1.NewRateLimiter(100, 1))
+				result, response, operationErr := dpxService.GetDataProduct(dpxService.NewGetDataProductOptions("testString"))
+				Expect(operationErr).To(BeNil())
+				Expect(response.StatusCode).To(Equal(200))
+				Expect(*result.ID).To(Equal("testString"))
+				Expect(atomic.LoadInt32(&requestCount)).To(Equal(int32(2)))
+				Expect(dpxService.GetRateLimiter().GetRate()).To(BeNumerically("<=", 100))
+			})
+			It(`Re-sends the request body with an operation rate limiter`, func() {
+				dpxService := newService()
+				dpxService.SetOperationRateLimiter("CreateDataProduct", dpxv1.NewRateLimiter(100, 1))
+				Expect(dpxService.GetOperationRateLimiter("CreateDataProduct")).ToNot(BeNil())
+				Expect(dpxService.GetOperationRateLimiter("GetDataProduct")).To(BeNil())
+
+				createDataProductOptions := dpxService.NewCreateDataProductOptions([]dpxv1.DataProductVersionPrototype{
+					{Asset: &dpxv1.AssetReference{Container: &dpxv1.ContainerReference{ID: core.StringPtr("testString")}}},
+				})
+				_, response, operationErr := dpxService.CreateDataProduct(createDataProductOptions)
+				Expect(operationErr).To(BeNil())
+				Expect(response.StatusCode).To(Equal(200))
+				Expect(atomic.LoadInt32(&requestCount)).To(Equal(int32(2)))
+			})
+			It(`Does not re-send the request when retries are disabled`, func() {
+				dpxService := newService()
+				dpxService.SetRateLimiter(dpxv1.NewRateLimiter(100, 1).SetMaxRetries(0))
+				_, response, operationErr := dpxService.GetDataProduct(dpxService.NewGetDataProductOptions("testString"))
+				Expect(operationErr).ToNot(BeNil())
+				Expect(response.StatusCode).To(Equal(429))
+			})
+		})
+
+		Context(`Using mock server endpoint that exhausts the rate-limit window`, func() {
+			BeforeEach(func() {
+				testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+					res.Header().Set("Content-type", "application/json")
+					res.Header().Set("X-RateLimit-Remaining", "0")
+					res.Header().Set("X-RateLimit-Reset", "60")
+					res.WriteHeader(200)
+					fmt.Fprint(res, `{"id": "testString", "container": {"id": "testString", "type": "catalog"}}`)
+				}))
+			})
+			It(`Blocks subsequent callers until the context is done`, func() {
+				dpxService := newService()
+				dpxService.SetRateLimiter(dpxv1.NewRateLimiter(100, 1))
+				_, _, operationErr := dpxService.GetDataProduct(dpxService.NewGetDataProductOptions("testString"))
+				Expect(operationErr).To(BeNil())
+
+				ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+				defer cancel()
+				_, _, operationErr = dpxService.GetDataProductWithContext(ctx, dpxService.NewGetDataProductOptions("testString"))
+				Expect(operationErr).To(MatchError(context.DeadlineExceeded))
+			})
+		})
+	})
+
+	It(`Clones operation rate limiters independently`, func() {
+		dpxService, _ := dpxv1.NewDpxV1(&dpxv1.DpxV1Options{Authenticator: &core.NoAuthAuthenticator{}})
+		limiter := dpxv1.NewRateLimiter(1, 1)
+		dpxService.SetOperationRateLimiter("GetDataProduct", limiter)
+
+		clone := dpxService.Clone()
+		Expect(clone.GetOperationRateLimiter("GetDataProduct")).To(Equal(limiter))
+		clone.SetOperationRateLimiter("GetDataProduct", nil)
+		Expect(clone.GetOperationRateLimiter("GetDataProduct")).To(BeNil())
+		Expect(dpxService.GetOperationRateLimiter("GetDataProduct")).To(Equal(limiter))
+	})
+})
@@ -0,0 +1,126 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue replaces secrets (credentials, tokens and the signatures of pre-signed URLs)
+// in the requests and responses that are logged or recorded by the SDK.
+const RedactedValue = "[redacted]"
+
+// secretNamePattern matches the names of headers, query parameters and JSON properties whose values are secret.
+var secretNamePattern = regexp.MustCompile(`(?i)(authorization|cookie|api[-_]?key|password|passcode|secret|token|credential|signature|^sig$)`)
+
+// isSecretName returns true if the value of the header, query parameter or JSON property "name" is secret.
+func isSecretName(name string) bool {
+	return secretNamePattern.MatchString(name)
+}
+
+// redactHeaders returns a copy of "header" in which the values of secret headers are redacted.
+func redactHeaders(header http.Header) http.Header {
+	redacted := make(http.Header, len(header))
+	for name, values := range header {
+		if isSecretName(name) {
+			redacted[name] = []string{RedactedValue}
+			continue
+		}
+		redactedValues := make([]string, len(values))
+		for i, value := range values {
+			if name == "Location" || name == "Content-Location" {
+				value = redactURL(value)
+			}
+			redactedValues[i] = value
+		}
+		redacted[name] = redactedValues
+	}
+	return redacted
+}
+
+// redactURL returns "rawURL" with its password and the values of secret query parameters
+// (e.g. the signature and credential of a pre-signed URL) redacted.
+func redactURL(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		// Don't risk leaking a secret in a URL that can't be parsed.
+		if i := strings.IndexByte(rawURL, '?'); i >= 0 {
+			return rawURL[:i+1] + RedactedValue
+		}
+		return rawURL
+	}
+	if parsedURL.User != nil {
+		if _, hasPassword := parsedURL.User.Password(); hasPassword {
+			parsedURL.User = url.UserPassword(parsedURL.User.Username(), RedactedValue)
+		}
+	}
+	if parsedURL.RawQuery != "" {
+		query := parsedURL.Query()
+		for name := range query {
+			if isSecretName(name) {
+				query[name] = []string{RedactedValue}
+			}
+		}
+		parsedURL.RawQuery = query.Encode()
+	}
+	return parsedURL.String()
+}
+
+// redactJSON returns a copy of the JSON document "body" in which the values of secret properties and the
+// secrets in URLs are redacted. If "body" is not a JSON document, a placeholder is returned instead.
+func redactJSON(body []byte) []byte {
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return []byte(`"` + RedactedValue + `"`)
+	}
+	redacted, err := json.Marshal(redactJSONValue(document))
+	if err != nil {
+		return []byte(`"` + RedactedValue + `"`)
+	}
+	return redacted
+}
+
+// redactJSONValue redacts the secrets in a value produced by json.Unmarshal.
+func redactJSONValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for name, property := range value {
+			if isSecretName(name) {
+				value[name] = RedactedValue
+			} else {
+				value[name] = redactJSONValue(property)
+			}
+		}
+		return value
+	case []interface{}:
+		for i, element := range value {
+			value[i] = redactJSONValue(element)
+		}
+		return value
+	case string:
+		if strings.Contains(value, "://") {
+			return redactURL(value)
+		}
+		return value
+	default:
+		return value
+	}
+}
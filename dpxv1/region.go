@@ -0,0 +1,94 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The service URLs of the public IBM Cloud regions.
+const (
+	RegionURL_AuSyd   = "https://api.au-syd.dai.cloud.ibm.com"
+	RegionURL_CaTor   = "https://api.ca-tor.dai.cloud.ibm.com"
+	RegionURL_EuDe    = "https://api.eu-de.dataplatform.cloud.ibm.com"
+	RegionURL_EuGb    = "https://api.eu-gb.dataplatform.cloud.ibm.com"
+	RegionURL_JpTok   = "https://api.jp-tok.dataplatform.cloud.ibm.com"
+	RegionURL_UsSouth = "https://api.dataplatform.cloud.ibm.com"
+)
+
+// regionKey is the external configuration property (e.g. the DPX_REGION environment variable) that selects
+// the region of NewDpxV1UsingExternalConfig.
+const regionKey = "REGION"
+
+var (
+	regionURLsMutex sync.RWMutex
+	regionURLs      = map[string]string{
+		"au-syd":   RegionURL_AuSyd,
+		"ca-tor":   RegionURL_CaTor,
+		"eu-de":    RegionURL_EuDe,
+		"eu-gb":    RegionURL_EuGb,
+		"jp-tok":   RegionURL_JpTok,
+		"us-south": RegionURL_UsSouth,
+	}
+)
+
+// RegisterRegion adds or replaces a region of the registry used by GetServiceURLForRegion, e.g. for a private
+// endpoint or a Cloud Pak for Data deployment:
+//
+//	err := dpxv1.RegisterRegion("cpd-prod", "https://cpd-prod.example.com")
+//
+// Region names are case-insensitive.
+func RegisterRegion(region string, serviceURL string) error {
+	if region == "" {
+		return fmt.Errorf("the region name must not be empty")
+	}
+	parsedURL, err := url.Parse(serviceURL)
+	if err != nil || parsedURL.Scheme == "" || parsedURL.Host == "" {
+		return fmt.Errorf("invalid service URL %q for region %q", serviceURL, region)
+	}
+	regionURLsMutex.Lock()
+	defer regionURLsMutex.Unlock()
+	regionURLs[strings.ToLower(region)] = strings.TrimSuffix(serviceURL, "/")
+	return nil
+}
+
+// Regions returns the names of the regions of the registry, sorted.
+func Regions() []string {
+	regionURLsMutex.RLock()
+	defer regionURLsMutex.RUnlock()
+	regions := make([]string, 0, len(regionURLs))
+	for region := range regionURLs {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// lookupRegionURL returns the service URL of a region of the registry.
+func lookupRegionURL(region string) (string, error) {
+	regionURLsMutex.RLock()
+	defer regionURLsMutex.RUnlock()
+	serviceURL, ok := regionURLs[strings.ToLower(region)]
+	if !ok {
+		return "", fmt.Errorf("unknown region %q; known regions are registered with RegisterRegion", region)
+	}
+	return serviceURL, nil
+}
@@ -0,0 +1,90 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Region-aware service URLs`, func() {
+	It(`Resolves the public IBM Cloud regions and registered regions`, func() {
+		url, err := dpxv1.GetServiceURLForRegion("eu-de")
+		Expect(err).To(BeNil())
+		Expect(url).To(Equal(dpxv1.RegionURL_EuDe))
+		url, err = dpxv1.GetServiceURLForRegion("US-SOUTH")
+		Expect(err).To(BeNil())
+		Expect(url).To(Equal(dpxv1.RegionURL_UsSouth))
+		Expect(dpxv1.Regions()).To(ContainElements("au-syd", "ca-tor", "eu-de", "eu-gb", "jp-tok", "us-south"))
+
+		_, err = dpxv1.GetServiceURLForRegion("cpd-test")
+		Expect(err).To(MatchError(ContainSubstring(`unknown region "cpd-test"`)))
+		Expect(dpxv1.RegisterRegion("CPD-Test", "https://cpd-test.example.com/")).To(Succeed())
+		url, err = dpxv1.GetServiceURLForRegion("cpd-test")
+		Expect(err).To(BeNil())
+		Expect(url).To(Equal("https://cpd-test.example.com"))
+
+		Expect(dpxv1.RegisterRegion("", "https://example.com")).ToNot(Succeed())
+		Expect(dpxv1.RegisterRegion("bad", "example.com")).To(MatchError(`invalid service URL "example.com" for region "bad"`))
+	})
+	It(`Selects the region through DpxV1Options`, func() {
+		dpxService, err := dpxv1.NewDpxV1(&dpxv1.DpxV1Options{Region: "jp-tok", Authenticator: &core.NoAuthAuthenticator{}})
+		Expect(err).To(BeNil())
+		Expect(dpxService.GetServiceURL()).To(Equal(dpxv1.RegionURL_JpTok))
+
+		dpxService, err = dpxv1.NewDpxV1(&dpxv1.DpxV1Options{
+			URL:           "https://dpx.example.com",
+			Region:        "jp-tok",
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		Expect(dpxService.GetServiceURL()).To(Equal("https://dpx.example.com"))
+
+		Expect(dpxService.SetServiceURLForRegion("eu-gb")).To(Succeed())
+		Expect(dpxService.GetServiceURL()).To(Equal(dpxv1.RegionURL_EuGb))
+		Expect(dpxService.SetServiceURLForRegion("mars-1")).ToNot(Succeed())
+
+		_, err = dpxv1.NewDpxV1(&dpxv1.DpxV1Options{Region: "mars-1", Authenticator: &core.NoAuthAuthenticator{}})
+		Expect(err).To(MatchError(ContainSubstring(`unknown region "mars-1"`)))
+	})
+	It(`Resolves the URL from the DPX_REGION environment variable`, func() {
+		// Other tests may leave DPX_URL set.
+		environment := map[string]string{"DPX_AUTH_TYPE": "noauth", "DPX_REGION": "eu-de", "DPX_URL": ""}
+		ClearTestEnvironment(environment)
+		SetTestEnvironment(environment)
+		defer ClearTestEnvironment(environment)
+
+		dpxService, err := dpxv1.NewDpxV1UsingExternalConfig(&dpxv1.DpxV1Options{})
+		Expect(err).To(BeNil())
+		Expect(dpxService.GetServiceURL()).To(Equal(dpxv1.RegionURL_EuDe))
+
+		dpxService, err = dpxv1.NewDpxV1UsingExternalConfig(&dpxv1.DpxV1Options{Region: "ca-tor"})
+		Expect(err).To(BeNil())
+		Expect(dpxService.GetServiceURL()).To(Equal(dpxv1.RegionURL_CaTor))
+
+		SetTestEnvironment(map[string]string{"DPX_URL": "https://dpx.example.com"})
+		dpxService, err = dpxv1.NewDpxV1UsingExternalConfig(&dpxv1.DpxV1Options{})
+		Expect(err).To(BeNil())
+		Expect(dpxService.GetServiceURL()).To(Equal("https://dpx.example.com"))
+
+		SetTestEnvironment(map[string]string{"DPX_URL": "", "DPX_REGION": "mars-1"})
+		_, err = dpxv1.NewDpxV1UsingExternalConfig(&dpxv1.DpxV1Options{})
+		Expect(err).To(MatchError(ContainSubstring(`unknown region "mars-1"`)))
+	})
+})
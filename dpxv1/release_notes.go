@@ -0,0 +1,215 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReleaseNotes : The history of the releases of a data product, as a changelog (see GenerateReleaseNotes).
+type ReleaseNotes struct {
+	// The ID of the data product.
+	DataProductID string `json:"data_product_id"`
+
+	// The name of the latest release.
+	Name string `json:"name,omitempty"`
+
+	// The releases, newest first.
+	Entries []ReleaseNotesEntry `json:"entries"`
+}
+
+// ReleaseNotesEntry : A release of a data product, with what changed since the previous release.
+type ReleaseNotesEntry struct {
+	// The version of the release.
+	Version string `json:"version"`
+
+	// The ID of the release.
+	ReleaseID string `json:"release_id"`
+
+	// The state of the release.
+	State string `json:"state"`
+
+	// The user who published the release.
+	PublishedBy string `json:"published_by,omitempty"`
+
+	// The time when the release was published.
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+
+	// True if the release was retired.
+	Retired bool `json:"retired"`
+
+	// The version of the previous release, if any.
+	PreviousVersion string `json:"previous_version,omitempty"`
+
+	// The changes since the previous release (see CompareVersions). Empty for the first release.
+	Changes []VersionChange `json:"changes"`
+}
+
+// GenerateReleaseNotes walks the releases of a data product (in every state) in version order, and summarizes
+// what changed between consecutive releases (see CompareVersions), with the publisher, the publication date and
+// whether the release was retired. Render the result with Markdown or JSON.
+func GenerateReleaseNotes(ctx context.Context, client DpxV1API, dataProductID string) (*ReleaseNotes, error) {
+	pager, err := client.NewDataProductReleasesPager(client.NewListDataProductReleasesOptions(dataProductID))
+	if err != nil {
+		return nil, err
+	}
+	summaries, err := pager.GetAllWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]*DataProductVersion, 0, len(summaries))
+	for _, summary := range summaries {
+		release, _, err := client.GetDataProductReleaseWithContext(ctx,
+			client.NewGetDataProductReleaseOptions(dataProductID, stringValue(summary.ID)))
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		if c := compareVersionNumbers(stringValue(releases[i].Version), stringValue(releases[j].Version)); c != 0 {
+			return c < 0
+		}
+		return publishedBefore(releases[i], releases[j])
+	})
+
+	notes := &ReleaseNotes{DataProductID: dataProductID, Entries: make([]ReleaseNotesEntry, 0, len(releases))}
+	var previous *DataProductVersion
+	for _, release := range releases {
+		entry := ReleaseNotesEntry{
+			Version:     stringValue(release.Version),
+			ReleaseID:   stringValue(release.ID),
+			State:       stringValue(release.State),
+			PublishedBy: stringValue(release.PublishedBy),
+			Retired:     stringValue(release.State) == DataProductVersion_State_Retired,
+			Changes:     []VersionChange{},
+		}
+		if release.PublishedAt != nil {
+			publishedAt := time.Time(*release.PublishedAt)
+			entry.PublishedAt = &publishedAt
+		}
+		if previous != nil {
+			entry.PreviousVersion = stringValue(previous.Version)
+			entry.Changes = CompareVersions(previous, release).Changes
+		}
+		notes.Entries = append([]ReleaseNotesEntry{entry}, notes.Entries...)
+		notes.Name = stringValue(release.Name)
+		previous = release
+	}
+	return notes, nil
+}
+
+// JSON returns the release notes as indented JSON.
+func (notes *ReleaseNotes) JSON() ([]byte, error) {
+	return json.MarshalIndent(notes, "", "  ")
+}
+
+// Markdown returns the release notes as a Markdown changelog, newest release first.
+func (notes *ReleaseNotes) Markdown() string {
+	var builder strings.Builder
+	title := notes.Name
+	if title == "" {
+		title = notes.DataProductID
+	}
+	fmt.Fprintf(&builder, "# Changelog: %s\n", title)
+	if len(notes.Entries) == 0 {
+		builder.WriteString("\n_No releases._\n")
+	}
+	for _, entry := range notes.Entries {
+		fmt.Fprintf(&builder, "\n## %s", entry.Version)
+		if entry.PublishedAt != nil {
+			fmt.Fprintf(&builder, " (%s)", entry.PublishedAt.UTC().Format(time.DateOnly))
+		}
+		builder.WriteString("\n\n")
+		if entry.PublishedBy != "" {
+			fmt.Fprintf(&builder, "Published by %s.", entry.PublishedBy)
+			if entry.Retired {
+				builder.WriteString(" ")
+			}
+		}
+		if entry.Retired {
+			builder.WriteString("**Retired.**")
+		}
+		if entry.PublishedBy != "" || entry.Retired {
+			builder.WriteString("\n\n")
+		}
+		switch {
+		case entry.PreviousVersion == "":
+			builder.WriteString("Initial release.\n")
+		case len(entry.Changes) == 0:
+			fmt.Fprintf(&builder, "No changes since %s.\n", entry.PreviousVersion)
+		default:
+			for _, change := range entry.Changes {
+				fmt.Fprintf(&builder, "- %s\n", change)
+			}
+		}
+	}
+	return builder.String()
+}
+
+// compareVersionNumbers compares two versions such as "1.10.0" and "1.9.2" by their numeric components, a
+// pre-release (e.g. "2.0.0-beta") being older than the release. Components that are not numbers are compared
+// as strings.
+func compareVersionNumbers(a string, b string) int {
+	aVersion, aPreRelease, _ := strings.Cut(a, "-")
+	bVersion, bPreRelease, _ := strings.Cut(b, "-")
+	aParts, bParts := strings.Split(aVersion, "."), strings.Split(bVersion, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		aPart, bPart := "0", "0"
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+		aNumber, aErr := strconv.Atoi(aPart)
+		bNumber, bErr := strconv.Atoi(bPart)
+		switch {
+		case aErr == nil && bErr == nil && aNumber != bNumber:
+			if aNumber < bNumber {
+				return -1
+			}
+			return 1
+		case (aErr != nil || bErr != nil) && aPart != bPart:
+			return strings.Compare(aPart, bPart)
+		}
+	}
+	switch {
+	case aPreRelease == bPreRelease:
+		return 0
+	case aPreRelease == "":
+		return 1
+	case bPreRelease == "":
+		return -1
+	}
+	return strings.Compare(aPreRelease, bPreRelease)
+}
+
+// publishedBefore returns true if "a" was published before "b"; unpublished versions come last.
+func publishedBefore(a *DataProductVersion, b *DataProductVersion) bool {
+	if a.PublishedAt == nil || b.PublishedAt == nil {
+		return a.PublishedAt != nil
+	}
+	return time.Time(*a.PublishedAt).Before(time.Time(*b.PublishedAt))
+}
@@ -0,0 +1,119 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`GenerateReleaseNotes`, func() {
+	var server *catalogServer
+	var dpxService *dpxv1.DpxV1
+
+	// testReleaseVersion returns a release with the specified version, publisher and publication date.
+	testReleaseVersion := func(releaseID string, version string, state string, publishedAt string) *dpxv1.DataProductVersion {
+		release := testVersion("product-1", releaseID, state, "Sales")
+		release.Version = core.StringPtr(version)
+		release.PublishedBy = core.StringPtr("user-1")
+		dateTime, err := core.ParseDateTime(publishedAt)
+		Expect(err).To(BeNil())
+		release.PublishedAt = &dateTime
+		return release
+	}
+
+	BeforeEach(func() {
+		server = newCatalogServer()
+		dpxService = server.newService()
+		server.put(testReleaseVersion("release-a", "1.10.0", dpxv1.DataProductVersion_State_Available, "2024-03-01T00:00:00.000Z"))
+		first := testReleaseVersion("release-b", "1.2.0", dpxv1.DataProductVersion_State_Retired, "2024-01-15T00:00:00.000Z")
+		first.Tags = []string{"finance"}
+		server.put(first)
+		beta := testReleaseVersion("release-c", "1.10.0-beta", dpxv1.DataProductVersion_State_Retired, "2024-02-01T00:00:00.000Z")
+		beta.Name = core.StringPtr("Sales (beta)")
+		server.put(beta)
+		server.put(testVersion("product-1", "draft-1", dpxv1.DataProductVersion_State_Draft, "Draft"))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Summarizes the changes between consecutive releases as Markdown`, func() {
+		notes, err := dpxv1.GenerateReleaseNotes(context.Background(), dpxService, "product-1")
+		Expect(err).To(BeNil())
+		Expect(notes.Entries).To(HaveLen(3))
+		Expect(notes.Markdown()).To(Equal(`# Changelog: Sales
+
+## 1.10.0 (2024-03-01)
+
+Published by user-1.
+
+- name: "Sales (beta)" -> "Sales"
+
+## 1.10.0-beta (2024-02-01)
+
+Published by user-1. **Retired.**
+
+- name: "Sales" -> "Sales (beta)"
+- tags: removed "finance"
+
+## 1.2.0 (2024-01-15)
+
+Published by user-1. **Retired.**
+
+Initial release.
+`))
+	})
+	It(`Renders the release notes as JSON`, func() {
+		notes, err := dpxv1.GenerateReleaseNotes(context.Background(), dpxService, "product-1")
+		Expect(err).To(BeNil())
+		data, err := notes.JSON()
+		Expect(err).To(BeNil())
+
+		var decoded map[string]interface{}
+		Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+		Expect(decoded["data_product_id"]).To(Equal("product-1"))
+		entries := decoded["entries"].([]interface{})
+		Expect(entries[1]).To(Equal(map[string]interface{}{
+			"version":          "1.10.0-beta",
+			"release_id":       "release-c",
+			"state":            "retired",
+			"published_by":     "user-1",
+			"published_at":     "2024-02-01T00:00:00Z",
+			"retired":          true,
+			"previous_version": "1.2.0",
+			"changes": []interface{}{
+				map[string]interface{}{"field": "name", "kind": "changed", "old": `"Sales"`, "new": `"Sales (beta)"`},
+				map[string]interface{}{"field": "tags", "kind": "removed", "item": "finance"},
+			},
+		}))
+		Expect(entries[2].(map[string]interface{})["changes"]).To(BeEmpty())
+	})
+	It(`Handles a data product without releases`, func() {
+		server.remove("product-1", "release-a")
+		server.remove("product-1", "release-b")
+		server.remove("product-1", "release-c")
+		notes, err := dpxv1.GenerateReleaseNotes(context.Background(), dpxService, "product-1")
+		Expect(err).To(BeNil())
+		Expect(notes.Markdown()).To(Equal("# Changelog: product-1\n\n_No releases._\n"))
+	})
+})
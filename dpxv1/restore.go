@@ -0,0 +1,635 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// The properties of a data product version that Restore re-applies to existing drafts and releases.
+var restoredVersionFields = []string{"description", "domain", "is_restricted", "name", "parts_out", "tags", "types", "use_cases"}
+
+// Constants associated with the RestoreAction.Type property.
+const (
+	// Recreate a draft that was deleted, with CreateDataProductDraft.
+	RestoreAction_Type_CreateDraft = "create_draft"
+
+	// Recreate a contract terms document of a draft, with CreateDraftContractTermsDocument, and upload its attachment.
+	RestoreAction_Type_CreateDocument = "create_document"
+
+	// Re-apply the backed up properties to a draft, with UpdateDataProductDraft.
+	RestoreAction_Type_UpdateDraft = "update_draft"
+
+	// Re-apply the backed up properties to a release, with UpdateDataProductRelease.
+	RestoreAction_Type_UpdateRelease = "update_release"
+)
+
+// RestoreOptions : Options for Restore and PlanRestore.
+type RestoreOptions struct {
+	// The IDs of the data products to restore. Defaults to every data product of the backup.
+	DataProductIDs []string
+
+	// If true, Restore only returns the plan, without applying it.
+	DryRun bool
+
+	// The client used to upload the attachments of contract terms documents to their (signed) upload URLs.
+	// Defaults to the HTTP client of the service if "client" is a *DpxV1, or else to a client whose timeout is
+	// DefaultAttachmentTimeout.
+	HTTPClient *http.Client
+}
+
+// RestorePlan : The actions that restore the state of a backup (see Backup) in the live instance.
+type RestorePlan struct {
+	// The backup directory.
+	Dir string
+
+	// The actions, in the order in which they are applied.
+	Actions []*RestoreAction
+
+	// The differences that cannot be restored (e.g. a release that was deleted).
+	Warnings []string
+
+	httpClient *http.Client
+}
+
+// RestoreAction : An action of a RestorePlan.
+type RestoreAction struct {
+	// The type of the action.
+	Type string
+
+	// The ID of the data product.
+	DataProductID string
+
+	// The ID of the backed up draft or release.
+	VersionID string
+
+	// The ID of the live draft (for update_draft and create_document), if it differs from VersionID because the
+	// draft was recreated by a previous restore.
+	LiveID string
+
+	// The ID of the backed up contract terms (for create_document).
+	ContractTermsID string
+
+	// The ID of the contract terms document (for create_document).
+	DocumentID string
+
+	// The operations that re-apply the backed up properties (for update_draft and update_release).
+	Patch []JSONPatchOperation
+
+	// The ID of the draft that was created by a create_draft action, once it is applied.
+	CreatedID string
+
+	// True once the action has been applied successfully.
+	Done bool
+
+	// The error of the action, if applying it failed.
+	Err error
+
+	version     *DataProductVersion    // the backed up version, for create_draft
+	document    *ContractTermsDocument // the backed up document, for create_document
+	contentPath string                 // the path of the backed up attachment, for create_document
+	draft       *RestoreAction         // the create_draft action of the document's draft, if it was deleted
+	termsIndex  int                    // the index of the contract terms in the backed up version
+	liveTermsID string                 // the ID of the contract terms in the live draft, if it differs
+}
+
+// String returns a description of the action.
+func (action *RestoreAction) String() string {
+	versionID := action.VersionID
+	if action.LiveID != "" {
+		versionID += " (recreated as " + action.LiveID + ")"
+	}
+	switch action.Type {
+	case RestoreAction_Type_CreateDraft:
+		return fmt.Sprintf("create draft %s of data product %s", versionID, action.DataProductID)
+	case RestoreAction_Type_CreateDocument:
+		return fmt.Sprintf("create document %s in contract terms %s of draft %s of data product %s",
+			action.DocumentID, action.ContractTermsID, versionID, action.DataProductID)
+	}
+	paths := make([]string, 0, len(action.Patch))
+	for _, operation := range action.Patch {
+		paths = append(paths, stringValue(operation.Op)+" "+stringValue(operation.Path))
+	}
+	kind := "draft"
+	if action.Type == RestoreAction_Type_UpdateRelease {
+		kind = "release"
+	}
+	return fmt.Sprintf("update %s %s of data product %s: %s", kind, versionID, action.DataProductID, strings.Join(paths, ", "))
+}
+
+// liveVersionID returns the ID of the live draft or release that the action applies to.
+func (action *RestoreAction) liveVersionID() string {
+	if action.LiveID != "" {
+		return action.LiveID
+	}
+	return action.VersionID
+}
+
+// String returns a description of the plan, with one line per action and warning.
+func (plan *RestorePlan) String() string {
+	var builder strings.Builder
+	if len(plan.Actions) == 0 {
+		builder.WriteString("Nothing to restore.\n")
+	}
+	for _, action := range plan.Actions {
+		status := ""
+		if action.Err != nil {
+			status = " (failed: " + action.Err.Error() + ")"
+		} else if action.Done {
+			status = " (done)"
+		}
+		fmt.Fprintf(&builder, "- %s%s\n", action, status)
+	}
+	for _, warning := range plan.Warnings {
+		fmt.Fprintf(&builder, "! %s\n", warning)
+	}
+	return builder.String()
+}
+
+// Restore restores the state of the backup directory "dir" (see Backup) in the live instance: it recreates
+// the drafts that were deleted, recreates the contract terms documents that are missing from drafts (uploading
+// their backed up attachments), and re-applies the backed up properties of drafts and releases. It returns the
+// plan (see PlanRestore); if options.DryRun is true the plan is not applied, so it can be reviewed first.
+// Otherwise the error joins the errors of the actions that failed.
+func Restore(ctx context.Context, client DpxV1API, dir string, options *RestoreOptions) (*RestorePlan, error) {
+	plan, err := PlanRestore(ctx, client, dir, options)
+	if err != nil || (options != nil && options.DryRun) {
+		return plan, err
+	}
+	return plan, plan.Apply(ctx, client)
+}
+
+// PlanRestore compares the backup directory "dir" (see Backup) with the live instance and returns the actions
+// that restore the state of the backup, without applying them. Drafts that were published (as a release with
+// the same ID or version) are not recreated, and a backed up draft that is missing is matched with a live draft
+// of the same version, such as one recreated by a previous restore, so that restoring again plans nothing.
+func PlanRestore(ctx context.Context, client DpxV1API, dir string, options *RestoreOptions) (*RestorePlan, error) {
+	if options == nil {
+		options = &RestoreOptions{}
+	}
+	manifest, err := LoadBackupManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	planner := &restorePlanner{
+		ctx:      ctx,
+		client:   client,
+		dir:      dir,
+		manifest: manifest,
+		plan:     &RestorePlan{Dir: dir, httpClient: options.HTTPClient},
+	}
+
+	var dataProductIDs []string
+	for _, entry := range manifest.Entries {
+		if entry.Kind == BackupEntry_Kind_DataProduct &&
+			(len(options.DataProductIDs) == 0 || slices.Contains(options.DataProductIDs, entry.DataProductID)) {
+			dataProductIDs = append(dataProductIDs, entry.DataProductID)
+		}
+	}
+	sort.Strings(dataProductIDs)
+	for _, dataProductID := range dataProductIDs {
+		err = planner.planDataProduct(dataProductID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return planner.plan, nil
+}
+
+// restorePlanner holds the state of a call to PlanRestore.
+type restorePlanner struct {
+	ctx      context.Context
+	client   DpxV1API
+	dir      string
+	manifest *BackupManifest
+	plan     *RestorePlan
+}
+
+func (planner *restorePlanner) warn(format string, args ...interface{}) {
+	planner.plan.Warnings = append(planner.plan.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (planner *restorePlanner) planDataProduct(dataProductID string) error {
+	_, response, err := planner.client.GetDataProductWithContext(planner.ctx, planner.client.NewGetDataProductOptions(dataProductID))
+	if isNotFound(response) {
+		planner.warn("data product %s no longer exists and cannot be restored", dataProductID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	releasesPager, err := planner.client.NewDataProductReleasesPager(planner.client.NewListDataProductReleasesOptions(dataProductID))
+	if err != nil {
+		return err
+	}
+	liveReleases, err := releasesPager.GetAllWithContext(planner.ctx)
+	if err != nil {
+		return err
+	}
+
+	var versionPaths []string
+	for relPath, entry := range planner.manifest.Entries {
+		if entry.DataProductID == dataProductID && (entry.Kind == BackupEntry_Kind_Draft || entry.Kind == BackupEntry_Kind_Release) {
+			versionPaths = append(versionPaths, relPath)
+		}
+	}
+	sort.Strings(versionPaths)
+	for _, relPath := range versionPaths {
+		entry := planner.manifest.Entries[relPath]
+		backedUp := &DataProductVersion{}
+		err = planner.readJSON(relPath, backedUp)
+		if err != nil {
+			return err
+		}
+		if entry.Kind == BackupEntry_Kind_Draft {
+			err = planner.planDraft(dataProductID, entry.VersionID, backedUp, liveReleases)
+		} else {
+			err = planner.planRelease(dataProductID, entry.VersionID, backedUp)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (planner *restorePlanner) planDraft(dataProductID string, draftID string, backedUp *DataProductVersion, liveReleases []DataProductVersionSummary) error {
+	live, response, err := planner.client.GetDataProductDraftWithContext(planner.ctx, planner.client.NewGetDataProductDraftOptions(dataProductID, draftID))
+	if err != nil && !isNotFound(response) {
+		return err
+	}
+	if err != nil {
+		// A draft recreated by a previous restore has a new ID.
+		live, err = planner.findDraft(dataProductID, backedUp)
+		if err != nil {
+			return err
+		}
+	}
+	if live != nil {
+		liveID := stringValue(live.ID)
+		if liveID == draftID {
+			liveID = ""
+		}
+		if patch := restorePatch(backedUp, live); len(patch) > 0 {
+			planner.plan.Actions = append(planner.plan.Actions, &RestoreAction{
+				Type:          RestoreAction_Type_UpdateDraft,
+				DataProductID: dataProductID,
+				VersionID:     draftID,
+				LiveID:        liveID,
+				Patch:         patch,
+			})
+		}
+		planner.planDocuments(dataProductID, draftID, backedUp, live, nil)
+		return nil
+	}
+
+	for _, release := range liveReleases {
+		if stringValue(release.ID) == draftID || (backedUp.Version != nil && stringValue(release.Version) == *backedUp.Version) {
+			planner.warn("draft %s of data product %s was published as release %s and is not recreated",
+				draftID, dataProductID, stringValue(release.ID))
+			return nil
+		}
+	}
+	createDraft := &RestoreAction{
+		Type:          RestoreAction_Type_CreateDraft,
+		DataProductID: dataProductID,
+		VersionID:     draftID,
+		version:       backedUp,
+	}
+	planner.plan.Actions = append(planner.plan.Actions, createDraft)
+	planner.planDocuments(dataProductID, draftID, backedUp, nil, createDraft)
+	return nil
+}
+
+// findDraft returns the live draft with the version of the backed up draft, or nil.
+func (planner *restorePlanner) findDraft(dataProductID string, backedUp *DataProductVersion) (*DataProductVersion, error) {
+	if backedUp.Version == nil {
+		return nil, nil
+	}
+	options := planner.client.NewListDataProductDraftsOptions(dataProductID)
+	options.SetVersion(*backedUp.Version)
+	pager, err := planner.client.NewDataProductDraftsPager(options)
+	if err != nil {
+		return nil, err
+	}
+	drafts, err := pager.GetAllWithContext(planner.ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, draft := range drafts {
+		if stringValue(draft.Version) != *backedUp.Version {
+			continue
+		}
+		live, _, err := planner.client.GetDataProductDraftWithContext(planner.ctx,
+			planner.client.NewGetDataProductDraftOptions(dataProductID, stringValue(draft.ID)))
+		return live, err
+	}
+	return nil, nil
+}
+
+// planDocuments plans the creation of the backed up documents of a draft that are missing from "live", or of
+// every backed up document if the draft is recreated by "createDraft".
+func (planner *restorePlanner) planDocuments(dataProductID string, draftID string, backedUp *DataProductVersion, live *DataProductVersion, createDraft *RestoreAction) {
+	for termsIndex, contractTerms := range backedUp.ContractTerms {
+		contractTermsID := stringValue(contractTerms.ID)
+		var liveTerms *DataProductContractTerms
+		liveID := ""
+		if live != nil {
+			for i := range live.ContractTerms {
+				if stringValue(live.ContractTerms[i].ID) == contractTermsID {
+					liveTerms = &live.ContractTerms[i]
+				}
+			}
+			if liveID = stringValue(live.ID); liveID == draftID {
+				liveID = ""
+			} else if liveTerms == nil && termsIndex < len(live.ContractTerms) {
+				// The recreated draft has new IDs; its contract terms are in the same order as in the backup.
+				liveTerms = &live.ContractTerms[termsIndex]
+			}
+			if liveTerms == nil {
+				planner.warn("contract terms %s of draft %s of data product %s no longer exist; their documents cannot be restored",
+					contractTermsID, draftID, dataProductID)
+				continue
+			}
+		}
+
+		for i := range contractTerms.Documents {
+			document := &contractTerms.Documents[i]
+			documentID := stringValue(document.ID)
+			if liveTerms != nil && slices.ContainsFunc(liveTerms.Documents, func(liveDocument ContractTermsDocument) bool {
+				return stringValue(liveDocument.ID) == documentID
+			}) {
+				continue
+			}
+			action := &RestoreAction{
+				Type:            RestoreAction_Type_CreateDocument,
+				DataProductID:   dataProductID,
+				VersionID:       draftID,
+				LiveID:          liveID,
+				ContractTermsID: contractTermsID,
+				DocumentID:      documentID,
+				document:        document,
+				draft:           createDraft,
+				termsIndex:      termsIndex,
+			}
+			if liveTerms != nil && stringValue(liveTerms.ID) != contractTermsID {
+				action.liveTermsID = stringValue(liveTerms.ID)
+			}
+			if document.Attachment != nil {
+				contentPath := backupDocumentPath(dataProductID, draftID, true, contractTermsID, documentID) + ".content"
+				if planner.manifest.Entries[contentPath] == nil {
+					planner.warn("the attachment of document %s of draft %s of data product %s was not backed up; the document is not recreated",
+						documentID, draftID, dataProductID)
+					continue
+				}
+				action.contentPath = contentPath
+			}
+			planner.plan.Actions = append(planner.plan.Actions, action)
+		}
+	}
+}
+
+func (planner *restorePlanner) planRelease(dataProductID string, releaseID string, backedUp *DataProductVersion) error {
+	live, response, err := planner.client.GetDataProductReleaseWithContext(planner.ctx, planner.client.NewGetDataProductReleaseOptions(dataProductID, releaseID))
+	if isNotFound(response) {
+		planner.warn("release %s of data product %s no longer exists and cannot be recreated", releaseID, dataProductID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if patch := restorePatch(backedUp, live); len(patch) > 0 {
+		planner.plan.Actions = append(planner.plan.Actions, &RestoreAction{
+			Type:          RestoreAction_Type_UpdateRelease,
+			DataProductID: dataProductID,
+			VersionID:     releaseID,
+			Patch:         patch,
+		})
+	}
+	for _, contractTerms := range backedUp.ContractTerms {
+		for _, document := range contractTerms.Documents {
+			if !versionHasDocument(live, stringValue(contractTerms.ID), stringValue(document.ID)) {
+				planner.warn("document %s of release %s of data product %s no longer exists and cannot be recreated",
+					stringValue(document.ID), releaseID, dataProductID)
+			}
+		}
+	}
+	return nil
+}
+
+// readJSON unmarshals the backup file at "relPath" into "model".
+func (planner *restorePlanner) readJSON(relPath string, model interface{}) error {
+	data, err := os.ReadFile(filepath.Join(planner.dir, filepath.FromSlash(relPath)))
+	if err != nil {
+		return fmt.Errorf("error reading backup: %w", err)
+	}
+	err = json.Unmarshal(data, model)
+	if err != nil {
+		return fmt.Errorf("error unmarshalling %s: %w", relPath, err)
+	}
+	return nil
+}
+
+// Apply applies the actions of the plan that have not been applied yet, in order. An action that fails does not
+// prevent the next ones, except for the documents of a draft that could not be recreated. It returns an error
+// that joins the errors of the actions that failed, which are also recorded in the actions.
+func (plan *RestorePlan) Apply(ctx context.Context, client DpxV1API) error {
+	var errs []error
+	for _, action := range plan.Actions {
+		if action.Done {
+			continue
+		}
+		action.Err = plan.apply(ctx, client, action)
+		if action.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", action, action.Err))
+			continue
+		}
+		action.Done = true
+	}
+	return errors.Join(errs...)
+}
+
+func (plan *RestorePlan) apply(ctx context.Context, client DpxV1API, action *RestoreAction) error {
+	switch action.Type {
+	case RestoreAction_Type_CreateDraft:
+		return plan.createDraft(ctx, client, action)
+	case RestoreAction_Type_CreateDocument:
+		return plan.createDocument(ctx, client, action)
+	case RestoreAction_Type_UpdateDraft:
+		_, _, err := client.UpdateDataProductDraftWithContext(ctx,
+			client.NewUpdateDataProductDraftOptions(action.DataProductID, action.liveVersionID(), action.Patch))
+		return err
+	case RestoreAction_Type_UpdateRelease:
+		_, _, err := client.UpdateDataProductReleaseWithContext(ctx,
+			client.NewUpdateDataProductReleaseOptions(action.DataProductID, action.VersionID, action.Patch))
+		return err
+	}
+	return fmt.Errorf("unknown restore action type %q", action.Type)
+}
+
+func (plan *RestorePlan) createDraft(ctx context.Context, client DpxV1API, action *RestoreAction) error {
+	version := action.version
+	options := client.NewCreateDataProductDraftOptions(action.DataProductID, version.Asset)
+	options.Version = version.Version
+	options.DataProduct = version.DataProduct
+	options.Name = version.Name
+	options.Description = version.Description
+	options.Tags = version.Tags
+	options.UseCases = version.UseCases
+	options.Domain = version.Domain
+	options.Types = version.Types
+	options.PartsOut = version.PartsOut
+	options.IsRestricted = version.IsRestricted
+	// The documents are recreated by the create_document actions.
+	for _, contractTerms := range version.ContractTerms {
+		options.ContractTerms = append(options.ContractTerms, DataProductContractTerms{Asset: contractTerms.Asset, ID: contractTerms.ID})
+	}
+
+	draft, _, err := client.CreateDataProductDraftWithContext(ctx, options)
+	if err != nil {
+		return err
+	}
+	action.CreatedID = stringValue(draft.ID)
+	action.version = draft
+	return nil
+}
+
+func (plan *RestorePlan) createDocument(ctx context.Context, client DpxV1API, action *RestoreAction) error {
+	draftID, contractTermsID := action.liveVersionID(), action.ContractTermsID
+	if action.liveTermsID != "" {
+		contractTermsID = action.liveTermsID
+	}
+	if action.draft != nil {
+		if !action.draft.Done {
+			return fmt.Errorf("draft %s was not recreated", action.VersionID)
+		}
+		// The recreated draft has new IDs; its contract terms are in the same order as in the backup.
+		draftID = action.draft.CreatedID
+		if action.termsIndex >= len(action.draft.version.ContractTerms) {
+			return fmt.Errorf("contract terms %s were not recreated", action.ContractTermsID)
+		}
+		contractTermsID = stringValue(action.draft.version.ContractTerms[action.termsIndex].ID)
+	}
+
+	document := action.document
+	options := client.NewCreateDraftContractTermsDocumentOptions(action.DataProductID, draftID, contractTermsID,
+		stringValue(document.Type), stringValue(document.Name), stringValue(document.ID), stringValue(document.URL))
+	if document.Attachment != nil || document.URL == nil {
+		options.URL = nil
+	}
+	created, _, err := client.CreateDraftContractTermsDocumentWithContext(ctx, options)
+	if err != nil {
+		return err
+	}
+	if action.contentPath == "" {
+		return nil
+	}
+
+	if created.UploadURL == nil {
+		return fmt.Errorf("no upload URL was returned for document %s", action.DocumentID)
+	}
+	content, err := os.ReadFile(filepath.Join(plan.Dir, filepath.FromSlash(action.contentPath)))
+	if err != nil {
+		return fmt.Errorf("error reading backup: %w", err)
+	}
+	err = plan.upload(ctx, attachmentHTTPClient(client, plan.httpClient), *created.UploadURL, content)
+	if err != nil {
+		return fmt.Errorf("error uploading the attachment of document %s: %w", action.DocumentID, err)
+	}
+	_, _, err = client.CompleteDraftContractTermsDocumentWithContext(ctx,
+		client.NewCompleteDraftContractTermsDocumentOptions(action.DataProductID, draftID, contractTermsID, stringValue(document.ID)))
+	return err
+}
+
+// upload uploads "content" to the signed URL "url" with "httpClient".
+func (plan *RestorePlan) upload(ctx context.Context, httpClient *http.Client, url string, content []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", response.Status)
+	}
+	return nil
+}
+
+// restorePatch returns the operations that re-apply the restored properties of "backedUp" to "live".
+func restorePatch(backedUp *DataProductVersion, live *DataProductVersion) (patch []JSONPatchOperation) {
+	backedUpFields, liveFields := jsonFields(backedUp), jsonFields(live)
+	for _, field := range restoredVersionFields {
+		backedUpValue, inBackup := backedUpFields[field]
+		liveValue, inLive := liveFields[field]
+		switch {
+		case inBackup && !inLive:
+			patch = append(patch, JSONPatchOperation{Op: core.StringPtr(JSONPatchOperation_Op_Add), Path: core.StringPtr("/" + field), Value: backedUpValue})
+		case !inBackup && inLive:
+			patch = append(patch, JSONPatchOperation{Op: core.StringPtr(JSONPatchOperation_Op_Remove), Path: core.StringPtr("/" + field)})
+		case inBackup && !reflect.DeepEqual(backedUpValue, liveValue):
+			patch = append(patch, JSONPatchOperation{Op: core.StringPtr(JSONPatchOperation_Op_Replace), Path: core.StringPtr("/" + field), Value: backedUpValue})
+		}
+	}
+	return
+}
+
+// jsonFields returns the top-level properties of the JSON representation of "model".
+func jsonFields(model interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	data, err := json.Marshal(model)
+	if err == nil {
+		_ = json.Unmarshal(data, &fields)
+	}
+	return fields
+}
+
+// versionHasDocument returns true if "version" has the specified contract terms document.
+func versionHasDocument(version *DataProductVersion, contractTermsID string, documentID string) bool {
+	for _, contractTerms := range version.ContractTerms {
+		if stringValue(contractTerms.ID) != contractTermsID {
+			continue
+		}
+		for _, document := range contractTerms.Documents {
+			if stringValue(document.ID) == documentID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isNotFound returns true if "response" has the status 404 Not Found.
+func isNotFound(response *core.DetailedResponse) bool {
+	return response != nil && response.StatusCode == http.StatusNotFound
+}
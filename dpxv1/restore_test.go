@@ -0,0 +1,191 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Restore`, func() {
+	var server *catalogServer
+	var dpxService *dpxv1.DpxV1
+	var dir string
+
+	// testDraft returns a draft with the contract terms documents of testRelease.
+	testDraft := func(dataProductID string, draftID string) *dpxv1.DataProductVersion {
+		draft := testRelease(dataProductID, draftID)
+		draft.State = core.StringPtr(dpxv1.DataProductVersion_State_Draft)
+		draft.Version = core.StringPtr("2.0.0")
+		draft.PublishedAt = nil
+		draft.Tags = []string{"finance"}
+		return draft
+	}
+	mutations := func() (mutations []string) {
+		for _, request := range server.requestLog() {
+			if !strings.HasPrefix(request, "GET ") {
+				mutations = append(mutations, request)
+			}
+		}
+		return
+	}
+
+	BeforeEach(func() {
+		server = newCatalogServer()
+		dpxService = server.newService()
+		server.put(testRelease("product-1", "release-1"))
+		server.put(testDraft("product-1", "draft-1"))
+		server.putFile("attachment-1", []byte("%PDF sla"))
+
+		var err error
+		dir, err = os.MkdirTemp("", "dpx-restore")
+		Expect(err).To(BeNil())
+		_, err = dpxv1.Backup(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	It(`Plans nothing when the instance matches the backup`, func() {
+		plan, err := dpxv1.PlanRestore(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		Expect(plan.Actions).To(BeEmpty())
+		Expect(plan.Warnings).To(BeEmpty())
+		Expect(plan.String()).To(Equal("Nothing to restore.\n"))
+	})
+	It(`Returns a dry-run plan without changing the instance`, func() {
+		release := testRelease("product-1", "release-1")
+		release.Name = core.StringPtr("Renamed")
+		release.Tags = []string{"finance"}
+		server.put(release)
+		server.remove("product-1", "draft-1")
+
+		plan, err := dpxv1.Restore(context.Background(), dpxService, dir, &dpxv1.RestoreOptions{DryRun: true})
+		Expect(err).To(BeNil())
+		Expect(plan.String()).To(Equal(
+			"- create draft draft-1 of data product product-1\n" +
+				"- create document document-1 in contract terms terms-1 of draft draft-1 of data product product-1\n" +
+				"- create document document-2 in contract terms terms-1 of draft draft-1 of data product product-1\n" +
+				"- update release release-1 of data product product-1: replace /name, remove /tags\n"))
+		Expect(plan.Actions[3].Patch[0].Value).To(Equal("Sales"))
+		Expect(mutations()).To(BeEmpty())
+	})
+	It(`Recreates deleted drafts with their documents and attachments`, func() {
+		server.remove("product-1", "draft-1")
+		transport := &pathRecordingTransport{}
+		dpxService.Service.GetHTTPClient().Transport = transport
+
+		plan, err := dpxv1.Restore(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		Expect(plan.Actions).To(HaveLen(3))
+		for _, action := range plan.Actions {
+			Expect(action.Done).To(BeTrue())
+		}
+		draftID := plan.Actions[0].CreatedID
+		Expect(draftID).ToNot(BeEmpty())
+
+		draft := server.get("product-1", draftID)
+		Expect(*draft.Name).To(Equal("Sales"))
+		Expect(*draft.Version).To(Equal("2.0.0"))
+		Expect(draft.Tags).To(Equal([]string{"finance"}))
+		Expect(draft.PartsOut).To(HaveLen(1))
+		Expect(draft.ContractTerms).To(HaveLen(1))
+		documents := draft.ContractTerms[0].Documents
+		Expect(documents).To(HaveLen(2))
+		Expect(documents[0].URL).To(BeNil())
+		Expect(server.files[*documents[0].Attachment.ID]).To(Equal([]byte("%PDF sla")))
+		Expect(*documents[1].URL).To(Equal("https://example.com/terms"))
+		Expect(mutations()).To(ContainElement(HavePrefix("PUT /upload/")))
+		Expect(transport.paths()).To(ContainElement(HavePrefix("/upload/")))
+		Expect(mutations()).To(ContainElement(HaveSuffix("/documents/document-1/complete")))
+	})
+	It(`Plans nothing when restoring again after recreating a draft`, func() {
+		server.remove("product-1", "draft-1")
+		plan, err := dpxv1.Restore(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		Expect(plan.Actions).To(HaveLen(3))
+		draftID := plan.Actions[0].CreatedID
+
+		plan, err = dpxv1.Restore(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		Expect(plan.Actions).To(BeEmpty())
+		Expect(plan.Warnings).To(BeEmpty())
+
+		recreated := server.get("product-1", draftID)
+		recreated.Description = core.StringPtr("Changed")
+		recreated.ContractTerms[0].Documents = recreated.ContractTerms[0].Documents[:1]
+		server.put(recreated)
+		plan, err = dpxv1.Restore(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		Expect(plan.String()).To(Equal(
+			"- update draft draft-1 (recreated as " + draftID + ") of data product product-1: replace /description (done)\n" +
+				"- create document document-2 in contract terms terms-1 of draft draft-1 (recreated as " + draftID + ") of data product product-1 (done)\n"))
+		restored := server.get("product-1", draftID)
+		Expect(*restored.Description).To(Equal("The Sales data product"))
+		Expect(restored.ContractTerms[0].Documents).To(HaveLen(2))
+	})
+	It(`Re-creates missing documents and re-applies metadata to existing drafts`, func() {
+		draft := testDraft("product-1", "draft-1")
+		draft.Description = core.StringPtr("Changed")
+		draft.ContractTerms[0].Documents = draft.ContractTerms[0].Documents[:1]
+		server.put(draft)
+
+		plan, err := dpxv1.Restore(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		Expect(plan.String()).To(Equal(
+			"- update draft draft-1 of data product product-1: replace /description (done)\n" +
+				"- create document document-2 in contract terms terms-1 of draft draft-1 of data product product-1 (done)\n"))
+		restored := server.get("product-1", "draft-1")
+		Expect(*restored.Description).To(Equal("The Sales data product"))
+		Expect(restored.ContractTerms[0].Documents).To(HaveLen(2))
+	})
+	It(`Warns about what cannot be restored`, func() {
+		server.remove("product-1", "release-1")
+		server.remove("product-1", "draft-1")
+		published := testDraft("product-1", "release-2")
+		published.State = core.StringPtr(dpxv1.DataProductVersion_State_Available)
+		server.put(published)
+
+		plan, err := dpxv1.PlanRestore(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		Expect(plan.Actions).To(BeEmpty())
+		Expect(plan.Warnings).To(Equal([]string{
+			"draft draft-1 of data product product-1 was published as release release-2 and is not recreated",
+			"release release-1 of data product product-1 no longer exists and cannot be recreated",
+		}))
+	})
+	It(`Records the errors of the actions that fail`, func() {
+		server.remove("product-1", "draft-1")
+		plan, err := dpxv1.PlanRestore(context.Background(), dpxService, dir, nil)
+		Expect(err).To(BeNil())
+		server.Close()
+
+		err = plan.Apply(context.Background(), dpxService)
+		Expect(err).ToNot(BeNil())
+		Expect(plan.Actions[0].Err).ToNot(BeNil())
+		Expect(plan.Actions[1].Err).To(MatchError("draft draft-1 was not recreated"))
+		Expect(plan.String()).To(ContainSubstring("(failed: "))
+	})
+})
@@ -0,0 +1,232 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// ErrCredentialRotationFailed is matched (via errors.Is) by the error returned by RotateCredentials when the
+// rotation or its verification fails.
+var ErrCredentialRotationFailed = errors.New("credential rotation failed")
+
+// RotateCredentialsOptions : Options for RotateCredentials.
+type RotateCredentialsOptions struct {
+	// Returns the authenticator used after the rotation, e.g. one built from a new API key read from a secrets
+	// manager. If nil, the current authenticator is rebuilt without its cached token (for iam), or kept.
+	NewAuthenticator func(ctx context.Context) (core.Authenticator, error)
+
+	// The container whose initialization status verifies the credentials. Defaults to the default container ID
+	// (see SetDefaultContainerID).
+	ContainerID string
+
+	// Receives the audit record of the rotation, whether it succeeds or not.
+	Audit func(record *CredentialRotationRecord)
+}
+
+// CredentialRotationRecord : The audit record of a credential rotation.
+type CredentialRotationRecord struct {
+	// The service URL of the client.
+	ServiceURL string `json:"service_url"`
+
+	// The authentication type of the authenticator in use after the rotation.
+	AuthType string `json:"auth_type"`
+
+	// The outcome of the rotation.
+	Outcome string `json:"outcome"`
+
+	// The step that failed, if any.
+	FailedStep string `json:"failed_step,omitempty"`
+
+	// The status codes of the ManageApiKeys and GetInitializeStatus responses, if any.
+	RotateStatusCode int `json:"rotate_status_code,omitempty"`
+	VerifyStatusCode int `json:"verify_status_code,omitempty"`
+
+	// The error message, if the rotation failed.
+	Error string `json:"error,omitempty"`
+
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// Constants associated with the CredentialRotationRecord.Outcome property.
+const (
+	// The credentials were rotated and verified, and the client uses the new authenticator.
+	CredentialRotationRecord_Outcome_Succeeded = "succeeded"
+	// The rotation failed before the authenticator of the client was replaced.
+	CredentialRotationRecord_Outcome_Failed = "failed"
+	// The verification failed and the client uses its previous authenticator again.
+	CredentialRotationRecord_Outcome_RolledBack = "rolled_back"
+)
+
+// Constants associated with the CredentialRotationRecord.FailedStep property.
+const (
+	CredentialRotationRecord_FailedStep_Rotate       = "rotate"
+	CredentialRotationRecord_FailedStep_Authenticate = "authenticate"
+	CredentialRotationRecord_FailedStep_Verify       = "verify"
+)
+
+// CredentialRotationError is returned by RotateCredentials when the rotation or its verification fails.
+type CredentialRotationError struct {
+	// The audit record of the rotation.
+	Record *CredentialRotationRecord
+
+	// The error of the failed step.
+	Err error
+}
+
+// Error returns the error message.
+func (err *CredentialRotationError) Error() string {
+	return fmt.Sprintf("%s (%s, %s): %s", ErrCredentialRotationFailed.Error(), err.Record.FailedStep, err.Record.Outcome, err.Err.Error())
+}
+
+// Is returns true if "target" is ErrCredentialRotationFailed.
+func (err *CredentialRotationError) Is(target error) bool {
+	return target == ErrCredentialRotationFailed
+}
+
+// Unwrap returns the error of the failed step.
+func (err *CredentialRotationError) Unwrap() error {
+	return err.Err
+}
+
+// RotateCredentials rotates the API keys of the service with ManageApiKeys, replaces the authenticator of the
+// client so that no stale token is used, and verifies the new credentials with GetInitializeStatus. If the
+// verification fails, the previous authenticator is reinstated. The audit record is passed to options.Audit and
+// logged at slog.LevelInfo (or slog.LevelError on failure) to the logger of the client, if any.
+//
+// The authenticator is replaced without synchronization: do not call RotateCredentials concurrently with other
+// operations of the same client.
+func (dpx *DpxV1) RotateCredentials(ctx context.Context, options *RotateCredentialsOptions) (*CredentialRotationRecord, error) {
+	if options == nil {
+		options = &RotateCredentialsOptions{}
+	}
+	previous := dpx.Service.Options.Authenticator
+	record := &CredentialRotationRecord{
+		ServiceURL: dpx.GetServiceURL(),
+		AuthType:   previous.AuthenticationType(),
+		StartedAt:  time.Now(),
+	}
+	err := dpx.rotateCredentials(ctx, options, previous, record)
+	record.FinishedAt = time.Now()
+	if err != nil {
+		record.Error = err.Error()
+		err = &CredentialRotationError{Record: record, Err: err}
+	} else {
+		record.Outcome = CredentialRotationRecord_Outcome_Succeeded
+	}
+	dpx.auditCredentialRotation(ctx, record)
+	if options.Audit != nil {
+		options.Audit(record)
+	}
+	return record, err
+}
+
+// rotateCredentials runs the steps of RotateCredentials and sets the outcome of a failed rotation.
+func (dpx *DpxV1) rotateCredentials(ctx context.Context, options *RotateCredentialsOptions, previous core.Authenticator, record *CredentialRotationRecord) error {
+	response, err := dpx.ManageApiKeysWithContext(ctx, dpx.NewManageApiKeysOptions())
+	if response != nil {
+		record.RotateStatusCode = response.StatusCode
+	}
+	if err != nil {
+		record.Outcome = CredentialRotationRecord_Outcome_Failed
+		record.FailedStep = CredentialRotationRecord_FailedStep_Rotate
+		return err
+	}
+
+	var authenticator core.Authenticator
+	if options.NewAuthenticator != nil {
+		authenticator, err = options.NewAuthenticator(ctx)
+	} else {
+		authenticator, err = refreshAuthenticator(previous)
+	}
+	if err == nil && authenticator == nil {
+		err = errors.New("no authenticator")
+	}
+	if err == nil {
+		err = authenticator.Validate()
+	}
+	if err != nil {
+		record.Outcome = CredentialRotationRecord_Outcome_Failed
+		record.FailedStep = CredentialRotationRecord_FailedStep_Authenticate
+		return err
+	}
+	dpx.Service.Options.Authenticator = authenticator
+	record.AuthType = authenticator.AuthenticationType()
+
+	verifyOptions := dpx.NewGetInitializeStatusOptions()
+	if options.ContainerID != "" {
+		verifyOptions.SetContainerID(options.ContainerID)
+	}
+	_, response, err = dpx.GetInitializeStatusWithContext(ctx, verifyOptions)
+	if response != nil {
+		record.VerifyStatusCode = response.StatusCode
+	}
+	if err != nil {
+		dpx.Service.Options.Authenticator = previous
+		record.AuthType = previous.AuthenticationType()
+		record.Outcome = CredentialRotationRecord_Outcome_RolledBack
+		record.FailedStep = CredentialRotationRecord_FailedStep_Verify
+		return err
+	}
+	return nil
+}
+
+// refreshAuthenticator returns a copy of an IAM authenticator without its cached token, or "authenticator"
+// itself for the other types, which do not cache tokens derived from the credentials.
+func refreshAuthenticator(authenticator core.Authenticator) (core.Authenticator, error) {
+	iam, ok := authenticator.(*core.IamAuthenticator)
+	if !ok {
+		return authenticator, nil
+	}
+	return core.NewIamAuthenticatorBuilder().
+		SetApiKey(iam.ApiKey).
+		SetRefreshToken(iam.RefreshToken).
+		SetURL(iam.URL).
+		SetClientIDSecret(iam.ClientId, iam.ClientSecret).
+		SetDisableSSLVerification(iam.DisableSSLVerification).
+		SetScope(iam.Scope).
+		SetHeaders(iam.Headers).
+		SetClient(iam.Client).
+		Build()
+}
+
+// auditCredentialRotation logs the audit record of a rotation to the logger of the client, if any.
+func (dpx *DpxV1) auditCredentialRotation(ctx context.Context, record *CredentialRotationRecord) {
+	logger := dpx.GetLogger()
+	if logger == nil {
+		return
+	}
+	level := slog.LevelInfo
+	attrs := []slog.Attr{
+		slog.String(LogKeyURL, record.ServiceURL),
+		slog.String("auth_type", record.AuthType),
+		slog.String("outcome", record.Outcome),
+		slog.Duration(LogKeyDuration, record.FinishedAt.Sub(record.StartedAt)),
+	}
+	if record.Error != "" {
+		level = slog.LevelError
+		attrs = append(attrs, slog.String("failed_step", record.FailedStep), slog.String(LogKeyError, record.Error))
+	}
+	logger.LogAttrs(ctx, level, "credential rotation", attrs...)
+}
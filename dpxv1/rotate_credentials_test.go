@@ -0,0 +1,162 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`RotateCredentials`, func() {
+	var testServer *httptest.Server
+	var mu sync.Mutex
+	var rotateStatus int
+	var validToken string
+	var tokens int
+	var verifiedWith []string
+
+	BeforeEach(func() {
+		rotateStatus = 200
+		validToken = "token-1"
+		tokens = 0
+		verifiedWith = nil
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			res.Header().Set("Content-Type", "application/json")
+			switch req.URL.Path {
+			case "/identity/token":
+				tokens++
+				fmt.Fprintf(res, `{"access_token": "token-%d", "refresh_token": "refresh", "token_type": "Bearer", "expires_in": 3600, "expiration": %d}`,
+					tokens, time.Now().Add(time.Hour).Unix())
+			case "/data_product_exchange/v1/configuration/rotate_credentials":
+				res.WriteHeader(rotateStatus)
+			case "/data_product_exchange/v1/configuration/initialize/status":
+				verifiedWith = append(verifiedWith, req.Header.Get("Authorization"))
+				if req.Header.Get("Authorization") != "Bearer "+validToken {
+					res.WriteHeader(401)
+					fmt.Fprint(res, `{"errors": [{"code": "invalid_token", "message": "Invalid token"}], "trace": "trace-1"}`)
+					return
+				}
+				fmt.Fprint(res, `{"status": "succeeded"}`)
+			}
+		}))
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	newService := func(authenticator core.Authenticator) *dpxv1.DpxV1 {
+		dpxService, err := dpxv1.NewDpxV1(&dpxv1.DpxV1Options{URL: testServer.URL, Authenticator: authenticator})
+		Expect(err).To(BeNil())
+		return dpxService
+	}
+
+	It(`Rebuilds the IAM authenticator so that a new token is used`, func() {
+		authenticator, err := core.NewIamAuthenticatorBuilder().SetApiKey("apikey").SetURL(testServer.URL).Build()
+		Expect(err).To(BeNil())
+		dpxService := newService(authenticator)
+		dpxService.SetDefaultContainerID("catalog-1")
+		_, _, err = dpxService.GetInitializeStatus(dpxService.NewGetInitializeStatusOptions())
+		Expect(err).To(BeNil())
+
+		validToken = "token-2"
+		var logs bytes.Buffer
+		dpxService.SetLogger(slog.New(slog.NewTextHandler(&logs, nil)), nil)
+		var audited *dpxv1.CredentialRotationRecord
+		record, err := dpxService.RotateCredentials(context.Background(), &dpxv1.RotateCredentialsOptions{
+			Audit: func(record *dpxv1.CredentialRotationRecord) { audited = record },
+		})
+		Expect(err).To(BeNil())
+		Expect(audited).To(BeIdenticalTo(record))
+		Expect(record.Outcome).To(Equal(dpxv1.CredentialRotationRecord_Outcome_Succeeded))
+		Expect(record.AuthType).To(Equal(core.AUTHTYPE_IAM))
+		Expect(record.RotateStatusCode).To(Equal(200))
+		Expect(record.VerifyStatusCode).To(Equal(200))
+		Expect(record.FinishedAt).ToNot(BeTemporally("<", record.StartedAt))
+		Expect(verifiedWith).To(Equal([]string{"Bearer token-1", "Bearer token-2"}))
+		Expect(dpxService.Service.Options.Authenticator).ToNot(BeIdenticalTo(authenticator))
+		Expect(logs.String()).To(ContainSubstring(`msg="credential rotation"`))
+		Expect(logs.String()).To(ContainSubstring(`outcome=succeeded`))
+	})
+	It(`Uses the authenticator returned by NewAuthenticator`, func() {
+		bearer, err := core.NewBearerTokenAuthenticator("token-1")
+		Expect(err).To(BeNil())
+		dpxService := newService(bearer)
+		validToken = "token-9"
+		_, err = dpxService.RotateCredentials(context.Background(), &dpxv1.RotateCredentialsOptions{
+			NewAuthenticator: func(context.Context) (core.Authenticator, error) {
+				return core.NewBearerTokenAuthenticator("token-9")
+			},
+		})
+		Expect(err).To(BeNil())
+		Expect(verifiedWith).To(Equal([]string{"Bearer token-9"}))
+	})
+	It(`Rolls back the authenticator when the verification fails`, func() {
+		bearer, err := core.NewBearerTokenAuthenticator("token-1")
+		Expect(err).To(BeNil())
+		dpxService := newService(bearer)
+		record, err := dpxService.RotateCredentials(context.Background(), &dpxv1.RotateCredentialsOptions{
+			NewAuthenticator: func(context.Context) (core.Authenticator, error) {
+				return core.NewBearerTokenAuthenticator("token-bad")
+			},
+		})
+		Expect(errors.Is(err, dpxv1.ErrCredentialRotationFailed)).To(BeTrue())
+		var rotationError *dpxv1.CredentialRotationError
+		Expect(errors.As(err, &rotationError)).To(BeTrue())
+		Expect(rotationError.Record).To(BeIdenticalTo(record))
+		Expect(record.Outcome).To(Equal(dpxv1.CredentialRotationRecord_Outcome_RolledBack))
+		Expect(record.FailedStep).To(Equal(dpxv1.CredentialRotationRecord_FailedStep_Verify))
+		Expect(record.VerifyStatusCode).To(Equal(401))
+		Expect(record.Error).To(ContainSubstring("Invalid token"))
+		Expect(dpxService.Service.Options.Authenticator).To(BeIdenticalTo(bearer))
+	})
+	It(`Keeps the authenticator when the rotation fails`, func() {
+		bearer, err := core.NewBearerTokenAuthenticator("token-1")
+		Expect(err).To(BeNil())
+		dpxService := newService(bearer)
+		rotateStatus = 500
+		record, err := dpxService.RotateCredentials(context.Background(), nil)
+		Expect(errors.Is(err, dpxv1.ErrCredentialRotationFailed)).To(BeTrue())
+		Expect(record.Outcome).To(Equal(dpxv1.CredentialRotationRecord_Outcome_Failed))
+		Expect(record.FailedStep).To(Equal(dpxv1.CredentialRotationRecord_FailedStep_Rotate))
+		Expect(record.RotateStatusCode).To(Equal(500))
+		Expect(verifiedWith).To(BeEmpty())
+
+		rotateStatus = 200
+		record, err = dpxService.RotateCredentials(context.Background(), &dpxv1.RotateCredentialsOptions{
+			NewAuthenticator: func(context.Context) (core.Authenticator, error) {
+				return nil, errors.New("secret not found")
+			},
+		})
+		Expect(err).To(MatchError("credential rotation failed (authenticate, failed): secret not found"))
+		Expect(record.FailedStep).To(Equal(dpxv1.CredentialRotationRecord_FailedStep_Authenticate))
+		Expect(dpxService.Service.Options.Authenticator).To(BeIdenticalTo(bearer))
+	})
+})
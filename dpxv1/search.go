@@ -0,0 +1,502 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// The weights of the indexed fields of a release in the score of a search result.
+const (
+	searchWeightName        = 5.0
+	searchWeightTags        = 3.0
+	searchWeightDomain      = 2.0
+	searchWeightUseCases    = 2.0
+	searchWeightDescription = 1.0
+)
+
+// SearchIndex : A local full-text index of the releases of the catalog, built with Refresh and queried with Search.
+// The name, description, tags, domain name and use case names of each release are indexed.
+// A SearchIndex can be saved and loaded, so that it can be queried offline. It is safe for concurrent use.
+type SearchIndex struct {
+	mu sync.RWMutex
+
+	// The indexed releases, keyed by "<data product ID>/<release ID>".
+	documents map[string]*searchDocument
+
+	// The keys of the documents that contain each term.
+	postings map[string]map[string]struct{}
+
+	// The latest published_at of the indexed releases.
+	publishedThrough time.Time
+}
+
+// searchDocument is an indexed release.
+type searchDocument struct {
+	key     string
+	version *DataProductVersion
+
+	// The weighted frequency of each term of the release.
+	terms map[string]float64
+}
+
+// SearchIndexOptions : Options for SearchIndex.Refresh.
+type SearchIndexOptions struct {
+	// The IDs of the data products whose releases are indexed. Defaults to every data product
+	// (as listed by ListDataProducts).
+	DataProductIDs []string
+
+	// The states of the releases that are indexed. Defaults to available and retired.
+	States []string
+
+	// Retrieve and re-validate every listed release, rather than only the releases that are not indexed yet
+	// or whose listed state, version, name or description differs from the indexed release. A full refresh
+	// is needed to pick up the tags, domain and use cases patched with UpdateDataProductRelease.
+	Full bool
+}
+
+// SearchRefreshStats : The changes made to a SearchIndex by a refresh.
+type SearchRefreshStats struct {
+	// The number of releases listed by the service.
+	Listed int
+
+	// The number of releases that were not indexed yet and were added to the index.
+	Added int
+
+	// The number of indexed releases whose retrieved content changed, e.g. because they were retired or patched.
+	Updated int
+
+	// The number of releases that were removed from the index because they are no longer listed.
+	Removed int
+}
+
+// SearchQuery : A query of a SearchIndex.
+type SearchQuery struct {
+	// The keywords to search for. Every keyword must match a word of the indexed fields (case-insensitive).
+	// If empty, every release that matches the filters is returned, most recently published first.
+	Text string
+
+	// Only return releases in one of these states.
+	States []string
+
+	// Only return releases whose domain ID or name is one of these (case-insensitive for names).
+	Domains []string
+
+	// Only return releases with at least one of these types.
+	Types []string
+
+	// Only return releases whose is_restricted flag is this value (unset is treated as false).
+	IsRestricted *bool
+
+	// The maximum number of results. Defaults to every result.
+	Limit int
+}
+
+// SearchResult : A release that matches a SearchQuery.
+type SearchResult struct {
+	// The ID of the data product.
+	DataProductID string
+
+	// The ID of the release.
+	ReleaseID string
+
+	// The relevance of the release to the query; higher is better.
+	Score float64
+
+	// The indexed release.
+	Release *DataProductVersion
+}
+
+// NewSearchIndex returns an empty SearchIndex.
+func NewSearchIndex() *SearchIndex {
+	return &SearchIndex{
+		documents: make(map[string]*searchDocument),
+		postings:  make(map[string]map[string]struct{}),
+	}
+}
+
+// LoadSearchIndex reads a SearchIndex previously written by SearchIndex.Save.
+func LoadSearchIndex(reader io.Reader) (*SearchIndex, error) {
+	saved := &savedSearchIndex{}
+	err := json.NewDecoder(reader).Decode(saved)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling search index: %w", err)
+	}
+	index := NewSearchIndex()
+	for _, release := range saved.Releases {
+		index.add(release)
+	}
+	index.publishedThrough = saved.PublishedThrough
+	return index, nil
+}
+
+// savedSearchIndex is the JSON representation of a SearchIndex.
+type savedSearchIndex struct {
+	PublishedThrough time.Time             `json:"published_through"`
+	Releases         []*DataProductVersion `json:"releases"`
+}
+
+// Save writes the indexed releases to "writer" as JSON. Use LoadSearchIndex to read them.
+func (index *SearchIndex) Save(writer io.Writer) error {
+	index.mu.RLock()
+	saved := &savedSearchIndex{
+		PublishedThrough: index.publishedThrough,
+		Releases:         make([]*DataProductVersion, 0, len(index.documents)),
+	}
+	for _, key := range index.sortedKeys() {
+		saved.Releases = append(saved.Releases, index.documents[key].version)
+	}
+	index.mu.RUnlock()
+
+	err := json.NewEncoder(writer).Encode(saved)
+	if err != nil {
+		return fmt.Errorf("error writing search index: %w", err)
+	}
+	return nil
+}
+
+// Len returns the number of indexed releases.
+func (index *SearchIndex) Len() int {
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+	return len(index.documents)
+}
+
+// PublishedThrough returns the latest published_at of the indexed releases, or the zero time if the index is empty.
+func (index *SearchIndex) PublishedThrough() time.Time {
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+	return index.publishedThrough
+}
+
+// Refresh brings the index up to date with the service. It lists the releases of the data products with the
+// pagers and only retrieves the releases published since the previous refresh: the listed summaries carry no
+// published_at, but a release's published_at never changes, so a release that was published after
+// PublishedThrough is one that is not indexed yet. An indexed release is also retrieved again if its listed
+// state, version, name or description changed (e.g. because it was retired). The summaries do not reveal the
+// tags, domain and use cases patched with UpdateDataProductRelease; set options.Full to retrieve every listed
+// release and re-index those whose retrieved content differs from the indexed one. Releases that are no longer
+// listed are removed, and PublishedThrough is then recomputed from the releases that remain. Specify nil options
+// to index every available and retired release.
+func (index *SearchIndex) Refresh(ctx context.Context, client DpxV1API, options *SearchIndexOptions) (*SearchRefreshStats, error) {
+	if options == nil {
+		options = &SearchIndexOptions{}
+	}
+	states := options.States
+	if len(states) == 0 {
+		states = []string{ListDataProductReleasesOptions_State_Available, ListDataProductReleasesOptions_State_Retired}
+	}
+	dataProductIDs, err := listDataProductIDs(ctx, client, options.DataProductIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &SearchRefreshStats{}
+	listed := make(map[string]bool)
+	for _, dataProductID := range dataProductIDs {
+		releasesOptions := client.NewListDataProductReleasesOptions(dataProductID)
+		releasesOptions.SetState(states)
+		pager, err := client.NewDataProductReleasesPager(releasesOptions)
+		if err != nil {
+			return stats, err
+		}
+		releases, err := pager.GetAllWithContext(ctx)
+		if err != nil {
+			return stats, err
+		}
+
+		for _, summary := range releases {
+			releaseID := stringValue(summary.ID)
+			key := versionKey(dataProductID, releaseID)
+			listed[key] = true
+			stats.Listed++
+			if !options.Full && index.indexed(key, &summary) {
+				continue
+			}
+
+			release, _, err := client.GetDataProductReleaseWithContext(ctx, client.NewGetDataProductReleaseOptions(dataProductID, releaseID))
+			if err != nil {
+				return stats, err
+			}
+			if release.DataProduct == nil {
+				release.DataProduct = &DataProductIdentity{ID: &dataProductID}
+			}
+			switch index.update(key, release) {
+			case searchUpdateAdded:
+				stats.Added++
+			case searchUpdateChanged:
+				stats.Updated++
+			}
+		}
+	}
+
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	for _, key := range index.sortedKeys() {
+		dataProductID := stringValue(index.documents[key].version.DataProduct.ID)
+		if !listed[key] && (len(options.DataProductIDs) == 0 || slices.Contains(options.DataProductIDs, dataProductID)) {
+			index.remove(key)
+			stats.Removed++
+		}
+	}
+	index.publishedThrough = time.Time{}
+	for _, document := range index.documents {
+		if published := publishedAt(document.version); published.After(index.publishedThrough) {
+			index.publishedThrough = published
+		}
+	}
+	return stats, nil
+}
+
+// indexed returns true if the release listed as "summary" is indexed under "key" and its listed
+// state, version, name and description are those of the indexed release.
+func (index *SearchIndex) indexed(key string, summary *DataProductVersionSummary) bool {
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+	document := index.documents[key]
+	if document == nil {
+		return false
+	}
+	version := document.version
+	return stringValue(summary.State) == stringValue(version.State) &&
+		stringValue(summary.Version) == stringValue(version.Version) &&
+		stringValue(summary.Name) == stringValue(version.Name) &&
+		stringValue(summary.Description) == stringValue(version.Description)
+}
+
+// The outcomes of SearchIndex.update.
+const (
+	searchUpdateUnchanged = iota
+	searchUpdateAdded
+	searchUpdateChanged
+)
+
+// update indexes the retrieved "release" under "key" unless the indexed release has the same content,
+// and returns the outcome.
+func (index *SearchIndex) update(key string, release *DataProductVersion) int {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	document := index.documents[key]
+	if document == nil {
+		index.add(release)
+		return searchUpdateAdded
+	}
+	if sameRelease(document.version, release) {
+		return searchUpdateUnchanged
+	}
+	index.add(release)
+	return searchUpdateChanged
+}
+
+// sameRelease returns true if "a" and "b" have the same JSON representation.
+func sameRelease(a *DataProductVersion, b *DataProductVersion) bool {
+	bufA, errA := json.Marshal(a)
+	bufB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(bufA, bufB)
+}
+
+// Search returns the indexed releases that match "query", the most relevant first.
+func (index *SearchIndex) Search(query *SearchQuery) []SearchResult {
+	if query == nil {
+		query = &SearchQuery{}
+	}
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+
+	queryTerms := tokenize(query.Text)
+	var candidates map[string]struct{}
+	if len(queryTerms) == 0 {
+		candidates = make(map[string]struct{}, len(index.documents))
+		for key := range index.documents {
+			candidates[key] = struct{}{}
+		}
+	} else {
+		// Every term must match, so start from the rarest term.
+		sort.Slice(queryTerms, func(i, j int) bool { return len(index.postings[queryTerms[i]]) < len(index.postings[queryTerms[j]]) })
+		candidates = index.postings[queryTerms[0]]
+	}
+
+	results := []SearchResult{}
+	for key := range candidates {
+		document := index.documents[key]
+		if !query.matches(document.version) {
+			continue
+		}
+		score := 0.0
+		for _, term := range queryTerms {
+			frequency, ok := document.terms[term]
+			if !ok {
+				score = -1
+				break
+			}
+			idf := math.Log(1 + float64(len(index.documents))/float64(len(index.postings[term])))
+			score += frequency * idf
+		}
+		if score < 0 {
+			continue
+		}
+		results = append(results, SearchResult{
+			DataProductID: stringValue(document.version.DataProduct.ID),
+			ReleaseID:     stringValue(document.version.ID),
+			Score:         score,
+			Release:       document.version,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		publishedI, publishedJ := publishedAt(results[i].Release), publishedAt(results[j].Release)
+		if !publishedI.Equal(publishedJ) {
+			return publishedI.After(publishedJ)
+		}
+		return versionKey(results[i].DataProductID, results[i].ReleaseID) < versionKey(results[j].DataProductID, results[j].ReleaseID)
+	})
+	if query.Limit > 0 && len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results
+}
+
+// matches returns true if "release" satisfies the filters of the query.
+func (query *SearchQuery) matches(release *DataProductVersion) bool {
+	if len(query.States) > 0 && !slices.Contains(query.States, stringValue(release.State)) {
+		return false
+	}
+	if len(query.Domains) > 0 {
+		if release.Domain == nil {
+			return false
+		}
+		found := false
+		for _, domain := range query.Domains {
+			if domain == stringValue(release.Domain.ID) || strings.EqualFold(domain, stringValue(release.Domain.Name)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(query.Types) > 0 {
+		found := false
+		for _, releaseType := range release.Types {
+			if slices.Contains(query.Types, releaseType) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if query.IsRestricted != nil && *query.IsRestricted != (release.IsRestricted != nil && *release.IsRestricted) {
+		return false
+	}
+	return true
+}
+
+// add indexes "release", replacing any previous version of it. The caller must hold the write lock.
+func (index *SearchIndex) add(release *DataProductVersion) {
+	key := versionKey(stringValue(release.DataProduct.ID), stringValue(release.ID))
+	index.remove(key)
+
+	document := &searchDocument{
+		key:     key,
+		version: release,
+		terms:   make(map[string]float64),
+	}
+	addTerms := func(text string, weight float64) {
+		for _, term := range tokenize(text) {
+			document.terms[term] += weight
+		}
+	}
+	addTerms(stringValue(release.Name), searchWeightName)
+	addTerms(stringValue(release.Description), searchWeightDescription)
+	for _, tag := range release.Tags {
+		addTerms(tag, searchWeightTags)
+	}
+	if release.Domain != nil {
+		addTerms(stringValue(release.Domain.Name), searchWeightDomain)
+	}
+	for _, useCase := range release.UseCases {
+		addTerms(stringValue(useCase.Name), searchWeightUseCases)
+	}
+
+	index.documents[key] = document
+	for term := range document.terms {
+		if index.postings[term] == nil {
+			index.postings[term] = make(map[string]struct{})
+		}
+		index.postings[term][key] = struct{}{}
+	}
+	if published := publishedAt(release); published.After(index.publishedThrough) {
+		index.publishedThrough = published
+	}
+}
+
+// remove removes the release with the specified key from the index. The caller must hold the write lock.
+func (index *SearchIndex) remove(key string) {
+	document := index.documents[key]
+	if document == nil {
+		return
+	}
+	for term := range document.terms {
+		delete(index.postings[term], key)
+		if len(index.postings[term]) == 0 {
+			delete(index.postings, term)
+		}
+	}
+	delete(index.documents, key)
+}
+
+// sortedKeys returns the keys of the indexed releases in order. The caller must hold the lock.
+func (index *SearchIndex) sortedKeys() []string {
+	keys := make([]string, 0, len(index.documents))
+	for key := range index.documents {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// tokenize splits "text" into lower case words.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// publishedAt returns the published_at of "release", or the zero time if it is not set.
+func publishedAt(release *DataProductVersion) time.Time {
+	if release.PublishedAt == nil {
+		return time.Time{}
+	}
+	return time.Time(*release.PublishedAt)
+}
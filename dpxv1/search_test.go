@@ -0,0 +1,171 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`SearchIndex`, func() {
+	var server *catalogServer
+	var dpxService *dpxv1.DpxV1
+	var index *dpxv1.SearchIndex
+
+	release := func(dataProductID string, releaseID string, name string, description string, published string) *dpxv1.DataProductVersion {
+		version := testVersion(dataProductID, releaseID, dpxv1.DataProductVersion_State_Available, name)
+		version.Description = core.StringPtr(description)
+		publishedAt, err := core.ParseDateTime(published)
+		Expect(err).To(BeNil())
+		version.PublishedAt = &publishedAt
+		return version
+	}
+
+	countReleaseGets := func() (count int) {
+		for _, request := range server.requestLog() {
+			if strings.HasPrefix(request, "GET ") && strings.Count(request, "/") == 6 {
+				count++
+			}
+		}
+		return
+	}
+
+	BeforeEach(func() {
+		server = newCatalogServer()
+		dpxService = server.newService()
+		index = dpxv1.NewSearchIndex()
+
+		sales := release("product-1", "release-1", "Finance Sales", "Quarterly sales figures", "2024-01-10T00:00:00.000Z")
+		sales.Tags = []string{"finance", "quarterly"}
+		sales.Domain = &dpxv1.Domain{ID: core.StringPtr("domain-1"), Name: core.StringPtr("Sales")}
+		sales.Types = []string{"data"}
+		server.put(sales)
+
+		ledger := release("product-2", "release-2", "General Ledger", "Accounting entries used by finance", "2024-02-10T00:00:00.000Z")
+		ledger.Domain = &dpxv1.Domain{ID: core.StringPtr("domain-2"), Name: core.StringPtr("Accounting")}
+		ledger.UseCases = []dpxv1.UseCase{{ID: core.StringPtr("use-case-1"), Name: core.StringPtr("Month-end close")}}
+		ledger.Types = []string{"data", "code"}
+		ledger.IsRestricted = core.BoolPtr(true)
+		server.put(ledger)
+
+		server.put(testVersion("product-2", "draft-1", dpxv1.DataProductVersion_State_Draft, "Finance draft"))
+
+		stats, err := index.Refresh(context.Background(), dpxService, nil)
+		Expect(err).To(BeNil())
+		Expect(*stats).To(Equal(dpxv1.SearchRefreshStats{Listed: 2, Added: 2}))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Ranks the releases that match every keyword`, func() {
+		Expect(index.Len()).To(Equal(2))
+		Expect(index.PublishedThrough()).To(Equal(time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)))
+
+		results := index.Search(&dpxv1.SearchQuery{Text: "FINANCE"})
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].ReleaseID).To(Equal("release-1"))
+		Expect(results[0].DataProductID).To(Equal("product-1"))
+		Expect(results[0].Score).To(BeNumerically(">", results[1].Score))
+		Expect(*results[1].Release.Name).To(Equal("General Ledger"))
+
+		Expect(index.Search(&dpxv1.SearchQuery{Text: "month-end"})).To(HaveLen(1))
+		Expect(index.Search(&dpxv1.SearchQuery{Text: "accounting finance"})[0].ReleaseID).To(Equal("release-2"))
+		Expect(index.Search(&dpxv1.SearchQuery{Text: "finance payroll"})).To(BeEmpty())
+		Expect(index.Search(&dpxv1.SearchQuery{Text: "draft"})).To(BeEmpty())
+		Expect(index.Search(&dpxv1.SearchQuery{Text: "finance", Limit: 1})).To(HaveLen(1))
+	})
+	It(`Filters the results by state, domain, types and is_restricted`, func() {
+		all := index.Search(nil)
+		Expect(all).To(HaveLen(2))
+		Expect(all[0].ReleaseID).To(Equal("release-2"))
+
+		Expect(index.Search(&dpxv1.SearchQuery{Domains: []string{"sales"}})[0].ReleaseID).To(Equal("release-1"))
+		Expect(index.Search(&dpxv1.SearchQuery{Domains: []string{"domain-2"}})[0].ReleaseID).To(Equal("release-2"))
+		Expect(index.Search(&dpxv1.SearchQuery{Types: []string{"code"}})).To(HaveLen(1))
+		Expect(index.Search(&dpxv1.SearchQuery{Text: "finance", IsRestricted: core.BoolPtr(false)})[0].ReleaseID).To(Equal("release-1"))
+		Expect(index.Search(&dpxv1.SearchQuery{States: []string{dpxv1.DataProductVersion_State_Retired}})).To(BeEmpty())
+	})
+	It(`Refreshes incrementally`, func() {
+		Expect(countReleaseGets()).To(Equal(2))
+		stats, err := index.Refresh(context.Background(), dpxService, nil)
+		Expect(err).To(BeNil())
+		Expect(*stats).To(Equal(dpxv1.SearchRefreshStats{Listed: 2}))
+		Expect(countReleaseGets()).To(Equal(2))
+
+		server.put(release("product-1", "release-3", "Payroll", "Salaries", "2024-03-10T00:00:00.000Z"))
+		retired := release("product-2", "release-2", "General Ledger", "Accounting entries used by finance", "2024-02-10T00:00:00.000Z")
+		retired.State = core.StringPtr(dpxv1.DataProductVersion_State_Retired)
+		server.put(retired)
+		stats, err = index.Refresh(context.Background(), dpxService, nil)
+		Expect(err).To(BeNil())
+		Expect(*stats).To(Equal(dpxv1.SearchRefreshStats{Listed: 3, Added: 1, Updated: 1}))
+		Expect(countReleaseGets()).To(Equal(4))
+		Expect(index.PublishedThrough()).To(Equal(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)))
+		Expect(index.Search(&dpxv1.SearchQuery{Text: "ledger", States: []string{dpxv1.DataProductVersion_State_Retired}})).To(HaveLen(1))
+
+		server.remove("product-1", "release-1")
+		stats, err = index.Refresh(context.Background(), dpxService, nil)
+		Expect(err).To(BeNil())
+		Expect(stats.Removed).To(Equal(1))
+		Expect(index.Search(&dpxv1.SearchQuery{Text: "quarterly"})).To(BeEmpty())
+
+		server.remove("product-1", "release-3")
+		_, err = index.Refresh(context.Background(), dpxService, nil)
+		Expect(err).To(BeNil())
+		Expect(index.PublishedThrough()).To(Equal(time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)))
+	})
+	It(`Re-indexes the releases that were patched`, func() {
+		patched := release("product-1", "release-1", "Finance Sales", "Quarterly sales figures", "2024-01-10T00:00:00.000Z")
+		patched.Tags = []string{"revenue"}
+		patched.Domain = &dpxv1.Domain{ID: core.StringPtr("domain-3"), Name: core.StringPtr("Marketing")}
+		patched.UseCases = []dpxv1.UseCase{{ID: core.StringPtr("use-case-2"), Name: core.StringPtr("Forecasting")}}
+		patched.Types = []string{"data"}
+		server.put(patched)
+
+		stats, err := index.Refresh(context.Background(), dpxService, nil)
+		Expect(err).To(BeNil())
+		Expect(*stats).To(Equal(dpxv1.SearchRefreshStats{Listed: 2}))
+		Expect(index.Search(&dpxv1.SearchQuery{Text: "revenue"})).To(BeEmpty())
+
+		stats, err = index.Refresh(context.Background(), dpxService, &dpxv1.SearchIndexOptions{Full: true})
+		Expect(err).To(BeNil())
+		Expect(*stats).To(Equal(dpxv1.SearchRefreshStats{Listed: 2, Updated: 1}))
+		Expect(countReleaseGets()).To(Equal(4))
+		Expect(index.Search(&dpxv1.SearchQuery{Text: "quarterly finance"})).To(HaveLen(1))
+		Expect(index.Search(&dpxv1.SearchQuery{Text: "revenue marketing forecasting"})[0].ReleaseID).To(Equal("release-1"))
+		Expect(index.Search(&dpxv1.SearchQuery{Domains: []string{"sales"}})).To(BeEmpty())
+		Expect(index.Search(&dpxv1.SearchQuery{Domains: []string{"domain-3"}})).To(HaveLen(1))
+	})
+	It(`Saves and loads the index`, func() {
+		var buffer bytes.Buffer
+		Expect(index.Save(&buffer)).To(Succeed())
+
+		loaded, err := dpxv1.LoadSearchIndex(&buffer)
+		Expect(err).To(BeNil())
+		Expect(loaded.Len()).To(Equal(2))
+		Expect(loaded.PublishedThrough()).To(Equal(index.PublishedThrough()))
+		Expect(loaded.Search(&dpxv1.SearchQuery{Text: "finance"})[0].ReleaseID).To(Equal("release-1"))
+	})
+})
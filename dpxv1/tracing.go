@@ -0,0 +1,135 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"net/http"
+
+	common "github.com/IBM/data-product-exchange-go-sdk/common"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the OpenTelemetry tracer used to create spans for DpxV1 operations.
+const TracerName = "github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+
+// Span attribute keys set on the spans created for DpxV1 operations.
+// Path parameters are recorded as "dpx.<parameter name>", e.g. "dpx.data_product_id".
+const (
+	TracingAttributeOperation  = attribute.Key("dpx.operation")
+	TracingAttributeErrorCode  = attribute.Key("dpx.error_code")
+	TracingAttributeRetryCount = attribute.Key("dpx.retry_count")
+	TracingAttributeTrace      = attribute.Key("dpx.trace")
+	TracingAttributeDryRun     = attribute.Key("dpx.dry_run")
+	tracingAttributeMethod     = attribute.Key("http.request.method")
+	tracingAttributeURL        = attribute.Key("url.full")
+	tracingAttributeStatusCode = attribute.Key("http.response.status_code")
+)
+
+// TracingOptions : Options for OpenTelemetry tracing of DpxV1 operations.
+type TracingOptions struct {
+	// The TracerProvider used to create spans. Defaults to the global TracerProvider (otel.GetTracerProvider()).
+	TracerProvider trace.TracerProvider
+
+	// The propagator used to inject the trace context into outgoing request headers.
+	// Defaults to W3C Trace Context and Baggage propagation.
+	Propagator propagation.TextMapPropagator
+}
+
+// tracingInterceptor creates a span for each operation and propagates its context to the service.
+type tracingInterceptor struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// EnableTracing enables OpenTelemetry tracing for this service instance.
+// Each operation creates a client span named after the operation (e.g. "dpx.PublishDataProductDraft"),
+// and the span context is propagated to the service in the request headers.
+// Specify nil options to use the defaults.
+func (dpx *DpxV1) EnableTracing(options *TracingOptions) {
+	if options == nil {
+		options = &TracingOptions{}
+	}
+	tracerProvider := options.TracerProvider
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	propagator := options.Propagator
+	if propagator == nil {
+		propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	}
+	dpx.tracing = &tracingInterceptor{
+		tracer:     tracerProvider.Tracer(TracerName, trace.WithInstrumentationVersion(common.Version)),
+		propagator: propagator,
+	}
+}
+
+// DisableTracing disables OpenTelemetry tracing for this service instance.
+func (dpx *DpxV1) DisableTracing() {
+	dpx.tracing = nil
+}
+
+// IsTracingEnabled returns true if OpenTelemetry tracing is enabled for this service instance.
+func (dpx *DpxV1) IsTracingEnabled() bool {
+	return dpx.tracing != nil
+}
+
+// Intercept implements the Interceptor interface.
+func (interceptor *tracingInterceptor) Intercept(operation *Operation, request *http.Request, next Invoker) (response *core.DetailedResponse, err error) {
+	attributes := []attribute.KeyValue{
+		TracingAttributeOperation.String(operation.ID),
+		tracingAttributeMethod.String(request.Method),
+		tracingAttributeURL.String(request.URL.Redacted()),
+	}
+	for name, value := range operation.PathParams {
+		attributes = append(attributes, attribute.String("dpx."+name, value))
+	}
+
+	ctx, span := interceptor.tracer.Start(request.Context(), "dpx."+operation.ID,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...))
+	defer span.End()
+
+	request = request.WithContext(ctx)
+	interceptor.propagator.Inject(ctx, propagation.HeaderCarrier(request.Header))
+
+	response, err = next(request)
+
+	if stats := getCallStats(ctx); stats != nil {
+		span.SetAttributes(TracingAttributeRetryCount.Int(stats.getRetries()))
+	}
+	if response != nil {
+		span.SetAttributes(tracingAttributeStatusCode.Int(response.StatusCode))
+		if code := getResponseErrorCode(response); code != "" {
+			span.SetAttributes(TracingAttributeErrorCode.String(code))
+		}
+		if traceID := getResponseTrace(response); traceID != "" {
+			span.SetAttributes(TracingAttributeTrace.String(traceID))
+		}
+	}
+	if isDryRunError(err) {
+		span.SetAttributes(TracingAttributeDryRun.Bool(true))
+	} else if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return
+}
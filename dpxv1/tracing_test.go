@@ -0,0 +1,142 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe(`Tracing`, func() {
+	var testServer *httptest.Server
+	var requestCount int32
+	var traceparents chan string
+	var dpxService *dpxv1.DpxV1
+	var spanRecorder *tracetest.SpanRecorder
+	var tracerProvider *sdktrace.TracerProvider
+
+	BeforeEach(func() {
+		atomic.StoreInt32(&requestCount, 0)
+		traceparents = make(chan string, 10)
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			traceparents <- req.Header.Get("traceparent")
+			res.Header().Set("Content-type", "application/json")
+			switch {
+			case req.Method == http.MethodDelete:
+				res.WriteHeader(404)
+				fmt.Fprint(res, `{"errors": [{"code": "does_not_exist", "message": "Draft not found"}], "trace": "trace-123"}`)
+			case atomic.AddInt32(&requestCount, 1) == 1:
+				res.Header().Set("Retry-After", "0")
+				res.WriteHeader(503)
+				fmt.Fprint(res, `{"errors": [{"code": "unable_to_perform", "message": "Try again"}]}`)
+			default:
+				res.WriteHeader(200)
+				fmt.Fprint(res, `{"version": "1.0.0", "state": "available", "id": "release-1", "name": "My Data Product"}`)
+			}
+		}))
+		var serviceErr error
+		dpxService, serviceErr = dpxv1.NewDpxV1(&dpxv1.DpxV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(serviceErr).To(BeNil())
+
+		spanRecorder = tracetest.NewSpanRecorder()
+		tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+		dpxService.EnableTracing(&dpxv1.TracingOptions{TracerProvider: tracerProvider})
+		Expect(dpxService.IsTracingEnabled()).To(BeTrue())
+	})
+	AfterEach(func() {
+		testServer.Close()
+		_ = tracerProvider.Shutdown(context.Background())
+	})
+
+	attributesOf := func(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+		attributes := make(map[attribute.Key]attribute.Value)
+		for _, kv := range span.Attributes() {
+			attributes[kv.Key] = kv.Value
+		}
+		return attributes
+	}
+
+	It(`Creates a span per operation with path params, status and retry count`, func() {
+		dpxService.EnableRetries(2, 10*time.Millisecond)
+
+		ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "parent")
+		_, response, err := dpxService.GetDataProductReleaseWithContext(ctx, dpxService.NewGetDataProductReleaseOptions("product-1", "release-1"))
+		parent.End()
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(200))
+
+		spans := spanRecorder.Ended()
+		Expect(spans).To(HaveLen(2))
+		span := spans[0]
+		Expect(span.Name()).To(Equal("dpx.GetDataProductRelease"))
+		Expect(span.SpanKind()).To(Equal(trace.SpanKindClient))
+		Expect(span.Parent().TraceID()).To(Equal(parent.SpanContext().TraceID()))
+
+		attributes := attributesOf(span)
+		Expect(attributes[dpxv1.TracingAttributeOperation].AsString()).To(Equal("GetDataProductRelease"))
+		Expect(attributes["dpx.data_product_id"].AsString()).To(Equal("product-1"))
+		Expect(attributes["dpx.release_id"].AsString()).To(Equal("release-1"))
+		Expect(attributes["http.response.status_code"].AsInt64()).To(Equal(int64(200)))
+		Expect(attributes[dpxv1.TracingAttributeRetryCount].AsInt64()).To(Equal(int64(1)))
+
+		// Both attempts carry the W3C trace context of the operation's span.
+		Expect(<-traceparents).To(ContainSubstring(span.SpanContext().TraceID().String()))
+		Expect(<-traceparents).To(ContainSubstring(span.SpanContext().SpanID().String()))
+	})
+	It(`Records the error code and trace of a failed operation`, func() {
+		_, err := dpxService.DeleteDataProductDraft(dpxService.NewDeleteDataProductDraftOptions("product-1", "draft-1"))
+		Expect(err).ToNot(BeNil())
+
+		spans := spanRecorder.Ended()
+		Expect(spans).To(HaveLen(1))
+		span := spans[0]
+		Expect(span.Name()).To(Equal("dpx.DeleteDataProductDraft"))
+		Expect(span.Status().Code).To(Equal(codes.Error))
+
+		attributes := attributesOf(span)
+		Expect(attributes["dpx.draft_id"].AsString()).To(Equal("draft-1"))
+		Expect(attributes[dpxv1.TracingAttributeErrorCode].AsString()).To(Equal("does_not_exist"))
+		Expect(attributes[dpxv1.TracingAttributeTrace].AsString()).To(Equal("trace-123"))
+		Expect(attributes["http.response.status_code"].AsInt64()).To(Equal(int64(404)))
+		Expect(attributes[dpxv1.TracingAttributeRetryCount].AsInt64()).To(Equal(int64(0)))
+	})
+	It(`Can be disabled`, func() {
+		dpxService.DisableTracing()
+		Expect(dpxService.IsTracingEnabled()).To(BeFalse())
+		_, err := dpxService.DeleteDataProductDraft(dpxService.NewDeleteDataProductDraftOptions("product-1", "draft-1"))
+		Expect(err).ToNot(BeNil())
+		Expect(spanRecorder.Ended()).To(BeEmpty())
+		Expect(<-traceparents).To(BeEmpty())
+	})
+})
@@ -0,0 +1,377 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// UseCaseIndexOptions : Options for BuildUseCaseIndex.
+type UseCaseIndexOptions struct {
+	// The IDs of the data products whose versions are indexed. Defaults to every data product
+	// (as listed by ListDataProducts).
+	DataProductIDs []string
+
+	// The states of the releases that are indexed. Defaults to available.
+	States []string
+
+	// If true, the drafts are indexed too.
+	IncludeDrafts bool
+}
+
+// UseCaseReference : A data product version that references a use case.
+type UseCaseReference struct {
+	// The ID of the use case.
+	UseCaseID string `json:"use_case_id"`
+
+	// The ID of the container of the use case, if any.
+	ContainerID string `json:"container_id,omitempty"`
+
+	// The name of the use case, as referenced by the version.
+	Name string `json:"name,omitempty"`
+
+	// The ID of the data product.
+	DataProductID string `json:"data_product_id"`
+
+	// The ID of the release or draft.
+	VersionID string `json:"version_id"`
+
+	// The version number of the release or draft.
+	Version string `json:"version,omitempty"`
+
+	// The state of the release or draft.
+	State string `json:"state"`
+}
+
+// UseCaseImpact : A use case that a change (retiring a release, or publishing a draft) removes from a data product.
+type UseCaseImpact struct {
+	// The ID of the use case.
+	UseCaseID string `json:"use_case_id"`
+
+	// The ID of the container of the use case, if any.
+	ContainerID string `json:"container_id,omitempty"`
+
+	// The name of the use case.
+	Name string `json:"name,omitempty"`
+
+	// The data product that no longer supports the use case after the change.
+	DataProductID string `json:"data_product_id"`
+
+	// The available releases that still support the use case after the change.
+	Remaining []UseCaseReference `json:"remaining"`
+
+	// True if no available release supports the use case after the change.
+	Orphaned bool `json:"orphaned"`
+}
+
+// String returns a description of the impact.
+func (impact UseCaseImpact) String() string {
+	useCase := impact.UseCaseID
+	if impact.Name != "" {
+		useCase = fmt.Sprintf("%s (%s)", impact.Name, impact.UseCaseID)
+	}
+	if impact.Orphaned {
+		return fmt.Sprintf("use case %s is no longer supported by any data product", useCase)
+	}
+	dataProductIDs := make(map[string]bool)
+	for _, reference := range impact.Remaining {
+		dataProductIDs[reference.DataProductID] = true
+	}
+	return fmt.Sprintf("use case %s is no longer supported by data product %s; still supported by %s",
+		useCase, impact.DataProductID, strings.Join(sortedKeys(dataProductIDs), ", "))
+}
+
+// UseCaseIndex : A reverse index from use cases (by ID and container) to the data product versions that reference
+// them, to find which products support a use case without scanning every release, and to report the use cases
+// affected by retiring a release or publishing a draft. It is safe for concurrent use.
+type UseCaseIndex struct {
+	mu sync.RWMutex
+
+	// The indexed versions, by version key (see versionKey).
+	versions map[string]*useCaseIndexVersion
+
+	// The keys of the versions that reference each use case, by use case key (see useCaseKey).
+	useCases map[string]map[string]bool
+}
+
+// useCaseIndexVersion is a version of a UseCaseIndex.
+type useCaseIndexVersion struct {
+	version    string
+	state      string
+	references []UseCaseReference
+}
+
+// NewUseCaseIndex returns an empty index.
+func NewUseCaseIndex() *UseCaseIndex {
+	return &UseCaseIndex{
+		versions: make(map[string]*useCaseIndexVersion),
+		useCases: make(map[string]map[string]bool),
+	}
+}
+
+// BuildUseCaseIndex lists the releases (and optionally the drafts) of the data products with the pagers, and
+// retrieves each of them to index its use cases. Specify nil options to index every available release.
+func BuildUseCaseIndex(ctx context.Context, client DpxV1API, options *UseCaseIndexOptions) (*UseCaseIndex, error) {
+	if options == nil {
+		options = &UseCaseIndexOptions{}
+	}
+	states := options.States
+	if len(states) == 0 {
+		states = []string{ListDataProductReleasesOptions_State_Available}
+	}
+	dataProductIDs, err := listDataProductIDs(ctx, client, options.DataProductIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	index := NewUseCaseIndex()
+	for _, dataProductID := range dataProductIDs {
+		releasesOptions := client.NewListDataProductReleasesOptions(dataProductID)
+		releasesOptions.SetState(states)
+		releasesPager, err := client.NewDataProductReleasesPager(releasesOptions)
+		if err != nil {
+			return nil, err
+		}
+		releases, err := releasesPager.GetAllWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range releases {
+			release, _, err := client.GetDataProductReleaseWithContext(ctx,
+				client.NewGetDataProductReleaseOptions(dataProductID, stringValue(summary.ID)))
+			if err != nil {
+				return nil, err
+			}
+			index.AddVersion(release)
+		}
+
+		if !options.IncludeDrafts {
+			continue
+		}
+		draftsPager, err := client.NewDataProductDraftsPager(client.NewListDataProductDraftsOptions(dataProductID))
+		if err != nil {
+			return nil, err
+		}
+		drafts, err := draftsPager.GetAllWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range drafts {
+			draft, _, err := client.GetDataProductDraftWithContext(ctx,
+				client.NewGetDataProductDraftOptions(dataProductID, stringValue(summary.ID)))
+			if err != nil {
+				return nil, err
+			}
+			index.AddVersion(draft)
+		}
+	}
+	return index, nil
+}
+
+// AddVersion adds or replaces the use cases of a data product version in the index.
+func (index *UseCaseIndex) AddVersion(version *DataProductVersion) {
+	dataProductID := ""
+	if version.DataProduct != nil {
+		dataProductID = stringValue(version.DataProduct.ID)
+	}
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	key := versionKey(dataProductID, stringValue(version.ID))
+	index.removeLocked(key)
+
+	references := make([]UseCaseReference, 0, len(version.UseCases))
+	for _, useCase := range version.UseCases {
+		reference := UseCaseReference{
+			UseCaseID:     stringValue(useCase.ID),
+			ContainerID:   containerID(useCase.Container),
+			Name:          stringValue(useCase.Name),
+			DataProductID: dataProductID,
+			VersionID:     stringValue(version.ID),
+			Version:       stringValue(version.Version),
+			State:         stringValue(version.State),
+		}
+		references = append(references, reference)
+		indexKey := useCaseKey(reference.UseCaseID, reference.ContainerID)
+		if index.useCases[indexKey] == nil {
+			index.useCases[indexKey] = make(map[string]bool)
+		}
+		index.useCases[indexKey][key] = true
+	}
+	index.versions[key] = &useCaseIndexVersion{
+		version:    stringValue(version.Version),
+		state:      stringValue(version.State),
+		references: references,
+	}
+}
+
+// RemoveVersion removes a data product version from the index.
+func (index *UseCaseIndex) RemoveVersion(dataProductID string, versionID string) {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	index.removeLocked(versionKey(dataProductID, versionID))
+}
+
+func (index *UseCaseIndex) removeLocked(key string) {
+	if index.versions[key] == nil {
+		return
+	}
+	for _, reference := range index.versions[key].references {
+		indexKey := useCaseKey(reference.UseCaseID, reference.ContainerID)
+		delete(index.useCases[indexKey], key)
+		if len(index.useCases[indexKey]) == 0 {
+			delete(index.useCases, indexKey)
+		}
+	}
+	delete(index.versions, key)
+}
+
+// Lookup returns the versions that reference the use case "useCaseID" in the container "containerID" (or in any
+// container if it is empty), sorted by data product and version ID.
+func (index *UseCaseIndex) Lookup(useCaseID string, containerID string) []UseCaseReference {
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+	return index.lookupLocked(useCaseID, containerID, "")
+}
+
+// DataProducts returns the IDs of the data products with an available release that references the use case
+// "useCaseID" in the container "containerID" (or in any container if it is empty), sorted.
+func (index *UseCaseIndex) DataProducts(useCaseID string, containerID string) []string {
+	dataProductIDs := make(map[string]bool)
+	for _, reference := range index.Lookup(useCaseID, containerID) {
+		if reference.State == DataProductVersion_State_Available {
+			dataProductIDs[reference.DataProductID] = true
+		}
+	}
+	return sortedKeys(dataProductIDs)
+}
+
+// RetirementImpact returns the use cases that the data product no longer supports if the release is retired:
+// the use cases of the release that no other available release of the data product references. The release must
+// be in the index.
+func (index *UseCaseIndex) RetirementImpact(dataProductID string, releaseID string) []UseCaseImpact {
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+	key := versionKey(dataProductID, releaseID)
+	if index.versions[key] == nil {
+		return nil
+	}
+	return index.impactLocked(dataProductID, key, index.versions[key].references, nil, false)
+}
+
+// DraftImpact returns the use cases that a draft removes when it is published: the use cases of the latest
+// available release of its data product (in version order) that the draft does not reference. The data product
+// is then represented by the draft, so the remaining releases are those of other data products. The draft does
+// not need to be in the index.
+func (index *UseCaseIndex) DraftImpact(draft *DataProductVersion) []UseCaseImpact {
+	dataProductID := ""
+	if draft.DataProduct != nil {
+		dataProductID = stringValue(draft.DataProduct.ID)
+	}
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+
+	latestKey, latestVersion := "", ""
+	for key, version := range index.versions {
+		if !strings.HasPrefix(key, dataProductID+"/") || version.state != DataProductVersion_State_Available {
+			continue
+		}
+		if latestKey == "" || compareVersionNumbers(version.version, latestVersion) > 0 {
+			latestKey, latestVersion = key, version.version
+		}
+	}
+	if latestKey == "" {
+		return nil
+	}
+	return index.impactLocked(dataProductID, latestKey, index.versions[latestKey].references, draft.UseCases, true)
+}
+
+// impactLocked returns the impacts of removing the version "removedKey", whose use cases are "removed", from the
+// data product, except for the use cases in "kept". If "superseded" is true, the other versions of the data
+// product are ignored.
+func (index *UseCaseIndex) impactLocked(dataProductID string, removedKey string, removed []UseCaseReference, kept []UseCase, superseded bool) (impacts []UseCaseImpact) {
+	for _, reference := range removed {
+		if useCaseReferenced(kept, reference.UseCaseID, reference.ContainerID) {
+			continue
+		}
+		var remaining []UseCaseReference
+		stillSupported := false
+		for _, other := range index.lookupLocked(reference.UseCaseID, reference.ContainerID, removedKey) {
+			if other.State != DataProductVersion_State_Available || (superseded && other.DataProductID == dataProductID) {
+				continue
+			}
+			remaining = append(remaining, other)
+			stillSupported = stillSupported || other.DataProductID == dataProductID
+		}
+		if stillSupported {
+			continue
+		}
+		impacts = append(impacts, UseCaseImpact{
+			UseCaseID:     reference.UseCaseID,
+			ContainerID:   reference.ContainerID,
+			Name:          reference.Name,
+			DataProductID: dataProductID,
+			Remaining:     remaining,
+			Orphaned:      len(remaining) == 0,
+		})
+	}
+	return
+}
+
+// lookupLocked returns the references to a use case, except those of the version "excludedKey".
+func (index *UseCaseIndex) lookupLocked(useCaseID string, containerID string, excludedKey string) (references []UseCaseReference) {
+	for useCaseKey, keys := range index.useCases {
+		id, container, _ := strings.Cut(useCaseKey, "\x00")
+		if id != useCaseID || (containerID != "" && container != containerID) {
+			continue
+		}
+		for key := range keys {
+			if key == excludedKey {
+				continue
+			}
+			for _, reference := range index.versions[key].references {
+				if reference.UseCaseID == id && reference.ContainerID == container {
+					references = append(references, reference)
+				}
+			}
+		}
+	}
+	sort.Slice(references, func(i, j int) bool {
+		if references[i].DataProductID != references[j].DataProductID {
+			return references[i].DataProductID < references[j].DataProductID
+		}
+		return references[i].VersionID < references[j].VersionID
+	})
+	return
+}
+
+// useCaseReferenced returns true if "useCases" references the use case.
+func useCaseReferenced(useCases []UseCase, useCaseID string, useCaseContainerID string) bool {
+	for _, useCase := range useCases {
+		if stringValue(useCase.ID) == useCaseID && containerID(useCase.Container) == useCaseContainerID {
+			return true
+		}
+	}
+	return false
+}
+
+func useCaseKey(useCaseID string, containerID string) string {
+	return useCaseID + "\x00" + containerID
+}
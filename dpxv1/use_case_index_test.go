@@ -0,0 +1,114 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"context"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`UseCaseIndex`, func() {
+	var server *catalogServer
+	var dpxService *dpxv1.DpxV1
+
+	useCase := func(id string, containerID string, name string) dpxv1.UseCase {
+		return dpxv1.UseCase{ID: core.StringPtr(id), Name: core.StringPtr(name), Container: &dpxv1.ContainerReference{ID: core.StringPtr(containerID)}}
+	}
+
+	BeforeEach(func() {
+		server = newCatalogServer()
+		dpxService = server.newService()
+
+		sales := testVersion("product-1", "release-1", dpxv1.DataProductVersion_State_Available, "Sales")
+		sales.UseCases = []dpxv1.UseCase{useCase("forecasting", "catalog-1", "Forecasting"), useCase("churn", "catalog-1", "Churn")}
+		server.put(sales)
+		salesV2 := testVersion("product-1", "release-2", dpxv1.DataProductVersion_State_Available, "Sales")
+		salesV2.Version = core.StringPtr("2.0.0")
+		salesV2.UseCases = []dpxv1.UseCase{useCase("forecasting", "catalog-1", "Forecasting")}
+		server.put(salesV2)
+		ledger := testVersion("product-2", "release-3", dpxv1.DataProductVersion_State_Available, "Ledger")
+		ledger.UseCases = []dpxv1.UseCase{useCase("forecasting", "catalog-1", "Forecasting"), useCase("forecasting", "catalog-2", "Forecasting")}
+		server.put(ledger)
+		draft := testVersion("product-2", "draft-1", dpxv1.DataProductVersion_State_Draft, "Ledger")
+		draft.UseCases = []dpxv1.UseCase{useCase("audit", "catalog-1", "Audit")}
+		server.put(draft)
+		server.put(testVersion("product-3", "release-4", dpxv1.DataProductVersion_State_Available, "Archive"))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Finds the products and releases that reference a use case`, func() {
+		index, err := dpxv1.BuildUseCaseIndex(context.Background(), dpxService, &dpxv1.UseCaseIndexOptions{IncludeDrafts: true})
+		Expect(err).To(BeNil())
+		Expect(index.DataProducts("forecasting", "")).To(Equal([]string{"product-1", "product-2"}))
+		Expect(index.DataProducts("forecasting", "catalog-2")).To(Equal([]string{"product-2"}))
+		Expect(index.DataProducts("audit", "")).To(BeEmpty())
+		Expect(index.Lookup("audit", "catalog-1")).To(Equal([]dpxv1.UseCaseReference{{
+			UseCaseID:     "audit",
+			ContainerID:   "catalog-1",
+			Name:          "Audit",
+			DataProductID: "product-2",
+			VersionID:     "draft-1",
+			Version:       "1.0.0",
+			State:         dpxv1.DataProductVersion_State_Draft,
+		}}))
+		Expect(index.Lookup("forecasting", "catalog-1")).To(HaveLen(3))
+
+		index.RemoveVersion("product-2", "release-3")
+		Expect(index.DataProducts("forecasting", "")).To(Equal([]string{"product-1"}))
+		Expect(index.Lookup("forecasting", "catalog-2")).To(BeEmpty())
+	})
+	It(`Reports the use cases affected by retiring a release`, func() {
+		index, err := dpxv1.BuildUseCaseIndex(context.Background(), dpxService, nil)
+		Expect(err).To(BeNil())
+		Expect(index.RetirementImpact("product-1", "release-2")).To(BeEmpty())
+
+		impacts := index.RetirementImpact("product-1", "release-1")
+		Expect(impacts).To(HaveLen(1))
+		Expect(impacts[0].UseCaseID).To(Equal("churn"))
+		Expect(impacts[0].Orphaned).To(BeTrue())
+		Expect(impacts[0].String()).To(Equal("use case Churn (churn) is no longer supported by any data product"))
+
+		impacts = index.RetirementImpact("product-2", "release-3")
+		Expect(impacts).To(HaveLen(2))
+		Expect(impacts[0].String()).To(Equal("use case Forecasting (forecasting) is no longer supported by data product product-2; still supported by product-1"))
+		Expect(impacts[0].Remaining).To(HaveLen(2))
+		Expect(impacts[1].ContainerID).To(Equal("catalog-2"))
+		Expect(impacts[1].Orphaned).To(BeTrue())
+	})
+	It(`Reports the use cases that a draft removes from the latest release`, func() {
+		index, err := dpxv1.BuildUseCaseIndex(context.Background(), dpxService, nil)
+		Expect(err).To(BeNil())
+
+		draft := testVersion("product-1", "draft-2", dpxv1.DataProductVersion_State_Draft, "Sales")
+		impacts := index.DraftImpact(draft)
+		Expect(impacts).To(HaveLen(1))
+		Expect(impacts[0].UseCaseID).To(Equal("forecasting"))
+		Expect(impacts[0].DataProductID).To(Equal("product-1"))
+		Expect(impacts[0].Remaining).To(HaveLen(1))
+		Expect(impacts[0].Remaining[0].DataProductID).To(Equal("product-2"))
+
+		draft.UseCases = []dpxv1.UseCase{useCase("forecasting", "catalog-1", "Forecasting")}
+		Expect(index.DraftImpact(draft)).To(BeEmpty())
+		Expect(index.DraftImpact(testVersion("product-9", "draft-1", dpxv1.DataProductVersion_State_Draft, "New"))).To(BeEmpty())
+	})
+})
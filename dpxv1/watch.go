@@ -0,0 +1,508 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultWatchInterval is the default interval at which Watch polls the service for changes.
+const DefaultWatchInterval = 30 * time.Second
+
+// EventType : The type of a change detected by Watch.
+type EventType string
+
+// Constants associated with the EventType.
+const (
+	// A release was published (Before is the draft, if it was known).
+	EventReleasePublished EventType = "release_published"
+
+	// A release was retired.
+	EventReleaseRetired EventType = "release_retired"
+
+	// The name, description or asset of an available release changed.
+	EventReleaseUpdated EventType = "release_updated"
+
+	// A draft was created.
+	EventDraftCreated EventType = "draft_created"
+
+	// A draft was deleted, with its data product or not (or replaced by a release with a different ID).
+	EventDraftDeleted EventType = "draft_deleted"
+
+	// The service could not be polled; Err contains the error. Polling continues at the next interval.
+	EventError EventType = "error"
+)
+
+// Event : A change to a data product version detected by Watch.
+type Event struct {
+	// The type of the change.
+	Type EventType
+
+	// The ID of the data product.
+	DataProductID string
+
+	// The ID of the data product version (draft or release) that changed.
+	VersionID string
+
+	// The version before the change, or nil if it was not previously known.
+	Before *DataProductVersionSummary
+
+	// The version after the change, or nil if it was deleted.
+	After *DataProductVersionSummary
+
+	// The time at which the change was detected.
+	Time time.Time
+
+	// The polling error of an EventError event.
+	Err error
+}
+
+// WatchFilter : Options for Watch.
+type WatchFilter struct {
+	// The IDs of the data products to watch. Defaults to every data product (as listed by ListDataProducts).
+	DataProductIDs []string
+
+	// The types of events to emit. Defaults to every type.
+	EventTypes []EventType
+
+	// The interval at which the service is polled. Defaults to DefaultWatchInterval.
+	Interval time.Duration
+
+	// The store in which the last observed state is persisted, so that changes which occur while the watcher
+	// is not running are emitted when it restarts, and changes are not emitted twice. Defaults to an in-memory
+	// store, which does not survive restarts (see NewFileWatchStateStore).
+	StateStore WatchStateStore
+
+	// If true, the first poll without a previously stored state emits DraftCreated and ReleasePublished events
+	// for the existing versions. Otherwise, the first poll only records the existing versions.
+	EmitInitial bool
+}
+
+// WatchState : The last state observed by Watch.
+type WatchState struct {
+	// The observed data product versions (drafts and releases), keyed by "<data product ID>/<version ID>".
+	Versions map[string]DataProductVersionSummary `json:"versions"`
+
+	// The time of the last change to the state.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WatchStateStore is implemented by types that persist the state observed by Watch.
+type WatchStateStore interface {
+	// LoadWatchState returns the stored state, or nil if no state has been stored.
+	LoadWatchState() (*WatchState, error)
+
+	// SaveWatchState stores "state".
+	SaveWatchState(state *WatchState) error
+}
+
+// memoryWatchStateStore is the default WatchStateStore, which keeps the state in memory.
+type memoryWatchStateStore struct {
+	state *WatchState
+}
+
+func (store *memoryWatchStateStore) LoadWatchState() (*WatchState, error) {
+	return store.state, nil
+}
+
+func (store *memoryWatchStateStore) SaveWatchState(state *WatchState) error {
+	store.state = state
+	return nil
+}
+
+// fileWatchStateStore is a WatchStateStore that persists the state in a JSON file.
+type fileWatchStateStore struct {
+	path string
+}
+
+// NewFileWatchStateStore returns a WatchStateStore that persists the state in the JSON file at "path".
+func NewFileWatchStateStore(path string) WatchStateStore {
+	return &fileWatchStateStore{path: path}
+}
+
+func (store *fileWatchStateStore) LoadWatchState() (*WatchState, error) {
+	data, err := os.ReadFile(store.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading watch state: %w", err)
+	}
+	state := &WatchState{}
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling watch state %s: %w", store.path, err)
+	}
+	return state, nil
+}
+
+func (store *fileWatchStateStore) SaveWatchState(state *WatchState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("error marshalling watch state: %w", err)
+	}
+	err = writeFileAtomic(store.path, data)
+	if err != nil {
+		return fmt.Errorf("error writing watch state: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes "data" to a temporary file and then renames it to "path", so that an interrupted
+// write does not leave a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = tempFile.Write(data)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempFile.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tempFile.Name())
+	}
+	return err
+}
+
+// Watch periodically polls the data products, releases and drafts of the service and emits an Event on
+// the returned channel for each change. The channel is closed when "ctx" is done.
+//
+// The state is saved to filter.StateStore after each event is received from the channel, so an event
+// is emitted again after a restart only if the watcher stopped before the event was received.
+// Specify a nil filter to watch every data product with the defaults.
+func (dpx *DpxV1) Watch(ctx context.Context, filter *WatchFilter) <-chan Event {
+	return watch(ctx, dpx, filter)
+}
+
+// watcher holds the state of a call to Watch.
+type watcher struct {
+	client     DpxV1API
+	filter     *WatchFilter
+	store      WatchStateStore
+	eventTypes map[EventType]bool
+	events     chan Event
+	state      *WatchState
+}
+
+func watch(ctx context.Context, client DpxV1API, filter *WatchFilter) <-chan Event {
+	if filter == nil {
+		filter = &WatchFilter{}
+	}
+	w := &watcher{
+		client: client,
+		filter: filter,
+		store:  filter.StateStore,
+		events: make(chan Event),
+	}
+	if w.store == nil {
+		w.store = &memoryWatchStateStore{}
+	}
+	if len(filter.EventTypes) > 0 {
+		w.eventTypes = make(map[EventType]bool, len(filter.EventTypes))
+		for _, eventType := range filter.EventTypes {
+			w.eventTypes[eventType] = true
+		}
+	}
+	interval := filter.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	go func() {
+		defer close(w.events)
+
+		state, err := w.store.LoadWatchState()
+		if err != nil {
+			w.emit(ctx, Event{Type: EventError, Time: time.Now(), Err: err})
+		}
+		baseline := state == nil && !filter.EmitInitial
+		if state == nil {
+			state = &WatchState{}
+		}
+		if state.Versions == nil {
+			state.Versions = make(map[string]DataProductVersionSummary)
+		}
+		w.state = state
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if !w.poll(ctx, baseline) {
+				return
+			}
+			baseline = false
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return w.events
+}
+
+// poll lists the watched versions and emits an event for each change. If "baseline" is true, the versions
+// are recorded without emitting events. It returns false if "ctx" is done.
+func (w *watcher) poll(ctx context.Context, baseline bool) bool {
+	dataProductIDs, err := listDataProductIDs(ctx, w.client, w.filter.DataProductIDs)
+	if err != nil {
+		return ctx.Err() == nil && w.emit(ctx, Event{Type: EventError, Time: time.Now(), Err: err})
+	}
+
+	listed := make(map[string]bool, len(dataProductIDs))
+	for _, dataProductID := range dataProductIDs {
+		listed[dataProductID] = true
+		current, err := w.listVersions(ctx, dataProductID)
+		if err != nil && w.isDeleted(ctx, dataProductID) {
+			if !baseline && !w.emitVanished(ctx, dataProductID) {
+				return false
+			}
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil || !w.emit(ctx, Event{Type: EventError, DataProductID: dataProductID, Time: time.Now(), Err: err}) {
+				return false
+			}
+			continue
+		}
+
+		if baseline {
+			for versionID, version := range current {
+				w.state.Versions[versionKey(dataProductID, versionID)] = version
+			}
+			continue
+		}
+		for _, event := range w.diff(dataProductID, current) {
+			if !w.emit(ctx, event) {
+				return false
+			}
+		}
+	}
+
+	// The data products that are no longer listed were deleted.
+	if len(w.filter.DataProductIDs) == 0 && !baseline {
+		for _, dataProductID := range w.storedDataProductIDs() {
+			if !listed[dataProductID] && !w.emitVanished(ctx, dataProductID) {
+				return false
+			}
+		}
+	}
+
+	if baseline {
+		w.state.UpdatedAt = time.Now()
+		if err := w.store.SaveWatchState(w.state); err != nil {
+			return w.emit(ctx, Event{Type: EventError, Time: time.Now(), Err: err})
+		}
+	}
+	return true
+}
+
+// listDataProductIDs returns "dataProductIDs" if it is not empty, or else the IDs of every data product.
+func listDataProductIDs(ctx context.Context, client DpxV1API, dataProductIDs []string) ([]string, error) {
+	if len(dataProductIDs) > 0 {
+		return dataProductIDs, nil
+	}
+	pager, err := client.NewDataProductsPager(client.NewListDataProductsOptions())
+	if err != nil {
+		return nil, err
+	}
+	dataProducts, err := pager.GetAllWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dataProductIDs = make([]string, 0, len(dataProducts))
+	for _, dataProduct := range dataProducts {
+		dataProductIDs = append(dataProductIDs, stringValue(dataProduct.ID))
+	}
+	return dataProductIDs, nil
+}
+
+// listVersions returns the releases and drafts of a data product, by version ID.
+func (w *watcher) listVersions(ctx context.Context, dataProductID string) (map[string]DataProductVersionSummary, error) {
+	releasesOptions := w.client.NewListDataProductReleasesOptions(dataProductID)
+	releasesOptions.SetState([]string{ListDataProductReleasesOptions_State_Available, ListDataProductReleasesOptions_State_Retired})
+	releasesPager, err := w.client.NewDataProductReleasesPager(releasesOptions)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := releasesPager.GetAllWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	draftsPager, err := w.client.NewDataProductDraftsPager(w.client.NewListDataProductDraftsOptions(dataProductID))
+	if err != nil {
+		return nil, err
+	}
+	drafts, err := draftsPager.GetAllWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]DataProductVersionSummary, len(releases)+len(drafts))
+	for _, version := range append(drafts, releases...) {
+		versions[stringValue(version.ID)] = version
+	}
+	return versions, nil
+}
+
+// diff returns the events for the changes between the stored versions of a data product and "current".
+func (w *watcher) diff(dataProductID string, current map[string]DataProductVersionSummary) (events []Event) {
+	now := time.Now()
+	newEvent := func(eventType EventType, versionID string, before *DataProductVersionSummary, after *DataProductVersionSummary) Event {
+		return Event{Type: eventType, DataProductID: dataProductID, VersionID: versionID, Before: before, After: after, Time: now}
+	}
+
+	versionIDs := make([]string, 0, len(current))
+	for versionID := range current {
+		versionIDs = append(versionIDs, versionID)
+	}
+	sort.Strings(versionIDs)
+	for _, versionID := range versionIDs {
+		after := current[versionID]
+		var before *DataProductVersionSummary
+		if stored, ok := w.state.Versions[versionKey(dataProductID, versionID)]; ok {
+			before = &stored
+		}
+
+		var eventType EventType
+		switch state := stringValue(after.State); {
+		case before == nil && state == DataProductVersionSummary_State_Draft:
+			eventType = EventDraftCreated
+		case before == nil && state == DataProductVersionSummary_State_Available:
+			eventType = EventReleasePublished
+		case before == nil && state == DataProductVersionSummary_State_Retired:
+			eventType = EventReleaseRetired
+		case before == nil:
+			continue
+		case stringValue(before.State) == DataProductVersionSummary_State_Draft && state == DataProductVersionSummary_State_Available:
+			eventType = EventReleasePublished
+		case stringValue(before.State) != DataProductVersionSummary_State_Retired && state == DataProductVersionSummary_State_Retired:
+			eventType = EventReleaseRetired
+		case stringValue(before.State) == DataProductVersionSummary_State_Available && state == DataProductVersionSummary_State_Available &&
+			!reflect.DeepEqual(*before, after):
+			eventType = EventReleaseUpdated
+		default:
+			if !reflect.DeepEqual(*before, after) {
+				// Record changes that don't produce an event (e.g. to a draft) without emitting anything.
+				events = append(events, Event{DataProductID: dataProductID, VersionID: versionID, After: &after})
+			}
+			continue
+		}
+		events = append(events, newEvent(eventType, versionID, before, &after))
+	}
+
+	var deletedIDs []string
+	for key, stored := range w.state.Versions {
+		versionID, found := strings.CutPrefix(key, dataProductID+"/")
+		if _, ok := current[versionID]; found && !ok && stringValue(stored.State) == DataProductVersionSummary_State_Draft {
+			deletedIDs = append(deletedIDs, versionID)
+		}
+	}
+	sort.Strings(deletedIDs)
+	for _, versionID := range deletedIDs {
+		before := w.state.Versions[versionKey(dataProductID, versionID)]
+		events = append(events, newEvent(EventDraftDeleted, versionID, &before, nil))
+	}
+	return
+}
+
+// isDeleted returns true if the data product "dataProductID" does not exist (anymore).
+func (w *watcher) isDeleted(ctx context.Context, dataProductID string) bool {
+	_, response, _ := w.client.GetDataProductWithContext(ctx, w.client.NewGetDataProductOptions(dataProductID))
+	return isNotFound(response)
+}
+
+// storedDataProductIDs returns the IDs of the data products of the stored versions, sorted.
+func (w *watcher) storedDataProductIDs() []string {
+	dataProductIDs := make(map[string]bool)
+	for key := range w.state.Versions {
+		if dataProductID, _, found := strings.Cut(key, "/"); found {
+			dataProductIDs[dataProductID] = true
+		}
+	}
+	return sortedKeys(dataProductIDs)
+}
+
+// emitVanished emits a DraftDeleted event for each stored draft of a data product that was deleted, and
+// forgets its stored releases. It returns false if "ctx" is done.
+func (w *watcher) emitVanished(ctx context.Context, dataProductID string) bool {
+	events := w.diff(dataProductID, nil)
+	var releaseIDs []string
+	for key, stored := range w.state.Versions {
+		versionID, found := strings.CutPrefix(key, dataProductID+"/")
+		if found && stringValue(stored.State) != DataProductVersionSummary_State_Draft {
+			releaseIDs = append(releaseIDs, versionID)
+		}
+	}
+	sort.Strings(releaseIDs)
+	for _, versionID := range releaseIDs {
+		// Events without a type only update the state.
+		events = append(events, Event{DataProductID: dataProductID, VersionID: versionID})
+	}
+	for _, event := range events {
+		if !w.emit(ctx, event) {
+			return false
+		}
+	}
+	return true
+}
+
+// emit sends "event" (unless its type is filtered out) and then records the change in the stored state.
+// Events without a type only update the state. It returns false if "ctx" is done before the event is received.
+func (w *watcher) emit(ctx context.Context, event Event) bool {
+	if event.Type != "" && (w.eventTypes == nil || w.eventTypes[event.Type] || event.Type == EventError) {
+		select {
+		case w.events <- event:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	if event.Type == EventError || event.VersionID == "" {
+		return true
+	}
+
+	key := versionKey(event.DataProductID, event.VersionID)
+	if event.After != nil {
+		w.state.Versions[key] = *event.After
+	} else {
+		delete(w.state.Versions, key)
+	}
+	w.state.UpdatedAt = event.Time
+	if err := w.store.SaveWatchState(w.state); err != nil {
+		return w.emit(ctx, Event{Type: EventError, Time: time.Now(), Err: err})
+	}
+	return true
+}
+
+// versionKey returns the key of a data product version in WatchState.Versions and in a SearchIndex.
+func versionKey(dataProductID string, versionID string) string {
+	return dataProductID + "/" + versionID
+}
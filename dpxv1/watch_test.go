@@ -0,0 +1,206 @@
+/**
+ * (C) Copyright IBM Corp. 2024.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dpxv1_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/IBM/data-product-exchange-go-sdk/dpxv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Watch`, func() {
+	var server *catalogServer
+	var dpxService *dpxv1.DpxV1
+	var ctx context.Context
+	var cancel context.CancelFunc
+
+	BeforeEach(func() {
+		server = newCatalogServer()
+		dpxService = server.newService()
+		ctx, cancel = context.WithCancel(context.Background())
+		server.put(testVersion("product-1", "release-1", dpxv1.DataProductVersion_State_Available, "Sales"))
+	})
+	AfterEach(func() {
+		cancel()
+		server.Close()
+	})
+
+	nextEvent := func(events <-chan dpxv1.Event) dpxv1.Event {
+		var event dpxv1.Event
+		Eventually(events, 5*time.Second).Should(Receive(&event))
+		return event
+	}
+
+	It(`Emits an event for each change after the baseline`, func() {
+		events := dpxService.Watch(ctx, &dpxv1.WatchFilter{Interval: 10 * time.Millisecond})
+		Consistently(events, 50*time.Millisecond).ShouldNot(Receive())
+
+		server.put(testVersion("product-1", "draft-1", dpxv1.DataProductVersion_State_Draft, "Sales"))
+		event := nextEvent(events)
+		Expect(event.Type).To(Equal(dpxv1.EventDraftCreated))
+		Expect(event.DataProductID).To(Equal("product-1"))
+		Expect(event.VersionID).To(Equal("draft-1"))
+		Expect(event.Before).To(BeNil())
+
+		server.put(testVersion("product-1", "draft-1", dpxv1.DataProductVersion_State_Available, "Sales"))
+		event = nextEvent(events)
+		Expect(event.Type).To(Equal(dpxv1.EventReleasePublished))
+		Expect(*event.Before.State).To(Equal(dpxv1.DataProductVersionSummary_State_Draft))
+		Expect(*event.After.State).To(Equal(dpxv1.DataProductVersionSummary_State_Available))
+
+		server.put(testVersion("product-1", "release-1", dpxv1.DataProductVersion_State_Retired, "Sales"))
+		event = nextEvent(events)
+		Expect(event.Type).To(Equal(dpxv1.EventReleaseRetired))
+		Expect(event.VersionID).To(Equal("release-1"))
+
+		server.put(testVersion("product-1", "draft-1", dpxv1.DataProductVersion_State_Available, "Sales and Returns"))
+		event = nextEvent(events)
+		Expect(event.Type).To(Equal(dpxv1.EventReleaseUpdated))
+		Expect(*event.Before.Name).To(Equal("Sales"))
+		Expect(*event.After.Name).To(Equal("Sales and Returns"))
+
+		server.put(testVersion("product-1", "draft-2", dpxv1.DataProductVersion_State_Draft, "Sales"))
+		Expect(nextEvent(events).Type).To(Equal(dpxv1.EventDraftCreated))
+		server.remove("product-1", "draft-2")
+		event = nextEvent(events)
+		Expect(event.Type).To(Equal(dpxv1.EventDraftDeleted))
+		Expect(event.VersionID).To(Equal("draft-2"))
+		Expect(event.After).To(BeNil())
+
+		Consistently(events, 50*time.Millisecond).ShouldNot(Receive())
+		cancel()
+		Eventually(events).Should(BeClosed())
+	})
+	It(`Emits the existing versions if EmitInitial is set and filters events by type`, func() {
+		server.put(testVersion("product-1", "draft-1", dpxv1.DataProductVersion_State_Draft, "Sales"))
+		events := dpxService.Watch(ctx, &dpxv1.WatchFilter{
+			DataProductIDs: []string{"product-1"},
+			EventTypes:     []dpxv1.EventType{dpxv1.EventReleasePublished},
+			Interval:       10 * time.Millisecond,
+			EmitInitial:    true,
+		})
+		event := nextEvent(events)
+		Expect(event.Type).To(Equal(dpxv1.EventReleasePublished))
+		Expect(event.VersionID).To(Equal("release-1"))
+		Consistently(events, 50*time.Millisecond).ShouldNot(Receive())
+		Expect(server.requestLog()).ToNot(ContainElement(MatchRegexp(`^GET /data_product_exchange/v1/data_products(\?|$)`)))
+	})
+	It(`Emits the changes that occurred while it was stopped when the state is persisted`, func() {
+		dir, err := os.MkdirTemp("", "dpx-watch")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		store := dpxv1.NewFileWatchStateStore(filepath.Join(dir, "watch-state.json"))
+		filter := &dpxv1.WatchFilter{Interval: 10 * time.Millisecond, StateStore: store}
+
+		firstCtx, firstCancel := context.WithCancel(ctx)
+		events := dpxService.Watch(firstCtx, filter)
+		Eventually(func() (*dpxv1.WatchState, error) { return store.LoadWatchState() }).ShouldNot(BeNil())
+		server.put(testVersion("product-1", "draft-1", dpxv1.DataProductVersion_State_Draft, "Sales"))
+		Expect(nextEvent(events).VersionID).To(Equal("draft-1"))
+		firstCancel()
+		Eventually(events).Should(BeClosed())
+
+		server.put(testVersion("product-1", "release-1", dpxv1.DataProductVersion_State_Retired, "Sales"))
+		events = dpxService.Watch(ctx, filter)
+		event := nextEvent(events)
+		Expect(event.Type).To(Equal(dpxv1.EventReleaseRetired))
+		Expect(event.VersionID).To(Equal("release-1"))
+		Consistently(events, 50*time.Millisecond).ShouldNot(Receive())
+
+		state, err := store.LoadWatchState()
+		Expect(err).To(BeNil())
+		Expect(state.Versions).To(HaveKey("product-1/draft-1"))
+		Expect(*state.Versions["product-1/release-1"].State).To(Equal(dpxv1.DataProductVersionSummary_State_Retired))
+	})
+	It(`Emits DraftDeleted for every known draft of a deleted data product`, func() {
+		server.put(testVersion("product-2", "release-2", dpxv1.DataProductVersion_State_Available, "Ledger"))
+		server.put(testVersion("product-2", "draft-2", dpxv1.DataProductVersion_State_Draft, "Ledger"))
+		server.put(testVersion("product-2", "draft-3", dpxv1.DataProductVersion_State_Draft, "Ledger"))
+		store := &recordingWatchStateStore{}
+		events := dpxService.Watch(ctx, &dpxv1.WatchFilter{Interval: 10 * time.Millisecond, StateStore: store})
+		Consistently(events, 50*time.Millisecond).ShouldNot(Receive())
+
+		server.removeDataProduct("product-2")
+		event := nextEvent(events)
+		Expect(event.Type).To(Equal(dpxv1.EventDraftDeleted))
+		Expect(event.DataProductID).To(Equal("product-2"))
+		Expect(event.VersionID).To(Equal("draft-2"))
+		Expect(*event.Before.State).To(Equal(dpxv1.DataProductVersionSummary_State_Draft))
+		Expect(event.After).To(BeNil())
+		event = nextEvent(events)
+		Expect(event.Type).To(Equal(dpxv1.EventDraftDeleted))
+		Expect(event.VersionID).To(Equal("draft-3"))
+		Consistently(events, 50*time.Millisecond).ShouldNot(Receive())
+		Expect(store.versionKeys()).To(Equal([]string{"product-1/release-1"}))
+	})
+	It(`Emits DraftDeleted for a watched data product that was deleted`, func() {
+		server.put(testVersion("product-1", "draft-1", dpxv1.DataProductVersion_State_Draft, "Sales"))
+		events := dpxService.Watch(ctx, &dpxv1.WatchFilter{DataProductIDs: []string{"product-1"}, Interval: 10 * time.Millisecond})
+		Consistently(events, 50*time.Millisecond).ShouldNot(Receive())
+
+		server.removeDataProduct("product-1")
+		event := nextEvent(events)
+		Expect(event.Type).To(Equal(dpxv1.EventDraftDeleted))
+		Expect(event.VersionID).To(Equal("draft-1"))
+		Consistently(events, 50*time.Millisecond).ShouldNot(Receive())
+	})
+	It(`Emits an error event when the service cannot be polled`, func() {
+		server.Close()
+		events := dpxService.Watch(ctx, &dpxv1.WatchFilter{
+			DataProductIDs: []string{"product-1"},
+			Interval:       10 * time.Millisecond,
+		})
+		event := nextEvent(events)
+		Expect(event.Type).To(Equal(dpxv1.EventError))
+		Expect(event.DataProductID).To(Equal("product-1"))
+		Expect(event.Err).ToNot(BeNil())
+	})
+})
+
+// recordingWatchStateStore is an in-memory WatchStateStore whose state can be inspected concurrently.
+type recordingWatchStateStore struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (store *recordingWatchStateStore) LoadWatchState() (*dpxv1.WatchState, error) {
+	return nil, nil
+}
+
+func (store *recordingWatchStateStore) SaveWatchState(state *dpxv1.WatchState) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.keys = store.keys[:0]
+	for key := range state.Versions {
+		store.keys = append(store.keys, key)
+	}
+	sort.Strings(store.keys)
+	return nil
+}
+
+func (store *recordingWatchStateStore) versionKeys() []string {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return append([]string(nil), store.keys...)
+}